@@ -2,5 +2,9 @@
 GO_LOCATION=$(which go)
 GOROOT=$(echo ${GO_LOCATION%/bin/go})
 
-protoc --go_out=plugins=grpc:. evo/protobuf/*.proto
+#Generated code is committed, it is refreshed when protoc is installed
+if command -v protoc >/dev/null; then
+  go generate ./evo/protobuf
+fi
+
 go build -o ./bin/dapi .
\ No newline at end of file
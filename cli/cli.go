@@ -0,0 +1,326 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/co-in/dash-dapi/config"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const DefaultTimeout = 30 * time.Second
+
+//Runs command with positional arguments, flags are already parsed
+type Action func(ctx context.Context, env *Env, args []string) error
+
+type Command struct {
+	Name        string
+	Usage       string
+	Description string
+	//Register command flags, returned Action reads them
+	Setup func(flags *flag.FlagSet) Action
+	//Action handles SIGINT itself, context is not cancelled by signals
+	HandlesSignals bool
+}
+
+var ErrUsage = errors.New("invalid usage")
+
+func Commands() []*Command {
+	commands := []*Command{
+		statusCommand(),
+		blockCommand(),
+		txCommand(),
+		sendCommand(),
+		utxoCommand(),
+		addressSummaryCommand(),
+		mnListDiffCommand(),
+		discoverCommand(),
+		identityCommand(),
+		contractCommand(),
+		documentsCommand(),
+		streamCommand(),
+		shellCommand(),
+		serveCommand(),
+	}
+
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].Name < commands[j].Name
+	})
+
+	return commands
+}
+
+func findCommand(name string) *Command {
+	for _, command := range Commands() {
+		if command.Name == name {
+			return command
+		}
+	}
+
+	return nil
+}
+
+//Global flags, accepted before and after command name
+type globalFlags struct {
+	loader  *config.Loader
+	node    *string
+	timeout *time.Duration
+	output  *string
+	record  *string
+	replay  *string
+}
+
+func registerGlobalFlags(flags *flag.FlagSet) *globalFlags {
+	return &globalFlags{
+		loader:  config.NewLoader(flags),
+		node:    flags.String("node", "", "use this evonode instead of random selection"),
+		timeout: flags.Duration("timeout", DefaultTimeout, "timeout of single request, 0 disables it"),
+		output:  flags.String("output", OutputTable, "output format: "+strings.Join(Outputs, ", ")),
+		record:  flags.String("record", "", "write DAPI calls to fixture file"),
+		replay:  flags.String("replay", "", "serve DAPI calls from fixture file without network"),
+	}
+}
+
+//Entry point of bin/dapi, returns process exit code
+func Run(args []string, stdout io.Writer, stderr io.Writer) int {
+	global := flag.NewFlagSet("dapi", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	registerGlobalFlags(global)
+	global.Usage = func() {
+		usage(stderr, global)
+	}
+
+	err := global.Parse(args)
+
+	if err == flag.ErrHelp {
+		return 0
+	}
+
+	if err != nil {
+		return 2
+	}
+
+	if global.NArg() == 0 {
+		usage(stderr, global)
+
+		return 2
+	}
+
+	name := global.Arg(0)
+
+	if name == "help" {
+		return help(stdout, stderr, global.Args()[1:])
+	}
+
+	command := findCommand(name)
+
+	if command == nil {
+		_, _ = fmt.Fprintf(stderr, "unknown command %s\n\n", name)
+		usage(stderr, global)
+
+		return 2
+	}
+
+	//Global flags before command name are parsed again together with command flags
+	commandIndex := len(args) - global.NArg()
+	commandArgs := append(append([]string(nil), args[:commandIndex]...), args[commandIndex+1:]...)
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+
+	if command.HandlesSignals {
+		ctx, cancel = context.WithCancel(context.Background())
+	} else {
+		ctx, cancel = signalContext()
+	}
+
+	defer cancel()
+
+	err = Execute(ctx, command, commandArgs, stdout, stderr)
+
+	if err == flag.ErrHelp {
+		return 0
+	}
+
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "dapi %s: %s\n", command.Name, err)
+
+		if err == ErrUsage {
+			_, _ = fmt.Fprintf(stderr, "usage: dapi %s\n", command.Usage)
+
+			return 2
+		}
+
+		return 1
+	}
+
+	return 0
+}
+
+//Parse global and command flags, connect and run command
+func Execute(ctx context.Context, command *Command, args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet(command.Name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	global := registerGlobalFlags(flags)
+	action := command.Setup(flags)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "usage: dapi %s\n\n%s\n\nflags:\n", command.Usage, command.Description)
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseInterleaved(flags, args)
+
+	if err != nil {
+		return err
+	}
+
+	cfg, err := global.loader.Load()
+
+	if err != nil {
+		return err
+	}
+
+	env, err := NewEnv(cfg, *global.node, *global.timeout, *global.output, stdout, stderr)
+
+	if err != nil {
+		return err
+	}
+
+	err = env.UseFixture(*global.record, *global.replay)
+
+	if err != nil {
+		return err
+	}
+
+	err = action(ctx, env, positional)
+	closeErr := env.Close()
+
+	if err != nil {
+		return err
+	}
+
+	return closeErr
+}
+
+//Run command with already connected environment, global flags are not accepted
+func (e *Env) Run(ctx context.Context, command *Command, args []string) error {
+	flags := flag.NewFlagSet(command.Name, flag.ContinueOnError)
+	flags.SetOutput(e.Stderr)
+	action := command.Setup(flags)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(e.Stderr, "usage: %s\n\n%s\n\nflags:\n", command.Usage, command.Description)
+		flags.PrintDefaults()
+	}
+
+	positional, err := parseInterleaved(flags, args)
+
+	if err != nil {
+		return err
+	}
+
+	return action(ctx, e, positional)
+}
+
+//Flags may follow positional arguments: documents <contract> <type> --limit 10
+func parseInterleaved(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		err := flags.Parse(args)
+
+		if err != nil {
+			return nil, err
+		}
+
+		args = flags.Args()
+
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func usage(w io.Writer, global *flag.FlagSet) {
+	_, _ = fmt.Fprintf(w, "usage: dapi [global flags] <command> [flags] [arguments]\n\ncommands:\n")
+
+	for _, command := range Commands() {
+		_, _ = fmt.Fprintf(w, "  %-16s%s\n", command.Name, command.Description)
+	}
+
+	_, _ = fmt.Fprintf(w, "  %-16s%s\n\nglobal flags:\n", "help", "show help of command")
+	global.PrintDefaults()
+}
+
+func help(stdout io.Writer, stderr io.Writer, args []string) int {
+	if len(args) == 0 {
+		global := flag.NewFlagSet("dapi", flag.ContinueOnError)
+		registerGlobalFlags(global)
+		global.SetOutput(stdout)
+		usage(stdout, global)
+
+		return 0
+	}
+
+	command := findCommand(args[0])
+
+	if command == nil {
+		_, _ = fmt.Fprintf(stderr, "unknown command %s\n", args[0])
+
+		return 2
+	}
+
+	flags := flag.NewFlagSet(command.Name, flag.ContinueOnError)
+	command.Setup(flags)
+	registerGlobalFlags(flags)
+	flags.SetOutput(stdout)
+	_, _ = fmt.Fprintf(stdout, "usage: dapi %s\n\n%s\n\nflags:\n", command.Usage, command.Description)
+	flags.PrintDefaults()
+
+	return 0
+}
+
+//Cancelled by SIGINT/SIGTERM, stops streams gracefully
+func signalContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-ctx.Done():
+		}
+
+		signal.Stop(signals)
+	}()
+
+	return ctx, cancel
+}
+
+//Repeatable string flag
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package cli_test
+
+import (
+	"bytes"
+	"github.com/co-in/dash-dapi/cli"
+	"github.com/co-in/dash-dapi/config"
+	"github.com/co-in/dash-dapi/evo/dapitest"
+	"github.com/co-in/dash-dapi/evo/network"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+//Configuration of test process must not leak into commands
+func clearEnv(t *testing.T) {
+	t.Helper()
+
+	for _, name := range []string{config.EnvConfig, config.EnvNetwork, config.EnvJsonRpcPort, config.EnvGRpcPort, config.EnvSeedNodes, config.EnvDatabase, config.EnvVerbose, config.EnvLogFormat} {
+		//Setenv restores previous value after test
+		t.Setenv(name, "")
+		_ = os.Unsetenv(name)
+	}
+}
+
+func run(args ...string) (int, string, string) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	code := cli.Run(args, stdout, stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+//Global flags of regtest node served by s, state is kept in memory
+func nodeFlags(s *dapitest.Server) []string {
+	return []string{
+		"-network", network.RegTestName,
+		"-seed", s.Host,
+		"-json-rpc-port", strconv.Itoa(int(s.JSONRPCPort)),
+		"-grpc-port", strconv.Itoa(int(s.GRPCPort)),
+		"-db", config.MemoryDatabase,
+		"-verbose", "0",
+	}
+}
+
+func TestHelp(t *testing.T) {
+	clearEnv(t)
+
+	code, stdout, _ := run("help")
+
+	if code != 0 || !strings.HasPrefix(stdout, "usage: dapi") {
+		t.Fatalf("exit code %d, output %s", code, stdout)
+	}
+
+	for _, command := range cli.Commands() {
+		if !strings.Contains(stdout, "  "+command.Name) {
+			t.Fatalf("command %s is not listed", command.Name)
+		}
+	}
+
+	code, stdout, _ = run("help", "block")
+
+	if code != 0 || !strings.HasPrefix(stdout, "usage: dapi block") || !strings.Contains(stdout, "-height") || !strings.Contains(stdout, "-output") {
+		t.Fatalf("exit code %d, output %s", code, stdout)
+	}
+
+	code, _, stderr := run("help", "unknown")
+
+	if code != 2 || !strings.Contains(stderr, "unknown command unknown") {
+		t.Fatalf("exit code %d, error output %s", code, stderr)
+	}
+
+	//Command is required
+	code, _, stderr = run()
+
+	if code != 2 || !strings.HasPrefix(stderr, "usage: dapi") {
+		t.Fatalf("exit code %d, error output %s", code, stderr)
+	}
+}
+
+func TestOutputValidation(t *testing.T) {
+	clearEnv(t)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "before command", args: []string{"-output", "xml", "status"}},
+		{name: "after command", args: []string{"status", "-output", "xml"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code, stdout, stderr := run(append(test.args, "-db", config.MemoryDatabase)...)
+
+			if code != 1 || stdout != "" || !strings.Contains(stderr, "dapi status: unknown output xml") {
+				t.Fatalf("exit code %d, output %s, error output %s", code, stdout, stderr)
+			}
+		})
+	}
+}
+
+//Recorded failure of call is replayed without node, command exits with same error
+func TestReplayedError(t *testing.T) {
+	clearEnv(t)
+
+	s, err := dapitest.NewServer()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.SetResponse(dapitest.MethodGetStatus, &proto.GetStatusResponse{Network: network.RegTestName, Connections: 8})
+	s.SetResponse(dapitest.MethodGetBlockHash, network.RegTest.GenesisHash)
+	s.SetError(dapitest.MethodGetBlock, status.Error(codes.NotFound, "block not found"))
+
+	fixture := filepath.Join(t.TempDir(), "fixture.json")
+	args := append(nodeFlags(s), "block", "--height", "5")
+
+	code, _, recorded := run(append([]string{"-record", fixture}, args...)...)
+	s.Close()
+
+	if code != 1 || !strings.Contains(recorded, "dapi block:") || !strings.Contains(recorded, "block not found") {
+		t.Fatalf("recorded run: exit code %d, error output %s", code, recorded)
+	}
+
+	code, stdout, replayed := run(append([]string{"-replay", fixture}, args...)...)
+
+	if code != 1 || stdout != "" || replayed != recorded {
+		t.Fatalf("replayed run: exit code %d, error output %s, expected %s", code, replayed, recorded)
+	}
+
+	//Usage error has own exit code
+	code, _, stderr := run(append([]string{"-replay", fixture}, append(nodeFlags(s), "block")...)...)
+
+	if code != 2 || !strings.Contains(stderr, "usage: dapi block") {
+		t.Fatalf("exit code %d, error output %s", code, stderr)
+	}
+}
@@ -0,0 +1,366 @@
+package cli
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+func statusCommand() *Command {
+	return &Command{
+		Name:        "status",
+		Usage:       "status",
+		Description: "show status of evonode",
+		Setup: func(flags *flag.FlagSet) Action {
+			return func(ctx context.Context, env *Env, args []string) error {
+				node, err := env.Node(ctx)
+
+				if err != nil {
+					return err
+				}
+
+				result, err := node.GetStatus()
+
+				if err != nil {
+					return err
+				}
+
+				return env.Print(Result{Value: statusView{
+					Node:            node.GetNodeName(),
+					Network:         result.Network,
+					CoreVersion:     result.CoreVersion,
+					ProtocolVersion: result.ProtocolVersion,
+					Blocks:          result.Blocks,
+					TimeOffset:      result.TimeOffset,
+					Connections:     result.Connections,
+					Proxy:           result.Proxy,
+					Difficulty:      result.Difficulty,
+					Testnet:         result.Testnet,
+					RelayFee:        result.RelayFee,
+					Errors:          result.Errors,
+				}})
+			}
+		},
+	}
+}
+
+func blockCommand() *Command {
+	return &Command{
+		Name:        "block",
+		Usage:       "block (--height <height> | --hash <hash>)",
+		Description: "show block by height or hash",
+		Setup: func(flags *flag.FlagSet) Action {
+			height := flags.Int("height", -1, "block height")
+			hash := flags.String("hash", "", "block hash")
+
+			return func(ctx context.Context, env *Env, args []string) error {
+				if len(args) != 0 || (*height < 0) == (*hash == "") {
+					return ErrUsage
+				}
+
+				request := structures.BlockRequest{}
+
+				if *hash != "" {
+					request.Hash = hash
+				} else {
+					request.Height = height
+				}
+
+				node, err := env.Node(ctx)
+
+				if err != nil {
+					return err
+				}
+
+				result, err := node.GetBlock(request)
+
+				if err != nil {
+					return err
+				}
+
+				view, err := newBlockView(result.Block)
+
+				if err != nil {
+					return err
+				}
+
+				return env.Print(Result{Value: view, Raw: [][]byte{result.Block}})
+			}
+		},
+	}
+}
+
+func txCommand() *Command {
+	return &Command{
+		Name:        "tx",
+		Usage:       "tx <txid>",
+		Description: "show transaction by id",
+		Setup: func(flags *flag.FlagSet) Action {
+			return func(ctx context.Context, env *Env, args []string) error {
+				if len(args) != 1 {
+					return ErrUsage
+				}
+
+				node, err := env.Node(ctx)
+
+				if err != nil {
+					return err
+				}
+
+				result, err := node.GetTransaction(args[0])
+
+				if err != nil {
+					return err
+				}
+
+				tx, err := transaction.Parse(result.Transaction)
+
+				if err != nil {
+					return err
+				}
+
+				return env.Print(Result{Value: newTransactionView(env, tx), Raw: [][]byte{result.Transaction}})
+			}
+		},
+	}
+}
+
+func sendCommand() *Command {
+	return &Command{
+		Name:        "send",
+		Usage:       "send [--allow-high-fees] [--bypass-limits] <hex|file>",
+		Description: "broadcast signed raw transaction, given as hex or file with hex or binary transaction",
+		Setup: func(flags *flag.FlagSet) Action {
+			allowHighFees := flags.Bool("allow-high-fees", false, "allow absurdly high fees")
+			bypassLimits := flags.Bool("bypass-limits", false, "bypass mempool limits")
+
+			return func(ctx context.Context, env *Env, args []string) error {
+				if len(args) != 1 {
+					return ErrUsage
+				}
+
+				data, err := readTransaction(args[0])
+
+				if err != nil {
+					return err
+				}
+
+				node, err := env.Node(ctx)
+
+				if err != nil {
+					return err
+				}
+
+				result, err := node.SendTransaction(data, *allowHighFees, *bypassLimits)
+
+				if err != nil {
+					return err
+				}
+
+				return env.Print(Result{Value: struct {
+					TransactionId string
+				}{result.TransactionId}})
+			}
+		},
+	}
+}
+
+//Hex string, file with hex string or file with binary transaction
+func readTransaction(arg string) ([]byte, error) {
+	if data, err := hex.DecodeString(arg); err == nil {
+		return data, nil
+	}
+
+	data, err := ioutil.ReadFile(arg)
+
+	if os.IsNotExist(err) {
+		return nil, errors.New("argument is neither hex transaction nor existing file")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if decoded, err := hex.DecodeString(strings.TrimSpace(string(data))); err == nil {
+		return decoded, nil
+	}
+
+	_, err = transaction.Parse(data)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", arg, err)
+	}
+
+	return data, nil
+}
+
+func utxoCommand() *Command {
+	return &Command{
+		Name:        "utxo",
+		Usage:       "utxo [--from <n>] [--to <n>] [--from-height <height>] [--to-height <height>] <address...>",
+		Description: "list unspent outputs of addresses",
+		Setup: func(flags *flag.FlagSet) Action {
+			from := flags.Int("from", -1, "index of first item")
+			to := flags.Int("to", -1, "index after last item")
+			fromHeight := flags.Int("from-height", -1, "minimal block height")
+			toHeight := flags.Int("to-height", -1, "maximal block height")
+
+			return func(ctx context.Context, env *Env, args []string) error {
+				if len(args) == 0 {
+					return ErrUsage
+				}
+
+				err := validateAddresses(env, args)
+
+				if err != nil {
+					return err
+				}
+
+				request := structures.UTXORequest{
+					From:       optionalInt(*from),
+					To:         optionalInt(*to),
+					FromHeight: optionalInt(*fromHeight),
+					ToHeight:   optionalInt(*toHeight),
+					Addresses:  args,
+				}
+
+				node, err := env.Node(ctx)
+
+				if err != nil {
+					return err
+				}
+
+				result, err := node.GetUTXO(request)
+
+				if err != nil {
+					return err
+				}
+
+				return env.Print(Result{Value: newUTXOView(result)})
+			}
+		},
+	}
+}
+
+func addressSummaryCommand() *Command {
+	return &Command{
+		Name:        "address-summary",
+		Usage:       "address-summary <address...>",
+		Description: "show balance and transactions of addresses",
+		Setup: func(flags *flag.FlagSet) Action {
+			return func(ctx context.Context, env *Env, args []string) error {
+				if len(args) == 0 {
+					return ErrUsage
+				}
+
+				err := validateAddresses(env, args)
+
+				if err != nil {
+					return err
+				}
+
+				node, err := env.Node(ctx)
+
+				if err != nil {
+					return err
+				}
+
+				result, err := node.GetAddressSummary(args)
+
+				if err != nil {
+					return err
+				}
+
+				return env.Print(Result{Value: addressSummaryView{
+					Addresses:             result.AddrStr,
+					BalanceSat:            result.BalanceSat,
+					TotalReceivedSat:      result.TotalReceivedSat,
+					TotalSentSat:          result.TotalSentSat,
+					UnconfirmedBalanceSat: result.UnconfirmedBalanceSat,
+					TxAppearances:         result.TxAppearances,
+					Transactions:          result.Transactions,
+				}})
+			}
+		},
+	}
+}
+
+func mnListDiffCommand() *Command {
+	return &Command{
+		Name:        "mnlist-diff",
+		Usage:       "mnlist-diff [--base <hash>] [--block <hash>]",
+		Description: "show masternode list difference between blocks, defaults from genesis to best block",
+		Setup: func(flags *flag.FlagSet) Action {
+			base := flags.String("base", "", "base block hash, default genesis")
+			blockHash := flags.String("block", "", "block hash, default best block")
+
+			return func(ctx context.Context, env *Env, args []string) error {
+				if len(args) != 0 {
+					return ErrUsage
+				}
+
+				node, err := env.Node(ctx)
+
+				if err != nil {
+					return err
+				}
+
+				if *base == "" {
+					hash, err := node.GetBlockHash(0)
+
+					if err != nil {
+						return err
+					}
+
+					*base = string(*hash)
+				}
+
+				if *blockHash == "" {
+					hash, err := node.GetBestBlockHash()
+
+					if err != nil {
+						return err
+					}
+
+					*blockHash = string(*hash)
+				}
+
+				result, err := node.GetMnListDiff(*base, *blockHash)
+
+				if err != nil {
+					return err
+				}
+
+				return env.Print(Result{Value: result})
+			}
+		},
+	}
+}
+
+func validateAddresses(env *Env, addresses []string) error {
+	for _, address := range addresses {
+		_, err := transaction.AddressToScript(address, env.Network.Address)
+
+		if err != nil {
+			return fmt.Errorf("%s: %s", address, err)
+		}
+	}
+
+	return nil
+}
+
+//Negative flag value means not set
+func optionalInt(value int) *int {
+	if value < 0 {
+		return nil
+	}
+
+	return &value
+}
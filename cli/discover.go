@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"regexp"
+	"sync"
+)
+
+func discoverCommand() *Command {
+	return &Command{
+		Name:        "discover",
+		Usage:       "discover",
+		Description: "discover available evonodes from masternode list and save them to database",
+		Setup: func(flags *flag.FlagSet) Action {
+			return func(ctx context.Context, env *Env, args []string) error {
+				if len(args) != 0 {
+					return ErrUsage
+				}
+
+				nodes, err := discoveryNewEvoNodes(ctx, env)
+
+				if err != nil {
+					return err
+				}
+
+				_, err = fmt.Fprintf(env.Stdout, "Discovered nodes:\t%d\n", nodes)
+
+				return err
+			}
+		},
+	}
+}
+
+func discoveryNewEvoNodes(ctx context.Context, env *Env) (int, error) {
+	dAPI, err := env.Client()
+
+	if err != nil {
+		return 0, err
+	}
+
+	dbProvider, err := env.Database()
+
+	if err != nil {
+		return 0, err
+	}
+
+	node, err := env.Node(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	lastBlockHash, err := node.GetBestBlockHash()
+
+	if err != nil {
+		return 0, err
+	}
+
+	dbLastBlockHash := dbProvider.GetCurrentBlockHash()
+	evoNodes := dbProvider.GetDiscoveredNodes()
+
+	//Sync MasterNode list
+	if string(*lastBlockHash) == dbLastBlockHash {
+		return len(evoNodes), nil
+	}
+
+	//First Run
+	if dbLastBlockHash == "" {
+		baseBlockHash, err := node.GetBlockHash(0)
+
+		if err != nil {
+			return 0, err
+		}
+
+		dbProvider.SetCurrentBlockHash(string(*baseBlockHash))
+	}
+
+	mnList, err := node.GetMnListDiff(dbProvider.GetCurrentBlockHash(), string(*lastBlockHash))
+
+	if err != nil {
+		return 0, err
+	}
+
+	//Discovery new EVO nodes
+	wg := new(sync.WaitGroup)
+	wg.Add(len(mnList.MnList))
+	re := regexp.MustCompile(`(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)(\.(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)){3}`)
+	m := new(sync.Mutex)
+
+	for _, v := range mnList.MnList {
+		//Async connection to EvoNodes
+		go func(v structures.NodeInfoResponse) {
+			var tempNode interfaces.IConnection
+			var err error
+
+			defer func() {
+				if tempNode != nil {
+					tempNode.Remove()
+				}
+
+				wg.Done()
+			}()
+
+			ipStr := string(re.Find([]byte(v.Service)))
+
+			if dAPI.AddNode(ipStr, 0) != nil {
+				return
+			}
+
+			tempNode, err = dAPI.SelectNode(ipStr)
+
+			if err != nil {
+				return
+			}
+
+			if tempNode.WithContext(ctx).CheckAvailability() {
+				m.Lock()
+				evoNodes = appendUnique(evoNodes, ipStr)
+				m.Unlock()
+			}
+		}(v)
+	}
+
+	wg.Wait()
+
+	dbProvider.SetDiscoveredNodes(evoNodes)
+	dbProvider.SetCurrentBlockHash(string(*lastBlockHash))
+
+	return len(evoNodes), dbProvider.Save()
+}
+
+func appendUnique(nodes []string, node string) []string {
+	for _, v := range nodes {
+		if v == node {
+			return nodes
+		}
+	}
+
+	return append(nodes, node)
+}
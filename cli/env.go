@@ -0,0 +1,276 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/co-in/dash-dapi/config"
+	"github.com/co-in/dash-dapi/db"
+	"github.com/co-in/dash-dapi/db/jsonFile"
+	"github.com/co-in/dash-dapi/db/memory"
+	"github.com/co-in/dash-dapi/evo"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	"github.com/co-in/dash-dapi/evo/network"
+	"github.com/co-in/dash-dapi/logging"
+	"github.com/co-in/dash-dapi/metrics"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+//Shared state of commands, client is connected on first use
+type Env struct {
+	Config  *config.Config
+	Network *network.Params
+	//Notices of commands
+	Logger *log.Logger
+	//Diagnostics of client and gateway, level and format are taken from config
+	Log    logging.Logger
+	Stdout io.Writer
+	Stderr io.Writer
+	//One of Outputs
+	Output string
+	//Client metrics are collected when set before first use of client
+	Metrics *metrics.Registry
+	//Calls are recorded to recordFile or replayed from fixture when set
+	fixture    *evo.Fixture
+	recordFile string
+	node       string
+	timeout    time.Duration
+	db         db.IDatabase
+	client     interfaces.IClient
+}
+
+func NewEnv(cfg *config.Config, node string, timeout time.Duration, output string, stdout io.Writer, stderr io.Writer) (*Env, error) {
+	params, err := cfg.NetworkParams()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateOutput(output)
+
+	if err != nil {
+		return nil, err
+	}
+
+	level := logging.LevelFromVerbose(cfg.VerboseLevel)
+	diagnostics := logging.NewStdLogger(log.New(stderr, "", log.LstdFlags), level)
+
+	if cfg.LogFormat == config.LogFormatJSON {
+		diagnostics = logging.NewJSONLogger(stderr, level)
+	}
+
+	return &Env{
+		Config:  cfg,
+		Network: params,
+		Logger:  log.New(stderr, "", log.LstdFlags),
+		Log:     diagnostics,
+		Stdout:  stdout,
+		Stderr:  stderr,
+		Output:  output,
+		node:    node,
+		timeout: timeout,
+	}, nil
+}
+
+func (e *Env) Database() (db.IDatabase, error) {
+	if e.db != nil {
+		return e.db, nil
+	}
+
+	if e.Config.DatabaseFile == config.MemoryDatabase {
+		e.db = memory.NewDB()
+
+		return e.db, nil
+	}
+
+	dbProvider := jsonFile.NewDB(e.Config.DatabaseFile)
+	err := dbProvider.Load()
+
+	//State file is created by first Save
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	//Settings of legacy database are written to configuration file before migrated state is saved without them
+	if moved := dbProvider.Moved(); len(moved) > 0 {
+		err = e.moveSettings(moved)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	e.db = dbProvider
+
+	return e.db, nil
+}
+
+func (e *Env) moveSettings(moved db.Document) error {
+	fields := make([]string, 0, len(moved))
+
+	for field := range moved {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	if filepath.Clean(e.Config.FileName) == filepath.Clean(e.Config.DatabaseFile) {
+		return fmt.Errorf("%s of %s must be moved to configuration file, use -config with other file", strings.Join(fields, ", "), e.Config.DatabaseFile)
+	}
+
+	err := config.MergeFile(e.Config.FileName, moved)
+
+	if err != nil {
+		return fmt.Errorf("move %s to %s: %s", strings.Join(fields, ", "), e.Config.FileName, err)
+	}
+
+	e.Logger.Printf("Moved %s from %s to %s, values set there are used from next run\n", strings.Join(fields, ", "), e.Config.DatabaseFile, e.Config.FileName)
+
+	return nil
+}
+
+func (e *Env) Client() (interfaces.IClient, error) {
+	if e.client != nil {
+		return e.client, nil
+	}
+
+	dbProvider, err := e.Database()
+
+	if err != nil {
+		return nil, err
+	}
+
+	options := []evo.Option{
+		evo.WithNodeRecords(dbProvider.GetNodeRecords()),
+		evo.WithNetwork(e.Network),
+		evo.WithTimeout(e.timeout),
+	}
+
+	if e.Metrics != nil {
+		options = append(options, evo.WithMetrics(e.Metrics))
+	}
+
+	if e.replaying() {
+		options = append(options, evo.WithReplay(e.fixture))
+	} else if e.fixture != nil {
+		options = append(options, evo.WithRecording(e.fixture))
+	}
+
+	dAPI, err := evo.NewClient(e.Log, e.Config.SeedNodes[0], e.Config.EvoJsonRpcPort, e.Config.EvoGRpcPort, options...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	//Apply seeds and previously discovered evoNodes
+	for _, v := range append(e.Config.SeedNodes, dbProvider.GetDiscoveredNodes()...) {
+		err = dAPI.AddNode(v, 0)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if e.node != "" {
+		err = dAPI.AddNode(e.node, 0)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	e.client = dAPI
+
+	return e.client, nil
+}
+
+//Node selected by -node flag or random node, bound to ctx
+func (e *Env) Node(ctx context.Context) (interfaces.IConnection, error) {
+	dAPI, err := e.Client()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var node interfaces.IConnection
+
+	if e.node != "" {
+		node, err = dAPI.SelectNode(e.node)
+	} else {
+		node, err = dAPI.SelectRandomNode()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return node.WithContext(ctx), nil
+}
+
+//Switch node used by following commands, empty name restores random selection
+func (e *Env) UseNode(name string) {
+	e.node = name
+
+	if e.client != nil && name != "" {
+		_ = e.client.AddNode(name, 0)
+	}
+}
+
+func (e *Env) NodeName() string {
+	return e.node
+}
+
+//Record calls of client to file or replay them from file, must be set before first use of client
+func (e *Env) UseFixture(recordFile string, replayFile string) error {
+	switch {
+	case recordFile != "" && replayFile != "":
+		return errors.New("-record and -replay are exclusive")
+	case recordFile != "":
+		e.fixture = evo.NewFixture()
+		e.recordFile = recordFile
+	case replayFile != "":
+		fixture, err := evo.LoadFixture(replayFile)
+
+		if err != nil {
+			return err
+		}
+
+		e.fixture = fixture
+	}
+
+	return nil
+}
+
+//Keep reputation of nodes for next run, save recorded calls. Replayed run does not touch database,
+//reputation of replayed calls does not reflect real nodes
+func (e *Env) Close() error {
+	if e.client == nil {
+		return nil
+	}
+
+	if e.recordFile != "" {
+		err := e.fixture.Save(e.recordFile)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if e.replaying() {
+		return nil
+	}
+
+	e.db.SetNodeRecords(e.client.NodeRecords())
+
+	return e.db.Save()
+}
+
+func (e *Env) replaying() bool {
+	return e.fixture != nil && e.recordFile == ""
+}
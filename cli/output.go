@@ -0,0 +1,476 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+	OutputHex   = "hex"
+	OutputRaw   = "raw"
+)
+
+var Outputs = []string{OutputTable, OutputJSON, OutputYAML, OutputHex, OutputRaw}
+
+var ErrNoRawOutput = errors.New("command has no binary payload, use table, json or yaml output")
+
+//Output of command: Value is rendered as table, json or yaml, Raw as hex or raw
+type Result struct {
+	Value interface{}
+	//Binary payloads (transaction, block, CBOR objects), one per line in hex output
+	Raw [][]byte
+}
+
+func validateOutput(output string) error {
+	for _, v := range Outputs {
+		if v == output {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown output %s (available: %s)", output, strings.Join(Outputs, ", "))
+}
+
+//Render result of command
+func (e *Env) Print(result Result) error {
+	switch e.Output {
+	case OutputHex, OutputRaw:
+		return e.printRaw(result)
+	}
+
+	tree, err := orderedTree(result.Value)
+
+	if err != nil {
+		return err
+	}
+
+	switch e.Output {
+	case OutputJSON:
+		data, err := json.MarshalIndent(result.Value, "", "  ")
+
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(e.Stdout, "%s\n", data)
+
+		return err
+	case OutputYAML:
+		buf := new(bytes.Buffer)
+		writeYAML(buf, tree, 0)
+		_, err = e.Stdout.Write(buf.Bytes())
+
+		return err
+	}
+
+	return writeTable(e.Stdout, tree)
+}
+
+//Render single event of stream: newline-delimited JSON, YAML documents or one table line
+func (e *Env) PrintEvent(result Result) error {
+	switch e.Output {
+	case OutputHex, OutputRaw:
+		return e.printRaw(result)
+	case OutputJSON:
+		data, err := json.Marshal(result.Value)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(e.Stdout, "%s\n", data)
+
+		return err
+	}
+
+	tree, err := orderedTree(result.Value)
+
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+
+	if e.Output == OutputYAML {
+		buf.WriteString("---\n")
+		writeYAML(buf, tree, 0)
+	} else {
+		var fields []string
+
+		for _, row := range flatten("", tree) {
+			fields = append(fields, row[0]+"="+row[1])
+		}
+
+		buf.WriteString(strings.Join(fields, " ") + "\n")
+	}
+
+	_, err = e.Stdout.Write(buf.Bytes())
+
+	return err
+}
+
+func (e *Env) printRaw(result Result) error {
+	if len(result.Raw) == 0 {
+		return ErrNoRawOutput
+	}
+
+	for _, raw := range result.Raw {
+		var err error
+
+		if e.Output == OutputHex {
+			_, err = io.WriteString(e.Stdout, hex.EncodeToString(raw)+"\n")
+		} else {
+			_, err = e.Stdout.Write(raw)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//JSON object with preserved field order
+type object []field
+
+type field struct {
+	key   string
+	value interface{}
+}
+
+//Value as decoded JSON tree: object, []interface{}, string, json.Number, bool or nil
+func orderedTree(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decodeOrdered(decoder)
+}
+
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		o := object{}
+
+		for decoder.More() {
+			key, err := decoder.Token()
+
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := decodeOrdered(decoder)
+
+			if err != nil {
+				return nil, err
+			}
+
+			o = append(o, field{key: key.(string), value: value})
+		}
+
+		_, err = decoder.Token()
+
+		return o, err
+	case json.Delim('['):
+		a := make([]interface{}, 0)
+
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+
+			if err != nil {
+				return nil, err
+			}
+
+			a = append(a, value)
+		}
+
+		_, err = decoder.Token()
+
+		return a, err
+	}
+
+	return token, nil
+}
+
+//Key-value rows, nested keys joined by dot
+func flatten(prefix string, value interface{}) [][2]string {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+
+		return prefix + "." + key
+	}
+
+	switch v := value.(type) {
+	case object:
+		var rows [][2]string
+
+		for _, f := range v {
+			rows = append(rows, flatten(join(f.key), f.value)...)
+		}
+
+		return rows
+	case []interface{}:
+		if isScalarList(v) {
+			var items []string
+
+			for _, item := range v {
+				items = append(items, scalar(item))
+			}
+
+			return [][2]string{{prefix, strings.Join(items, ", ")}}
+		}
+
+		var rows [][2]string
+
+		for i, item := range v {
+			rows = append(rows, flatten(join(strconv.Itoa(i)), item)...)
+		}
+
+		return rows
+	}
+
+	return [][2]string{{prefix, scalar(value)}}
+}
+
+//Objects as aligned key-value rows, lists of flat objects as columns
+func writeTable(w io.Writer, tree interface{}) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	if list, ok := tree.([]interface{}); ok && isFlatObjectList(list) {
+		writeColumns(tw, list)
+
+		return tw.Flush()
+	}
+
+	var lists []field
+
+	if o, ok := tree.(object); ok {
+		var rest object
+
+		for _, f := range o {
+			if list, ok := f.value.([]interface{}); ok && isFlatObjectList(list) {
+				lists = append(lists, f)
+			} else {
+				rest = append(rest, f)
+			}
+		}
+
+		tree = rest
+	}
+
+	for _, row := range flatten("", tree) {
+		_, _ = fmt.Fprintf(tw, "%s:\t%s\n", row[0], row[1])
+	}
+
+	for _, f := range lists {
+		_, _ = fmt.Fprintf(tw, "\n%s:\n", f.key)
+		err := tw.Flush()
+
+		if err != nil {
+			return err
+		}
+
+		writeColumns(tw, f.value.([]interface{}))
+	}
+
+	return tw.Flush()
+}
+
+func writeColumns(tw *tabwriter.Writer, list []interface{}) {
+	var columns []string
+	seen := make(map[string]bool)
+
+	for _, item := range list {
+		for _, f := range item.(object) {
+			if !seen[f.key] {
+				seen[f.key] = true
+				columns = append(columns, f.key)
+			}
+		}
+	}
+
+	_, _ = fmt.Fprintln(tw, strings.ToUpper(strings.Join(columns, "\t")))
+
+	for _, item := range list {
+		values := make(map[string]string)
+
+		for _, f := range item.(object) {
+			values[f.key] = scalar(f.value)
+		}
+
+		row := make([]string, len(columns))
+
+		for i, column := range columns {
+			row[i] = values[column]
+		}
+
+		_, _ = fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+}
+
+func isScalarList(list []interface{}) bool {
+	for _, item := range list {
+		switch item.(type) {
+		case object, []interface{}:
+			return false
+		}
+	}
+
+	return true
+}
+
+func isFlatObjectList(list []interface{}) bool {
+	if len(list) == 0 {
+		return false
+	}
+
+	for _, item := range list {
+		o, ok := item.(object)
+
+		if !ok {
+			return false
+		}
+
+		for _, f := range o {
+			switch f.value.(type) {
+			case object, []interface{}:
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func scalar(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case object:
+		if len(v) == 0 {
+			return "{}"
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return "[]"
+		}
+	}
+
+	return fmt.Sprint(value)
+}
+
+func writeYAML(buf *bytes.Buffer, value interface{}, indent int) {
+	pad := strings.Repeat("  ", indent)
+
+	switch v := value.(type) {
+	case object:
+		if len(v) == 0 {
+			buf.WriteString(pad + "{}\n")
+
+			return
+		}
+
+		for _, f := range v {
+			buf.WriteString(pad + yamlString(f.key) + ":")
+			writeYAMLChild(buf, f.value, indent)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			buf.WriteString(pad + "[]\n")
+
+			return
+		}
+
+		for _, item := range v {
+			buf.WriteString(pad + "-")
+			writeYAMLChild(buf, item, indent)
+		}
+	default:
+		buf.WriteString(pad + yamlScalar(value) + "\n")
+	}
+}
+
+//Value after "key:" or "-", nested collections go to next lines
+func writeYAMLChild(buf *bytes.Buffer, value interface{}, indent int) {
+	switch v := value.(type) {
+	case object:
+		if len(v) > 0 {
+			buf.WriteString("\n")
+			writeYAML(buf, v, indent+1)
+
+			return
+		}
+	case []interface{}:
+		if len(v) > 0 {
+			buf.WriteString("\n")
+			writeYAML(buf, v, indent+1)
+
+			return
+		}
+	}
+
+	buf.WriteString(" " + yamlScalar(value) + "\n")
+}
+
+func yamlScalar(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return yamlString(v)
+	case object:
+		return "{}"
+	case []interface{}:
+		return "[]"
+	}
+
+	return scalar(value)
+}
+
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true, "off": true, "null": true, "~": true,
+}
+
+//Plain scalar when unambiguous, JSON double-quoted string otherwise (valid YAML)
+func yamlString(s string) string {
+	if s == "" || yamlReserved[strings.ToLower(s)] || strings.ContainsAny(s, ":#{}[],&*!|>'\"%@`\n\t") ||
+		strings.TrimSpace(s) != s || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "?") {
+		return strconv.Quote(s)
+	}
+
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
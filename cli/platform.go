@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/platform"
+	"github.com/co-in/dash-dapi/evo/structures"
+)
+
+func identityCommand() *Command {
+	return &Command{
+		Name:        "identity",
+		Usage:       "identity <id>",
+		Description: "show identity",
+		Setup: func(flags *flag.FlagSet) Action {
+			return func(ctx context.Context, env *Env, args []string) error {
+				if len(args) != 1 {
+					return ErrUsage
+				}
+
+				node, err := env.Node(ctx)
+
+				if err != nil {
+					return err
+				}
+
+				result, err := node.GetIdentity(args[0])
+
+				if err != nil {
+					return err
+				}
+
+				return env.PrintPlatform(result.Identity)
+			}
+		},
+	}
+}
+
+func contractCommand() *Command {
+	return &Command{
+		Name:        "contract",
+		Usage:       "contract [<id>]",
+		Description: "show data contract, defaults to DPNS contract of network",
+		Setup: func(flags *flag.FlagSet) Action {
+			return func(ctx context.Context, env *Env, args []string) error {
+				if len(args) > 1 {
+					return ErrUsage
+				}
+
+				id, err := contractID(env, args)
+
+				if err != nil {
+					return err
+				}
+
+				node, err := env.Node(ctx)
+
+				if err != nil {
+					return err
+				}
+
+				result, err := node.GetDataContract(id)
+
+				if err != nil {
+					return err
+				}
+
+				return env.PrintPlatform(result.DataContract)
+			}
+		},
+	}
+}
+
+func documentsCommand() *Command {
+	return &Command{
+		Name:        "documents",
+		Usage:       "documents <contract> <type> [--where <json>] [--order-by <json>] [--limit <n>] [--start-at <n> | --start-after <n>]",
+		Description: "query documents of data contract",
+		Setup: func(flags *flag.FlagSet) Action {
+			where := flags.String("where", "", "JSON array of conditions, e.g. '[[\"field\",\"==\",\"value\"]]'")
+			orderBy := flags.String("order-by", "", "JSON array of sort rules, e.g. '[[\"field\",\"asc\"]]'")
+			limit := flags.Int("limit", -1, "maximal number of documents")
+			startAt := flags.Int("start-at", -1, "index of first document")
+			startAfter := flags.Int("start-after", -1, "index before first document")
+
+			return func(ctx context.Context, env *Env, args []string) error {
+				if len(args) != 2 {
+					return ErrUsage
+				}
+
+				filter := structures.GetDocumentsRequest{
+					Limit:      optionalInt(*limit),
+					StartAt:    optionalInt(*startAt),
+					StartAfter: optionalInt(*startAfter),
+				}
+
+				var err error
+
+				filter.Where, err = query("where", *where)
+
+				if err != nil {
+					return err
+				}
+
+				filter.OrderBy, err = query("order-by", *orderBy)
+
+				if err != nil {
+					return err
+				}
+
+				node, err := env.Node(ctx)
+
+				if err != nil {
+					return err
+				}
+
+				result, err := node.GetDocuments(args[0], args[1], filter)
+
+				if err != nil {
+					return err
+				}
+
+				if env.Output == OutputHex || env.Output == OutputRaw {
+					return env.Print(Result{Raw: result.Documents})
+				}
+
+				documents, err := platform.DecodeDocuments(result.Documents)
+
+				if err != nil {
+					return err
+				}
+
+				return env.Print(Result{Value: documents, Raw: result.Documents})
+			}
+		},
+	}
+}
+
+func contractID(env *Env, args []string) (string, error) {
+	if len(args) == 1 {
+		return args[0], nil
+	}
+
+	if env.Network.DPNSContractID == "" {
+		return "", fmt.Errorf("DPNS contract is unknown on %s, contract id is required", env.Network.Name)
+	}
+
+	return env.Network.DPNSContractID, nil
+}
+
+//DAPI expects where and orderBy as CBOR encoded arrays
+func query(name string, value string) (*[]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	data, err := platform.QueryFromJSON(value)
+
+	if err != nil {
+		return nil, fmt.Errorf("--%s: %s", name, err)
+	}
+
+	return &data, nil
+}
+
+//Decoded CBOR object, payload is not decoded for hex and raw output
+func (e *Env) PrintPlatform(raw []byte) error {
+	if e.Output == OutputHex || e.Output == OutputRaw {
+		return e.Print(Result{Raw: [][]byte{raw}})
+	}
+
+	value, err := platform.Decode(raw)
+
+	if err != nil {
+		return err
+	}
+
+	return e.Print(Result{Value: value, Raw: [][]byte{raw}})
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"github.com/co-in/dash-dapi/db/boltFile"
+	"github.com/co-in/dash-dapi/evo/cache"
+	"github.com/co-in/dash-dapi/gateway"
+	"github.com/co-in/dash-dapi/metrics"
+	"net"
+)
+
+func serveCommand() *Command {
+	return &Command{
+		Name:        "serve",
+		Usage:       "serve [--grpc-listen <address>] [--json-rpc-listen <address>] [--rest-listen <address>] [--attempts <n>]",
+		Description: "serve local DAPI gateway forwarding gRPC, JSON-RPC and REST calls to healthy evonodes",
+		Setup: func(flags *flag.FlagSet) Action {
+			gRPCListen := flags.String("grpc-listen", "127.0.0.1:3010", "gRPC listen address, empty disables gRPC")
+			jsonRPCListen := flags.String("json-rpc-listen", "127.0.0.1:3000", "JSON-RPC listen address, empty disables JSON-RPC")
+			restListen := flags.String("rest-listen", "127.0.0.1:3080", "REST listen address, empty disables REST")
+			metricsListen := flags.String("metrics-listen", "", "listen address of Prometheus /metrics endpoint, empty disables metrics")
+			attempts := flags.Int("attempts", gateway.DefaultOptions().Attempts, "nodes tried by single call")
+			retryDelay := flags.Duration("retry-delay", gateway.DefaultOptions().RetryDelay, "delay between stream reconnections")
+			streamBuffer := flags.Int("stream-buffer", gateway.DefaultOptions().StreamBuffer, "events buffered per stream bridge client, slower clients are disconnected")
+			heartbeat := flags.Duration("heartbeat", gateway.DefaultOptions().Heartbeat, "interval of stream bridge heartbeats")
+			cacheSize := flags.Int("cache-size", cache.DefaultOptions().MaxEntries, "immutable responses cached in memory, 0 disables cache")
+			cacheFile := flags.String("cache-file", "", "persist cached responses to this bolt database")
+			cacheQuorum := flags.Int("cache-quorum", cache.DefaultOptions().Quorum, "nodes which must report tip or chain lock before blocks are cached by height")
+
+			return func(ctx context.Context, env *Env, args []string) error {
+				if len(args) != 0 {
+					return ErrUsage
+				}
+
+				if *metricsListen != "" {
+					env.Metrics = metrics.NewRegistry()
+				}
+
+				dAPI, err := env.Client()
+
+				if err != nil {
+					return err
+				}
+
+				if *cacheSize > 0 || *cacheFile != "" {
+					options := cache.DefaultOptions()
+					options.MaxEntries = *cacheSize
+					options.Quorum = *cacheQuorum
+
+					if *cacheFile != "" {
+						store := boltFile.NewDB(*cacheFile)
+						err = store.Load()
+
+						if err != nil {
+							return err
+						}
+
+						defer func() {
+							_ = store.Close()
+						}()
+
+						options.Store = store
+					}
+
+					responses := cache.New(options)
+					dAPI = responses.WrapClient(dAPI)
+
+					//Chain locks finalize cached heights and reveal reorgs
+					go responses.FollowChainLocks(ctx, dAPI, *retryDelay)
+				}
+
+				listeners := gateway.Listeners{}
+				endpoints := []struct {
+					name     string
+					address  string
+					listener *net.Listener
+				}{
+					{"gRPC", *gRPCListen, &listeners.GRPC},
+					{"JSON-RPC", *jsonRPCListen, &listeners.JSONRPC},
+					{"REST", *restListen, &listeners.REST},
+					{"metrics", *metricsListen, &listeners.Metrics},
+				}
+
+				for _, endpoint := range endpoints {
+					if endpoint.address == "" {
+						continue
+					}
+
+					*endpoint.listener, err = net.Listen("tcp", endpoint.address)
+
+					if err != nil {
+						closeListeners(listeners)
+
+						return err
+					}
+
+					env.Logger.Printf("Serving %s on %s\n", endpoint.name, (*endpoint.listener).Addr())
+				}
+
+				g := gateway.New(dAPI, gateway.Options{
+					Attempts:     *attempts,
+					RetryDelay:   *retryDelay,
+					Logger:       env.Log,
+					StreamBuffer: *streamBuffer,
+					Heartbeat:    *heartbeat,
+					Metrics:      env.Metrics,
+				})
+
+				//Reputation is saved by Env.Close after shutdown
+				return g.Serve(ctx, listeners)
+			}
+		},
+	}
+}
+
+func closeListeners(listeners gateway.Listeners) {
+	for _, listener := range []net.Listener{listeners.GRPC, listeners.JSONRPC, listeners.REST, listeners.Metrics} {
+		if listener != nil {
+			_ = listener.Close()
+		}
+	}
+}
@@ -0,0 +1,579 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/chzyer/readline"
+	"github.com/co-in/dash-dapi/db"
+	"github.com/co-in/dash-dapi/evo/platform"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const shellHistoryFile = ".dapi_history"
+
+var errExit = errors.New("exit")
+
+type shellBuiltin struct {
+	usage       string
+	description string
+}
+
+var shellBuiltins = map[string]shellBuiltin{
+	"use":    {"use [<node>]", "send following commands to node, without argument select random nodes"},
+	"nodes":  {"nodes", "list known evonodes"},
+	"output": {"output <format>", "switch output format: " + strings.Join(Outputs, ", ")},
+	"help":   {"help [<command>]", "show commands or help of command"},
+	"exit":   {"exit", "leave shell"},
+}
+
+func shellCommand() *Command {
+	return &Command{
+		Name:           "shell",
+		Usage:          "shell [--history <file>]",
+		Description:    "interactive shell with completion and history",
+		HandlesSignals: true,
+		Setup: func(flags *flag.FlagSet) Action {
+			history := flags.String("history", defaultHistoryFile(), "history file, empty disables persistent history")
+
+			return func(ctx context.Context, env *Env, args []string) error {
+				if len(args) != 0 {
+					return ErrUsage
+				}
+
+				explicit := false
+				flags.Visit(func(f *flag.Flag) {
+					explicit = explicit || f.Name == "output"
+				})
+
+				//Decoded CBOR objects are easier to read as indented JSON than flattened table,
+				//explicit -output is kept
+				if !explicit {
+					env.Output = OutputJSON
+				}
+
+				sh := &shell{
+					env:       env,
+					contracts: make(map[string]*platform.Contract),
+				}
+
+				return sh.run(ctx, *history)
+			}
+		},
+	}
+}
+
+func defaultHistoryFile() string {
+	home, err := os.UserHomeDir()
+
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, shellHistoryFile)
+}
+
+type shell struct {
+	env *Env
+	//Loaded data contracts by id, used for completion
+	contracts map[string]*platform.Contract
+}
+
+func (sh *shell) run(ctx context.Context, history string) error {
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          sh.prompt(),
+		HistoryFile:     history,
+		AutoComplete:    sh,
+		InterruptPrompt: "^C",
+		EOFPrompt:       "exit",
+		Stdout:          sh.env.Stdout,
+		Stderr:          sh.env.Stderr,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = rl.Close()
+	}()
+
+	for {
+		rl.SetPrompt(sh.prompt())
+		line, err := rl.Readline()
+
+		if err == readline.ErrInterrupt {
+			continue
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		args, err := splitArgs(line)
+
+		if err == nil && len(args) > 0 {
+			err = sh.execute(ctx, args)
+		}
+
+		if err == errExit {
+			return nil
+		}
+
+		if err != nil {
+			_, _ = fmt.Fprintf(sh.env.Stderr, "%s\n", err)
+		}
+	}
+}
+
+func (sh *shell) prompt() string {
+	node := sh.env.NodeName()
+
+	if node == "" {
+		node = "random"
+	}
+
+	return fmt.Sprintf("dapi(%s@%s)> ", sh.env.Network.Name, node)
+}
+
+func (sh *shell) execute(ctx context.Context, args []string) error {
+	switch args[0] {
+	case "exit", "quit":
+		return errExit
+	case "help":
+		sh.help(args[1:])
+
+		return nil
+	case "use":
+		return sh.use(ctx, args[1:])
+	case "nodes":
+		return sh.nodes()
+	case "output":
+		if len(args) != 2 {
+			return errors.New("usage: " + shellBuiltins["output"].usage)
+		}
+
+		err := validateOutput(args[1])
+
+		if err != nil {
+			return err
+		}
+
+		sh.env.Output = args[1]
+
+		return nil
+	}
+
+	command := findCommand(args[0])
+
+	if command == nil || command.HandlesSignals {
+		return fmt.Errorf("unknown command %s, type help", args[0])
+	}
+
+	//Ctrl-C aborts command, not shell
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	defer signal.Stop(signals)
+
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err := sh.env.Run(ctx, command, args[1:])
+
+	//Remember contract for completion of document types and fields
+	if err == nil && (command.Name == "contract" || command.Name == "documents") && len(args) > 1 && !strings.HasPrefix(args[1], "-") {
+		sh.contract(args[1])
+	}
+
+	if err == flag.ErrHelp {
+		return nil
+	}
+
+	if err == ErrUsage {
+		return fmt.Errorf("usage: %s", command.Usage)
+	}
+
+	return err
+}
+
+func (sh *shell) help(args []string) {
+	if len(args) > 0 {
+		if builtin, ok := shellBuiltins[args[0]]; ok {
+			_, _ = fmt.Fprintf(sh.env.Stdout, "usage: %s\n\n%s\n", builtin.usage, builtin.description)
+
+			return
+		}
+
+		if command := findCommand(args[0]); command != nil {
+			_ = sh.env.Run(context.Background(), command, []string{"-help"})
+
+			return
+		}
+	}
+
+	_, _ = fmt.Fprintf(sh.env.Stdout, "commands:\n")
+
+	for _, command := range Commands() {
+		if !command.HandlesSignals {
+			_, _ = fmt.Fprintf(sh.env.Stdout, "  %-16s%s\n", command.Name, command.Description)
+		}
+	}
+
+	for _, name := range sortedBuiltins() {
+		_, _ = fmt.Fprintf(sh.env.Stdout, "  %-16s%s\n", name, shellBuiltins[name].description)
+	}
+}
+
+func (sh *shell) use(ctx context.Context, args []string) error {
+	if len(args) > 1 {
+		return errors.New("usage: " + shellBuiltins["use"].usage)
+	}
+
+	previous := sh.env.NodeName()
+
+	if len(args) == 0 {
+		sh.env.UseNode("")
+
+		return nil
+	}
+
+	sh.env.UseNode(args[0])
+
+	//Refuse unreachable or foreign node
+	node, err := sh.env.Node(ctx)
+
+	if err == nil {
+		_, err = node.GetStatus()
+	}
+
+	if err != nil {
+		sh.env.UseNode(previous)
+
+		return fmt.Errorf("use %s: %s", args[0], err)
+	}
+
+	return nil
+}
+
+func (sh *shell) nodes() error {
+	dAPI, err := sh.env.Client()
+
+	if err != nil {
+		return err
+	}
+
+	type nodeView struct {
+		Node       string
+		Fraud      float64
+		LatencyP50 int64
+		Banned     bool
+	}
+
+	records := dAPI.NodeRecords()
+	views := make([]nodeView, 0, len(records))
+	now := time.Now()
+
+	for _, name := range sortedNodes(records) {
+		record := records[name]
+		views = append(views, nodeView{
+			Node:       name,
+			Fraud:      record.Fraud,
+			LatencyP50: record.LatencyP50,
+			Banned:     record.BanUntil.After(now),
+		})
+	}
+
+	return sh.env.Print(Result{Value: views})
+}
+
+//Data contract for completion, fetched once
+func (sh *shell) contract(id string) *platform.Contract {
+	if c, ok := sh.contracts[id]; ok {
+		return c
+	}
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+
+	if sh.env.timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), sh.env.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+
+	defer cancel()
+
+	node, err := sh.env.Node(ctx)
+
+	if err != nil {
+		return nil
+	}
+
+	response, err := node.GetDataContract(id)
+
+	if err != nil {
+		return nil
+	}
+
+	c, err := platform.ParseContract(response.DataContract)
+
+	if err != nil {
+		return nil
+	}
+
+	sh.contracts[id] = c
+
+	return c
+}
+
+//readline.AutoCompleter, completes word under cursor
+func (sh *shell) Do(line []rune, pos int) ([][]rune, int) {
+	words := strings.Fields(string(line[:pos]))
+	current := ""
+
+	if len(words) > 0 && !strings.HasSuffix(string(line[:pos]), " ") {
+		current = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	var result [][]rune
+
+	for _, candidate := range sh.candidates(words, current) {
+		if strings.HasPrefix(candidate, current) {
+			suffix := candidate[len(current):]
+
+			//JSON fragments are continued by user
+			if !strings.HasSuffix(candidate, ",") {
+				suffix += " "
+			}
+
+			result = append(result, []rune(suffix))
+		}
+	}
+
+	return result, len([]rune(current))
+}
+
+func (sh *shell) candidates(words []string, current string) []string {
+	if len(words) == 0 {
+		var names []string
+
+		for _, command := range Commands() {
+			if !command.HandlesSignals {
+				names = append(names, command.Name)
+			}
+		}
+
+		return append(names, sortedBuiltins()...)
+	}
+
+	switch words[0] {
+	case "help":
+		return sh.candidates(nil, current)
+	case "output":
+		return Outputs
+	case "use":
+		if dAPI, err := sh.env.Client(); err == nil {
+			return sortedNodes(dAPI.NodeRecords())
+		}
+
+		return nil
+	}
+
+	command := findCommand(words[0])
+
+	if command == nil {
+		return nil
+	}
+
+	flags := flag.NewFlagSet(command.Name, flag.ContinueOnError)
+	command.Setup(flags)
+
+	if strings.HasPrefix(current, "-") {
+		var names []string
+
+		flags.VisitAll(func(f *flag.Flag) {
+			names = append(names, "--"+f.Name)
+		})
+
+		return names
+	}
+
+	positional, valueOf := shellPositional(flags, words[1:])
+
+	if command.Name != "documents" && command.Name != "contract" {
+		return nil
+	}
+
+	var contract *platform.Contract
+
+	if len(positional) > 0 {
+		contract = sh.contract(positional[0])
+	}
+
+	switch valueOf {
+	case "where", "order-by":
+		if contract == nil || len(positional) < 2 {
+			return nil
+		}
+
+		var values []string
+
+		for _, field := range contract.IndexedFields(positional[1]) {
+			if valueOf == "where" {
+				values = append(values, `'[["`+field+`",`)
+			} else {
+				values = append(values, `'[["`+field+`","asc"]]'`, `'[["`+field+`","desc"]]'`)
+			}
+		}
+
+		return values
+	case "":
+	default:
+		return nil
+	}
+
+	switch len(positional) {
+	case 0:
+		ids := make([]string, 0, len(sh.contracts)+1)
+
+		for id := range sh.contracts {
+			ids = append(ids, id)
+		}
+
+		if sh.env.Network.DPNSContractID != "" && sh.contracts[sh.env.Network.DPNSContractID] == nil {
+			ids = append(ids, sh.env.Network.DPNSContractID)
+		}
+
+		sort.Strings(ids)
+
+		return ids
+	case 1:
+		if command.Name == "documents" && contract != nil {
+			return contract.DocumentTypes()
+		}
+	}
+
+	return nil
+}
+
+//Positional arguments before cursor and name of flag whose value is completed
+func shellPositional(flags *flag.FlagSet, words []string) ([]string, string) {
+	var positional []string
+
+	for i := 0; i < len(words); i++ {
+		name := strings.TrimLeft(words[i], "-")
+
+		if !strings.HasPrefix(words[i], "-") {
+			positional = append(positional, words[i])
+
+			continue
+		}
+
+		f := flags.Lookup(strings.SplitN(name, "=", 2)[0])
+
+		if f == nil || strings.Contains(name, "=") {
+			continue
+		}
+
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+			continue
+		}
+
+		if i == len(words)-1 {
+			return positional, f.Name
+		}
+
+		i++
+	}
+
+	return positional, ""
+}
+
+func sortedBuiltins() []string {
+	names := make([]string, 0, len(shellBuiltins))
+
+	for name := range shellBuiltins {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func sortedNodes(records map[string]db.NodeRecord) []string {
+	names := make([]string, 0, len(records))
+
+	for name := range records {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+//Split line into arguments, supports single and double quotes and backslash escapes
+func splitArgs(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg, escaped := false, false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, errors.New("unterminated quote or escape")
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/bloom"
+	"github.com/co-in/dash-dapi/evo/stream"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"github.com/co-in/dash-dapi/logging"
+	"time"
+)
+
+func streamCommand() *Command {
+	return &Command{
+		Name:        "stream",
+		Usage:       "stream (--address <address>... | --filter <hex> [--hash-funcs <n>] [--tweak <n>] [--flags <n>]) [--from-height <height>] [--count <n>]",
+		Description: "stream transactions, merkle blocks and InstantSend locks matching addresses or bloom filter until interrupted",
+		Setup: func(flags *flag.FlagSet) Action {
+			var addresses stringList
+			flags.Var(&addresses, "address", "watched address, repeatable")
+			filterData := flags.String("filter", "", "hex encoded bloom filter")
+			hashFuncs := flags.Uint("hash-funcs", 11, "number of hash functions of --filter")
+			tweak := flags.Uint("tweak", 0, "tweak of --filter")
+			filterFlags := flags.Uint("flags", uint(bloom.UpdateNone), "update flags of --filter")
+			fromHeight := flags.Int("from-height", -1, "start from block height, default only new transactions")
+			count := flags.Int("count", -1, "number of blocks to stream, default until interrupted")
+
+			return func(ctx context.Context, env *Env, args []string) error {
+				if len(args) != 0 || (len(addresses) == 0) == (*filterData == "") {
+					return ErrUsage
+				}
+
+				filter, err := streamFilter(env, addresses, *filterData, uint32(*hashFuncs), uint32(*tweak), uint32(*filterFlags))
+
+				if err != nil {
+					return err
+				}
+
+				dAPI, err := env.Client()
+
+				if err != nil {
+					return err
+				}
+
+				options := stream.DefaultOptions()
+				options.OnReconnect = func(e stream.ReconnectEvent) {
+					logging.Warn(env.Log, "Stream reconnected", logging.Any("from", e.From), logging.Node(e.To), logging.Any("from_block_height", e.FromBlockHeight), logging.Err(e.Err))
+				}
+
+				subscriber, err := stream.NewSubscriber(ctx, dAPI, structures.SubscribeToTransactionsWithProofsRequest{
+					BloomFilter:     filter,
+					FromBlockHeight: optionalInt(*fromHeight),
+					Count:           optionalInt(*count),
+				}, options)
+
+				if err != nil {
+					return err
+				}
+
+				events := subscriber.Events(100, stream.PolicyBlock)
+				subscriber.Start()
+
+				for e := range events {
+					if event, ok := e.(stream.ErrorEvent); ok {
+						return event.Err
+					}
+
+					err = env.PrintEvent(newEventView(env, e))
+
+					if err != nil {
+						return err
+					}
+				}
+
+				//Interrupted by signal
+				return nil
+			}
+		},
+	}
+}
+
+//Filter of address hashes or raw filter given by user
+func streamFilter(env *Env, addresses []string, data string, hashFuncs uint32, tweak uint32, flags uint32) (structures.BloomFilterRequest, error) {
+	if data != "" {
+		raw, err := hex.DecodeString(data)
+
+		if err != nil {
+			return structures.BloomFilterRequest{}, fmt.Errorf("--filter: %s", err)
+		}
+
+		return structures.BloomFilterRequest{
+			Data:     raw,
+			HashFunc: hashFuncs,
+			Tweak:    tweak,
+			Flags:    flags,
+		}, nil
+	}
+
+	filter, err := bloom.NewAddressFilter(addresses, env.Network.Address, tweak)
+
+	if err != nil {
+		return structures.BloomFilterRequest{}, err
+	}
+
+	return filter.Request(), nil
+}
+
+type transactionEventView struct {
+	Event       string
+	Transaction *transactionView
+}
+
+type merkleBlockEventView struct {
+	Event      string
+	Height     int
+	MerkleRoot string
+	Time       time.Time
+	//Txids of matched transactions
+	Matches []string
+}
+
+type instantLockEventView struct {
+	Event string
+	TxID  string
+}
+
+func newEventView(env *Env, e stream.Event) Result {
+	switch event := e.(type) {
+	case stream.TransactionEvent:
+		return Result{
+			Value: transactionEventView{Event: "transaction", Transaction: newTransactionView(env, event.Transaction)},
+			Raw:   [][]byte{event.Raw},
+		}
+	case stream.MerkleBlockEvent:
+		view := merkleBlockEventView{
+			Event:      "merkle_block",
+			Height:     event.Height,
+			MerkleRoot: hex.EncodeToString(transaction.ReverseBytes(event.MerkleBlock.Header.MerkleRoot[:])),
+			Time:       time.Unix(int64(event.MerkleBlock.Header.Timestamp), 0).UTC(),
+			Matches:    make([]string, 0, len(event.Matches)),
+		}
+
+		for _, hash := range event.Matches {
+			view.Matches = append(view.Matches, hex.EncodeToString(transaction.ReverseBytes(hash[:])))
+		}
+
+		return Result{Value: view, Raw: [][]byte{event.Raw}}
+	case stream.InstantLockEvent:
+		return Result{
+			Value: instantLockEventView{Event: "instant_lock", TxID: event.TxID},
+			Raw:   [][]byte{event.Raw},
+		}
+	}
+
+	return Result{}
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/block"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"time"
+)
+
+//Rendered values of commands, field names are labels of table output and keys of json/yaml
+
+type statusView struct {
+	Node            string
+	Network         string
+	CoreVersion     uint32
+	ProtocolVersion uint32
+	Blocks          uint32
+	TimeOffset      uint32
+	Connections     uint32
+	Proxy           string
+	Difficulty      float64
+	Testnet         bool
+	RelayFee        float64
+	Errors          string
+}
+
+type blockView struct {
+	Version    int32
+	PrevBlock  string
+	MerkleRoot string
+	Time       time.Time
+	Bits       string
+	Nonce      uint32
+	Size       int
+}
+
+func newBlockView(raw []byte) (*blockView, error) {
+	header, err := block.ParseHeader(raw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockView{
+		Version:    header.Version,
+		PrevBlock:  hex.EncodeToString(transaction.ReverseBytes(header.PrevBlock[:])),
+		MerkleRoot: hex.EncodeToString(transaction.ReverseBytes(header.MerkleRoot[:])),
+		Time:       time.Unix(int64(header.Timestamp), 0).UTC(),
+		Bits:       fmt.Sprintf("%08x", header.Bits),
+		Nonce:      header.Nonce,
+		Size:       len(raw),
+	}, nil
+}
+
+type inputView struct {
+	TxID     string
+	Index    uint32
+	Sequence uint32
+}
+
+type outputView struct {
+	Index int
+	Value int64
+	//Empty for non-standard scripts
+	Address string
+	Script  string
+}
+
+type transactionView struct {
+	TxID         string
+	Version      uint16
+	Type         uint16
+	LockTime     uint32
+	Inputs       []inputView
+	Outputs      []outputView
+	ExtraPayload string `json:",omitempty"`
+}
+
+func newTransactionView(env *Env, tx *transaction.Transaction) *transactionView {
+	view := &transactionView{
+		TxID:         tx.TxID(),
+		Version:      tx.Version,
+		Type:         tx.Type,
+		LockTime:     tx.LockTime,
+		Inputs:       make([]inputView, 0, len(tx.Inputs)),
+		Outputs:      make([]outputView, 0, len(tx.Outputs)),
+		ExtraPayload: hex.EncodeToString(tx.ExtraPayload),
+	}
+
+	for _, in := range tx.Inputs {
+		view.Inputs = append(view.Inputs, inputView{
+			TxID:     in.PreviousOutput.TxID(),
+			Index:    in.PreviousOutput.Index,
+			Sequence: in.Sequence,
+		})
+	}
+
+	for i, out := range tx.Outputs {
+		address, _ := transaction.ScriptToAddress(out.Script, env.Network.Address)
+		view.Outputs = append(view.Outputs, outputView{
+			Index:   i,
+			Value:   out.Value,
+			Address: address,
+			Script:  hex.EncodeToString(out.Script),
+		})
+	}
+
+	return view
+}
+
+type utxoView struct {
+	TotalItems int
+	Items      []structures.UTXOItemResponse
+}
+
+func newUTXOView(response *structures.UTXOResponse) *utxoView {
+	view := &utxoView{
+		TotalItems: response.TotalItems,
+		Items:      response.Items,
+	}
+
+	if view.Items == nil {
+		view.Items = []structures.UTXOItemResponse{}
+	}
+
+	return view
+}
+
+type addressSummaryView struct {
+	Addresses             []string
+	BalanceSat            int64
+	TotalReceivedSat      int64
+	TotalSentSat          int64
+	UnconfirmedBalanceSat int64
+	TxAppearances         int
+	Transactions          []string
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/co-in/dash-dapi/config"
+	"github.com/co-in/dash-dapi/db"
+	"github.com/co-in/dash-dapi/db/boltFile"
+	"github.com/co-in/dash-dapi/db/jsonFile"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+//Upgrade JSON file database schema or copy its state into bolt database
+func main() {
+	logger := log.New(os.Stdout, "", log.LstdFlags)
+	from := flag.String("from", "db.json", "source JSON database")
+	to := flag.String("to", "", "destination bolt database, empty to upgrade source in place")
+	configFile := flag.String("config", config.DefaultFileName, "configuration file receiving settings moved out of source")
+	dryRun := flag.Bool("dry-run", false, "verify schema migrations without writing")
+
+	//Default of -to is not printed by PrintDefaults, as it is empty
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		_, _ = fmt.Fprintf(out, "Usage: %s [-from file] [-to file] [-config file] [-dry-run]\n", filepath.Base(os.Args[0]))
+		_, _ = fmt.Fprintln(out, "  -to defaults to \"\": schema of -from is upgraded in place, other value copies state of -from into new bolt database")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	err := migrate(logger, *from, *to, *configFile, *dryRun)
+
+	if err != nil {
+		logger.Println(err)
+		os.Exit(1)
+	}
+}
+
+//Destination is closed before error is returned, so main may exit
+func migrate(logger *log.Logger, from string, to string, configFile string, dryRun bool) error {
+	source := jsonFile.NewDB(from)
+	report, err := source.Migrate(true)
+
+	if err != nil {
+		return err
+	}
+
+	logger.Printf("Schema of %s: version %d -> %d\n", from, report.From, report.To)
+
+	for _, m := range report.Applied {
+		logger.Printf("\t%s\n", m)
+	}
+
+	//Settings are written before source is rewritten without them
+	err = moveSettings(logger, report.Moved, from, configFile, dryRun)
+
+	if err != nil || dryRun {
+		return err
+	}
+
+	if to == "" {
+		_, err = source.Migrate(false)
+
+		return err
+	}
+
+	err = source.Load()
+
+	if err != nil {
+		return err
+	}
+
+	destination := boltFile.NewDB(to)
+	err = destination.Import(source.IBaseDatabase)
+	closeErr := destination.Close()
+
+	if err != nil {
+		return err
+	}
+
+	if closeErr != nil {
+		return closeErr
+	}
+
+	logger.Printf("Migrated %s to %s\n", from, to)
+
+	return nil
+}
+
+func moveSettings(logger *log.Logger, moved db.Document, from string, configFile string, dryRun bool) error {
+	if len(moved) == 0 {
+		return nil
+	}
+
+	var fields []string
+
+	for field, value := range moved {
+		fields = append(fields, fmt.Sprintf("%s=%s", field, value))
+	}
+
+	sort.Strings(fields)
+
+	if dryRun {
+		logger.Printf("Settings %v would be moved to %s\n", fields, configFile)
+
+		return nil
+	}
+
+	if filepath.Clean(configFile) == filepath.Clean(from) {
+		return fmt.Errorf("settings %v of %s must be moved to configuration file, use -config with other file", fields, from)
+	}
+
+	err := config.MergeFile(configFile, moved)
+
+	if err != nil {
+		return err
+	}
+
+	logger.Printf("Moved settings %v to %s\n", fields, configFile)
+
+	return nil
+}
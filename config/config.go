@@ -0,0 +1,417 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/network"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultFileName     = "config.json"
+	DefaultDatabaseFile = "db.json"
+	DefaultNetwork      = network.EvoNetName
+
+	EnvConfig      = "DAPI_CONFIG"
+	EnvNetwork     = "DAPI_NETWORK"
+	EnvJsonRpcPort = "DAPI_JSON_RPC_PORT"
+	EnvGRpcPort    = "DAPI_GRPC_PORT"
+	EnvSeedNodes   = "DAPI_SEED_NODES"
+	EnvDatabase    = "DAPI_DATABASE"
+	EnvVerbose     = "DAPI_VERBOSE"
+	EnvLogFormat   = "DAPI_LOG_FORMAT"
+)
+
+//Permissions of configuration file created by MergeFile
+const fileMode = 0644
+
+//Database file name keeping state in memory, nothing is written to disk
+const MemoryDatabase = ":memory:"
+
+//Formats of diagnostic log
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+//Operator settings, read-only at runtime. Field names match legacy db.json, so old file works as config
+//Ports and seeds not set explicitly are taken from network profile
+type Config struct {
+	Network        string   `json:"network"`
+	EvoJsonRpcPort uint16   `json:"evo_json_rpc_port"`
+	EvoGRpcPort    uint16   `json:"evo_grpc_port"`
+	SeedNodes      []string `json:"evo_nodes"`
+	DatabaseFile   string   `json:"database_file"`
+	VerboseLevel   int      `json:"verbose_level"`
+	LogFormat      string   `json:"log_format"`
+	//File Config was read from, it is set even when default file does not exist
+	FileName string `json:"-"`
+}
+
+//Loads Config with precedence: defaults < file < environment < flags
+type Loader struct {
+	flags       *flag.FlagSet
+	fileName    *string
+	network     *string
+	jsonRpcPort *uint
+	gRpcPort    *uint
+	seedNodes   *string
+	database    *string
+	verbose     *int
+	logFormat   *string
+}
+
+func Default() *Config {
+	return &Config{
+		Network:      DefaultNetwork,
+		DatabaseFile: DefaultDatabaseFile,
+		VerboseLevel: 1,
+		LogFormat:    LogFormatText,
+	}
+}
+
+//Register configuration flags, Load must be called after flags are parsed
+func NewLoader(flags *flag.FlagSet) *Loader {
+	return &Loader{
+		flags:       flags,
+		fileName:    flags.String("config", DefaultFileName, "configuration file (env "+EnvConfig+")"),
+		network:     flags.String("network", DefaultNetwork, "network profile: "+strings.Join(network.Names(), ", ")+" (env "+EnvNetwork+")"),
+		jsonRpcPort: flags.Uint("json-rpc-port", 0, "DAPI JSON-RPC port, default from network (env "+EnvJsonRpcPort+")"),
+		gRpcPort:    flags.Uint("grpc-port", 0, "DAPI gRPC port, default from network (env "+EnvGRpcPort+")"),
+		seedNodes:   flags.String("seed", "", "comma separated seed evonodes, default from network (env "+EnvSeedNodes+")"),
+		database:    flags.String("db", DefaultDatabaseFile, "state database file, "+MemoryDatabase+" keeps state in memory (env "+EnvDatabase+")"),
+		verbose:     flags.Int("verbose", 1, "verbose level: 0 errors, 1 warnings, 2 node failures, 3 payloads of calls (env "+EnvVerbose+")"),
+		logFormat:   flags.String("log-format", LogFormatText, "diagnostic log format: "+LogFormatText+", "+LogFormatJSON+" (env "+EnvLogFormat+")"),
+	}
+}
+
+func (l *Loader) Load() (*Config, error) {
+	set := make(map[string]bool)
+	l.flags.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	c := Default()
+
+	fileName, fileRequired := *l.fileName, set["config"]
+
+	if env, ok := os.LookupEnv(EnvConfig); ok && !set["config"] {
+		fileName, fileRequired = env, true
+	}
+
+	c.FileName = fileName
+	err := c.loadFile(fileName, fileRequired)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.loadEnv()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if set["network"] {
+		c.Network = *l.network
+	}
+
+	if set["json-rpc-port"] {
+		c.EvoJsonRpcPort, err = parsePort(strconv.FormatUint(uint64(*l.jsonRpcPort), 10))
+
+		if err != nil {
+			return nil, fmt.Errorf("-json-rpc-port: %s", err)
+		}
+	}
+
+	if set["grpc-port"] {
+		c.EvoGRpcPort, err = parsePort(strconv.FormatUint(uint64(*l.gRpcPort), 10))
+
+		if err != nil {
+			return nil, fmt.Errorf("-grpc-port: %s", err)
+		}
+	}
+
+	if set["seed"] {
+		c.SeedNodes = splitNodes(*l.seedNodes)
+	}
+
+	if set["db"] {
+		c.DatabaseFile = *l.database
+	}
+
+	if set["verbose"] {
+		c.VerboseLevel = *l.verbose
+	}
+
+	if set["log-format"] {
+		c.LogFormat = *l.logFormat
+	}
+
+	err = c.applyNetwork()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c, c.Validate()
+}
+
+//Selected network profile
+func (c *Config) NetworkParams() (*network.Params, error) {
+	return network.Get(c.Network)
+}
+
+func (c *Config) applyNetwork() error {
+	params, err := c.NetworkParams()
+
+	if err != nil {
+		return err
+	}
+
+	if c.EvoJsonRpcPort == 0 {
+		c.EvoJsonRpcPort = params.JsonRpcPort
+	}
+
+	if c.EvoGRpcPort == 0 {
+		c.EvoGRpcPort = params.GRpcPort
+	}
+
+	if len(c.SeedNodes) == 0 {
+		c.SeedNodes = append([]string(nil), params.SeedNodes...)
+	}
+
+	return nil
+}
+
+//Missing default file is not an error
+func (c *Config) loadFile(fileName string, required bool) error {
+	file, err := os.Open(fileName)
+
+	if os.IsNotExist(err) && !required {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	err = json.NewDecoder(file).Decode(c)
+
+	if err != nil {
+		return fmt.Errorf("%s: %s", fileName, err)
+	}
+
+	return nil
+}
+
+func (c *Config) loadEnv() error {
+	var err error
+
+	if v, ok := os.LookupEnv(EnvNetwork); ok {
+		c.Network = v
+	}
+
+	if v, ok := os.LookupEnv(EnvJsonRpcPort); ok {
+		c.EvoJsonRpcPort, err = parsePort(v)
+
+		if err != nil {
+			return fmt.Errorf("%s: %s", EnvJsonRpcPort, err)
+		}
+	}
+
+	if v, ok := os.LookupEnv(EnvGRpcPort); ok {
+		c.EvoGRpcPort, err = parsePort(v)
+
+		if err != nil {
+			return fmt.Errorf("%s: %s", EnvGRpcPort, err)
+		}
+	}
+
+	if v, ok := os.LookupEnv(EnvSeedNodes); ok {
+		c.SeedNodes = splitNodes(v)
+	}
+
+	if v, ok := os.LookupEnv(EnvDatabase); ok {
+		c.DatabaseFile = v
+	}
+
+	if v, ok := os.LookupEnv(EnvVerbose); ok {
+		c.VerboseLevel, err = strconv.Atoi(v)
+
+		if err != nil {
+			return fmt.Errorf("%s: %s", EnvVerbose, err)
+		}
+	}
+
+	if v, ok := os.LookupEnv(EnvLogFormat); ok {
+		c.LogFormat = v
+	}
+
+	return nil
+}
+
+func (c *Config) Validate() error {
+	if c.EvoJsonRpcPort == 0 {
+		return errors.New("invalid or missing evo_json_rpc_port")
+	}
+
+	if c.EvoGRpcPort == 0 {
+		return errors.New("invalid or missing evo_grpc_port")
+	}
+
+	if len(c.SeedNodes) == 0 {
+		return errors.New("empty evo_nodes")
+	}
+
+	if c.LogFormat != LogFormatText && c.LogFormat != LogFormatJSON {
+		return fmt.Errorf("invalid log_format %s, expected %s or %s", c.LogFormat, LogFormatText, LogFormatJSON)
+	}
+
+	return nil
+}
+
+//Add fields moved out of legacy database (e.g. ports) to configuration file, which is created when missing.
+//Fields already set in file are kept
+func MergeFile(fileName string, fields map[string]json.RawMessage) error {
+	document := make(map[string]json.RawMessage)
+	mode := os.FileMode(fileMode)
+	data, err := ioutil.ReadFile(fileName)
+
+	if err == nil {
+		err = json.Unmarshal(data, &document)
+
+		if err != nil {
+			return fmt.Errorf("%s: %s", fileName, err)
+		}
+
+		if info, err := os.Stat(fileName); err == nil {
+			mode = info.Mode().Perm()
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	changed := false
+
+	for field, value := range fields {
+		if _, ok := document[field]; !ok {
+			document[field] = value
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	data, err = json.MarshalIndent(document, "", "\t")
+
+	if err != nil {
+		return err
+	}
+
+	return writeFile(fileName, append(data, '\n'), mode)
+}
+
+//Write data to temporary file and atomically rename it, interrupted write keeps previous file
+func writeFile(fileName string, data []byte, mode os.FileMode) error {
+	dir, base := filepath.Split(fileName)
+
+	if dir == "" {
+		dir = "."
+	}
+
+	tempFile, err := ioutil.TempFile(dir, base+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	tempName := tempFile.Name()
+
+	defer func() {
+		//No-op after successful rename
+		_ = os.Remove(tempName)
+	}()
+
+	_, err = tempFile.Write(data)
+
+	if err == nil {
+		err = tempFile.Sync()
+	}
+
+	closeErr := tempFile.Close()
+
+	if err != nil {
+		return err
+	}
+
+	if closeErr != nil {
+		return closeErr
+	}
+
+	err = os.Chmod(tempName, mode)
+
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tempName, fileName)
+
+	if err != nil {
+		return err
+	}
+
+	return syncDir(dir)
+}
+
+//Persist rename in directory entry
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = d.Close()
+	}()
+
+	//Best effort, some platforms do not support fsync of directories
+	_ = d.Sync()
+
+	return nil
+}
+
+func parsePort(value string) (uint16, error) {
+	port, err := strconv.ParseUint(value, 10, 16)
+
+	if err != nil || port == 0 {
+		return 0, fmt.Errorf("invalid port %s", value)
+	}
+
+	return uint16(port), nil
+}
+
+func splitNodes(value string) []string {
+	var nodes []string
+
+	for _, node := range strings.Split(value, ",") {
+		if node = strings.TrimSpace(node); node != "" {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
@@ -0,0 +1,290 @@
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"github.com/co-in/dash-dapi/evo/network"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+//Environment of test process must not leak into loaded Config
+func clearEnv(t *testing.T) {
+	t.Helper()
+
+	for _, name := range []string{EnvConfig, EnvNetwork, EnvJsonRpcPort, EnvGRpcPort, EnvSeedNodes, EnvDatabase, EnvVerbose, EnvLogFormat} {
+		//Setenv restores previous value after test
+		t.Setenv(name, "")
+		_ = os.Unsetenv(name)
+	}
+}
+
+func writeConfig(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+	fileName := filepath.Join(dir, name)
+	err := ioutil.WriteFile(fileName, []byte(content), fileMode)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName
+}
+
+func load(args []string) (*Config, error) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	loader := NewLoader(flags)
+	err := flags.Parse(args)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return loader.Load()
+}
+
+func TestPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	file := writeConfig(t, dir, "file.json", `{
+		"network": "testnet",
+		"evo_json_rpc_port": 1001,
+		"evo_grpc_port": 1002,
+		"evo_nodes": ["file"],
+		"database_file": "file.json",
+		"verbose_level": 2,
+		"log_format": "json"
+	}`)
+	other := writeConfig(t, dir, "other.json", `{"network": "regtest"}`)
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		args     []string
+		expected Config
+	}{
+		{
+			name: "defaults",
+			expected: Config{
+				Network:        DefaultNetwork,
+				EvoJsonRpcPort: network.EvoNet.JsonRpcPort,
+				EvoGRpcPort:    network.EvoNet.GRpcPort,
+				SeedNodes:      network.EvoNet.SeedNodes,
+				DatabaseFile:   DefaultDatabaseFile,
+				VerboseLevel:   1,
+				LogFormat:      LogFormatText,
+				FileName:       DefaultFileName,
+			},
+		},
+		{
+			name: "file",
+			args: []string{"-config", file},
+			expected: Config{
+				Network:        network.TestNetName,
+				EvoJsonRpcPort: 1001,
+				EvoGRpcPort:    1002,
+				SeedNodes:      []string{"file"},
+				DatabaseFile:   "file.json",
+				VerboseLevel:   2,
+				LogFormat:      LogFormatJSON,
+				FileName:       file,
+			},
+		},
+		{
+			name: "environment over file",
+			env:  map[string]string{EnvGRpcPort: "2002", EnvSeedNodes: "env1, env2", EnvVerbose: "3"},
+			args: []string{"-config", file},
+			expected: Config{
+				Network:        network.TestNetName,
+				EvoJsonRpcPort: 1001,
+				EvoGRpcPort:    2002,
+				SeedNodes:      []string{"env1", "env2"},
+				DatabaseFile:   "file.json",
+				VerboseLevel:   3,
+				LogFormat:      LogFormatJSON,
+				FileName:       file,
+			},
+		},
+		{
+			name: "flags over environment",
+			env:  map[string]string{EnvGRpcPort: "2002", EnvSeedNodes: "env1, env2", EnvVerbose: "3", EnvLogFormat: LogFormatJSON},
+			args: []string{"-config", file, "-grpc-port", "3003", "-verbose", "0", "-db", MemoryDatabase, "-log-format", LogFormatText},
+			expected: Config{
+				Network:        network.TestNetName,
+				EvoJsonRpcPort: 1001,
+				EvoGRpcPort:    3003,
+				SeedNodes:      []string{"env1", "env2"},
+				DatabaseFile:   MemoryDatabase,
+				VerboseLevel:   0,
+				LogFormat:      LogFormatText,
+				FileName:       file,
+			},
+		},
+		{
+			name: "file from environment",
+			env:  map[string]string{EnvConfig: other},
+			expected: Config{
+				Network:        network.RegTestName,
+				EvoJsonRpcPort: network.RegTest.JsonRpcPort,
+				EvoGRpcPort:    network.RegTest.GRpcPort,
+				SeedNodes:      network.RegTest.SeedNodes,
+				DatabaseFile:   DefaultDatabaseFile,
+				VerboseLevel:   1,
+				LogFormat:      LogFormatText,
+				FileName:       other,
+			},
+		},
+		{
+			name: "file flag over environment",
+			env:  map[string]string{EnvConfig: other},
+			args: []string{"-config", file, "-network", network.EvoNetName, "-seed", "flag"},
+			expected: Config{
+				Network:        network.EvoNetName,
+				EvoJsonRpcPort: 1001,
+				EvoGRpcPort:    1002,
+				SeedNodes:      []string{"flag"},
+				DatabaseFile:   "file.json",
+				VerboseLevel:   2,
+				LogFormat:      LogFormatJSON,
+				FileName:       file,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clearEnv(t)
+
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+
+			c, err := load(test.args)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(*c, test.expected) {
+				t.Fatalf("config %+v, expected %+v", *c, test.expected)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	file := writeConfig(t, dir, "file.json", `{"network": "testnet"}`)
+	missing := filepath.Join(dir, "missing.json")
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+	}{
+		{name: "missing file of flag", args: []string{"-config", missing}},
+		{name: "missing file of environment", env: map[string]string{EnvConfig: missing}},
+		{name: "corrupt file", args: []string{"-config", writeConfig(t, dir, "corrupt.json", `{"network":`)}},
+		{name: "invalid port of environment", env: map[string]string{EnvJsonRpcPort: "65536"}, args: []string{"-config", file}},
+		{name: "invalid port of flag", args: []string{"-config", file, "-grpc-port", "0"}},
+		{name: "unknown network", env: map[string]string{EnvNetwork: "unknown"}, args: []string{"-config", file}},
+		{name: "invalid log format", args: []string{"-config", file, "-log-format", "xml"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clearEnv(t)
+
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+
+			_, err := load(test.args)
+
+			if err == nil {
+				t.Fatal("no error")
+			}
+		})
+	}
+}
+
+func TestMergeFile(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, DefaultFileName)
+	fields := map[string]json.RawMessage{
+		"evo_json_rpc_port": json.RawMessage("1001"),
+		"evo_grpc_port":     json.RawMessage("1002"),
+	}
+
+	//Missing file is created
+	err := MergeFile(fileName, fields)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ioutil.WriteFile(fileName, []byte(`{"evo_grpc_port": 2002, "network": "testnet"}`), fileMode)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Chmod(fileName, 0600)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Fields set in file are kept, permissions of existing file too
+	err = MergeFile(fileName, fields)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var document map[string]interface{}
+	err = json.Unmarshal(data, &document)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{"evo_json_rpc_port": 1001.0, "evo_grpc_port": 2002.0, "network": "testnet"}
+
+	if !reflect.DeepEqual(document, expected) {
+		t.Fatalf("document %v, expected %v", document, expected)
+	}
+
+	if info, _ := os.Stat(fileName); info.Mode().Perm() != 0600 {
+		t.Fatalf("mode %s", info.Mode())
+	}
+
+	//Temporary file is renamed over configuration file
+	entries, _ := ioutil.ReadDir(dir)
+
+	if len(entries) != 1 {
+		t.Fatalf("%d files in directory", len(entries))
+	}
+}
+
+func TestMergeFileCorrupt(t *testing.T) {
+	fileName := writeConfig(t, t.TempDir(), DefaultFileName, `{"network":`)
+
+	err := MergeFile(fileName, map[string]json.RawMessage{"evo_grpc_port": json.RawMessage("1002")})
+
+	if err == nil {
+		t.Fatal("corrupt file is overwritten")
+	}
+
+	if data, _ := ioutil.ReadFile(fileName); string(data) != `{"network":` {
+		t.Fatalf("file changed to %s", data)
+	}
+}
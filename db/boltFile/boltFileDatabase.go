@@ -0,0 +1,355 @@
+package boltFile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/co-in/dash-dapi/db"
+	"go.etcd.io/bbolt"
+	"time"
+)
+
+const (
+	fileMode = 0600
+	//Fail instead of waiting forever when file is locked by another process
+	openTimeout = time.Second
+)
+
+//Key of base state document in state bucket
+var stateKey = []byte("document")
+
+//Field of state document with node records, they are kept per node in node scores bucket instead
+const nodesField = "nodes"
+
+type database struct {
+	db.IBaseDatabase
+	fileName string
+	bolt     *bbolt.DB
+}
+
+type tx struct {
+	*bbolt.Tx
+}
+
+func NewDB(fileName string) *database {
+	d := new(database)
+	d.fileName = fileName
+	d.IBaseDatabase = db.NewBaseDatabase()
+
+	return d
+}
+
+func (d *database) open() error {
+	if d.bolt != nil {
+		return nil
+	}
+
+	bolt, err := bbolt.Open(d.fileName, fileMode, &bbolt.Options{Timeout: openTimeout})
+
+	if err != nil {
+		return err
+	}
+
+	err = bolt.Update(func(t *bbolt.Tx) error {
+		for _, name := range db.Buckets {
+			_, err := t.CreateBucketIfNotExists([]byte(name))
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		_ = bolt.Close()
+
+		return err
+	}
+
+	d.bolt = bolt
+
+	return nil
+}
+
+//Load state from database file, file is created on first run
+func (d *database) Load() error {
+	err := d.open()
+
+	if err != nil {
+		return err
+	}
+
+	return d.View(func(t db.IKeyValueTx) error {
+		state := d.IBaseDatabase
+
+		if data := t.Get(db.BucketState, stateKey); data != nil {
+			var err error
+			state, _, err = db.DecodeState(data)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		//Documents saved before records were moved to node scores bucket still have them
+		records := state.GetNodeRecords()
+
+		if records == nil {
+			records = make(map[string]db.NodeRecord)
+		}
+
+		err := t.ForEach(db.BucketNodeScores, func(key []byte, value []byte) error {
+			var record db.NodeRecord
+			err := json.Unmarshal(value, &record)
+
+			if err != nil {
+				return fmt.Errorf("record of node %s: %s", key, err)
+			}
+
+			records[string(key)] = record
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		state.SetNodeRecords(records)
+		d.IBaseDatabase = state
+
+		return nil
+	})
+}
+
+//Upgrade stored state to current schema version. Dry run only verifies migrations and reports them
+func (d *database) Migrate(dryRun bool) (*db.MigrationReport, error) {
+	var data []byte
+
+	err := d.View(func(t db.IKeyValueTx) error {
+		data = append(data, t.Get(db.BucketState, stateKey)...)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		return &db.MigrationReport{From: db.CurrentVersion, To: db.CurrentVersion}, nil
+	}
+
+	report, err := db.VerifyMigration(data)
+
+	if err != nil || dryRun {
+		return report, err
+	}
+
+	err = d.Load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return report, d.Save()
+}
+
+//Save state in single transaction. Record of node is written only when it was changed, records of removed nodes are deleted
+func (d *database) Save() error {
+	err := d.open()
+
+	if err != nil {
+		return err
+	}
+
+	data, err := stateDocument(d.IBaseDatabase)
+
+	if err != nil {
+		return err
+	}
+
+	records := make(map[string][]byte, len(d.GetNodeRecords()))
+
+	for node, record := range d.GetNodeRecords() {
+		records[node], err = json.Marshal(record)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return d.Update(func(t db.IKeyValueTx) error {
+		err := t.Put(db.BucketState, stateKey, data)
+
+		if err != nil {
+			return err
+		}
+
+		var removed [][]byte
+
+		err = t.ForEach(db.BucketNodeScores, func(key []byte, value []byte) error {
+			record, ok := records[string(key)]
+
+			if !ok {
+				removed = append(removed, append([]byte(nil), key...))
+			} else if bytes.Equal(record, value) {
+				delete(records, string(key))
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		//Bucket must not be changed while iterating it
+		for _, key := range removed {
+			err = t.Delete(db.BucketNodeScores, key)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		for node, record := range records {
+			err = t.Put(db.BucketNodeScores, []byte(node), record)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+//State document without node records, it keeps version, discovered nodes and current block hash only
+func stateDocument(state db.IBaseDatabase) ([]byte, error) {
+	data, err := json.Marshal(state)
+
+	if err != nil {
+		return nil, err
+	}
+
+	document := make(db.Document)
+	err = json.Unmarshal(data, &document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	delete(document, nodesField)
+
+	return json.Marshal(document)
+}
+
+//Copy plain state (e.g. IBaseDatabase of jsonFile) and save it
+func (d *database) Import(source db.IBaseDatabase) error {
+	data, err := json.Marshal(source)
+
+	if err != nil {
+		return err
+	}
+
+	state, _, err := db.DecodeState(data)
+
+	if err != nil {
+		return err
+	}
+
+	d.IBaseDatabase = state
+
+	return d.Save()
+}
+
+func (d *database) View(fn func(t db.IKeyValueTx) error) error {
+	err := d.open()
+
+	if err != nil {
+		return err
+	}
+
+	return d.bolt.View(func(t *bbolt.Tx) error {
+		return fn(tx{t})
+	})
+}
+
+//Batched writes, all or nothing
+func (d *database) Update(fn func(t db.IKeyValueTx) error) error {
+	err := d.open()
+
+	if err != nil {
+		return err
+	}
+
+	return d.bolt.Update(func(t *bbolt.Tx) error {
+		return fn(tx{t})
+	})
+}
+
+func (d *database) Close() error {
+	if d.bolt == nil {
+		return nil
+	}
+
+	err := d.bolt.Close()
+	d.bolt = nil
+
+	return err
+}
+
+func (t tx) bucket(name string) (*bbolt.Bucket, error) {
+	b := t.Bucket([]byte(name))
+
+	if b == nil {
+		return nil, errors.New("unknown bucket " + name)
+	}
+
+	return b, nil
+}
+
+//Value is valid only inside transaction
+func (t tx) Get(bucket string, key []byte) []byte {
+	b, err := t.bucket(bucket)
+
+	if err != nil {
+		return nil
+	}
+
+	return b.Get(key)
+}
+
+func (t tx) Put(bucket string, key []byte, value []byte) error {
+	b, err := t.bucket(bucket)
+
+	if err != nil {
+		return err
+	}
+
+	return b.Put(key, value)
+}
+
+func (t tx) Delete(bucket string, key []byte) error {
+	b, err := t.bucket(bucket)
+
+	if err != nil {
+		return err
+	}
+
+	return b.Delete(key)
+}
+
+func (t tx) ForEach(bucket string, fn func(key []byte, value []byte) error) error {
+	b, err := t.bucket(bucket)
+
+	if err != nil {
+		return err
+	}
+
+	return b.ForEach(fn)
+}
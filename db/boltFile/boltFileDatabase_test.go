@@ -0,0 +1,140 @@
+package boltFile
+
+import (
+	"encoding/json"
+	"github.com/co-in/dash-dapi/db"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestDB(t *testing.T) *database {
+	d := NewDB(filepath.Join(t.TempDir(), "state.db"))
+
+	t.Cleanup(func() {
+		_ = d.Close()
+	})
+
+	return d
+}
+
+func bucketKeys(t *testing.T, d *database, bucket string) []string {
+	var keys []string
+
+	err := d.View(func(tx db.IKeyValueTx) error {
+		return tx.ForEach(bucket, func(key []byte, value []byte) error {
+			keys = append(keys, string(key))
+
+			return nil
+		})
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return keys
+}
+
+func TestNodeRecords(t *testing.T) {
+	d := newTestDB(t)
+	err := d.Load()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	records := map[string]db.NodeRecord{
+		"10.0.0.1": {Fraud: 2, FraudUpdatedAt: updated, LatencySamples: []int64{20, 30}},
+		"10.0.0.2": {LastSuccess: updated},
+	}
+
+	d.SetDiscoveredNodes([]string{"10.0.0.1", "10.0.0.2"})
+	d.SetNodeRecords(records)
+	err = d.Save()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if keys := bucketKeys(t, d, db.BucketNodeScores); !reflect.DeepEqual(keys, []string{"10.0.0.1", "10.0.0.2"}) {
+		t.Fatalf("node keys %v", keys)
+	}
+
+	err = d.View(func(tx db.IKeyValueTx) error {
+		document := make(db.Document)
+		err := json.Unmarshal(tx.Get(db.BucketState, stateKey), &document)
+
+		if _, ok := document[nodesField]; ok {
+			t.Fatal("node records are kept in state document")
+		}
+
+		return err
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Removed node is deleted, others are reloaded
+	delete(records, "10.0.0.2")
+	d.SetNodeRecords(records)
+	err = d.Save()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewDB(d.fileName)
+	_ = d.Close()
+	err = loaded.Load()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer loaded.Close()
+
+	if !reflect.DeepEqual(loaded.GetNodeRecords(), records) {
+		t.Fatalf("loaded records %+v, expected %+v", loaded.GetNodeRecords(), records)
+	}
+
+	if !reflect.DeepEqual(loaded.GetDiscoveredNodes(), []string{"10.0.0.1", "10.0.0.2"}) {
+		t.Fatalf("discovered nodes %v", loaded.GetDiscoveredNodes())
+	}
+}
+
+//Records saved inside of state document by previous builds are moved to node scores bucket
+func TestNodeRecordsOfDocument(t *testing.T) {
+	d := newTestDB(t)
+
+	err := d.Update(func(tx db.IKeyValueTx) error {
+		return tx.Put(db.BucketState, stateKey, []byte(`{"version":3,"discovered_nodes":[],"current_block_hash":"","nodes":{"10.0.0.1":{"fraud":1}}}`))
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = d.Load()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d.GetNodeRecords()["10.0.0.1"].Fraud != 1 {
+		t.Fatalf("records %+v", d.GetNodeRecords())
+	}
+
+	err = d.Save()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if keys := bucketKeys(t, d, db.BucketNodeScores); !reflect.DeepEqual(keys, []string{"10.0.0.1"}) {
+		t.Fatalf("node keys %v", keys)
+	}
+}
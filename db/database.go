@@ -1,19 +1,37 @@
 package db
 
+import "time"
+
+//Persisted reputation of evonode
+type NodeRecord struct {
+	//Fraud score as of FraudUpdatedAt, decays with time
+	Fraud          float64   `json:"fraud"`
+	FraudUpdatedAt time.Time `json:"fraud_updated_at"`
+	//Recent request latencies, oldest first
+	LatencySamples []int64   `json:"latency_samples_ms,omitempty"`
+	LatencyP50     int64     `json:"latency_p50_ms"`
+	LatencyP90     int64     `json:"latency_p90_ms"`
+	LatencyP99     int64     `json:"latency_p99_ms"`
+	LastSuccess    time.Time `json:"last_success"`
+	LastFailure    time.Time `json:"last_failure"`
+	BanUntil       time.Time `json:"ban_until"`
+}
+
 type baseDatabase struct {
-	EvoJsonRpcPort   uint16   `json:"evo_json_rpc_port"`
-	EvoGRpcPort      uint16   `json:"evo_grpc_port"`
-	EvoNodes         []string `json:"evo_nodes"`
-	CurrentBlockHash string   `json:"current_block_hash"`
+	Version          int                   `json:"version"`
+	DiscoveredNodes  []string              `json:"discovered_nodes"`
+	CurrentBlockHash string                `json:"current_block_hash"`
+	Nodes            map[string]NodeRecord `json:"nodes"`
 }
 
 type IBaseDatabase interface {
-	GetEvoJsonRpcPort() uint16
-	GetEvoGRpcPort() uint16
-	GetEvoNodes() []string
-	SetEvoNodes(nodes []string)
+	GetVersion() int
+	GetDiscoveredNodes() []string
+	SetDiscoveredNodes(nodes []string)
 	GetCurrentBlockHash() string
 	SetCurrentBlockHash(hash string)
+	GetNodeRecords() map[string]NodeRecord
+	SetNodeRecords(records map[string]NodeRecord)
 }
 
 type IDatabase interface {
@@ -23,23 +41,22 @@ type IDatabase interface {
 }
 
 func NewBaseDatabase() *baseDatabase {
-	return &baseDatabase{}
-}
-
-func (d *baseDatabase) GetEvoNodes() []string {
-	return d.EvoNodes
+	return &baseDatabase{
+		Version: CurrentVersion,
+		Nodes:   make(map[string]NodeRecord),
+	}
 }
 
-func (d *baseDatabase) GetEvoJsonRpcPort() uint16 {
-	return d.EvoJsonRpcPort
+func (d *baseDatabase) GetVersion() int {
+	return d.Version
 }
 
-func (d *baseDatabase) GetEvoGRpcPort() uint16 {
-	return d.EvoGRpcPort
+func (d *baseDatabase) GetDiscoveredNodes() []string {
+	return d.DiscoveredNodes
 }
 
-func (d *baseDatabase) SetEvoNodes(nodes []string) {
-	d.EvoNodes = nodes
+func (d *baseDatabase) SetDiscoveredNodes(nodes []string) {
+	d.DiscoveredNodes = nodes
 }
 
 func (d *baseDatabase) GetCurrentBlockHash() string {
@@ -49,3 +66,45 @@ func (d *baseDatabase) GetCurrentBlockHash() string {
 func (d *baseDatabase) SetCurrentBlockHash(hash string) {
 	d.CurrentBlockHash = hash
 }
+
+func (d *baseDatabase) GetNodeRecords() map[string]NodeRecord {
+	return d.Nodes
+}
+
+func (d *baseDatabase) SetNodeRecords(records map[string]NodeRecord) {
+	d.Nodes = records
+}
+
+//Namespaces of key-value databases
+const (
+	BucketState      = "state"
+	BucketHeaders    = "headers"
+	BucketMnList     = "mnlist"
+	BucketWallet     = "wallet"
+	BucketNodeScores = "node_scores"
+	BucketCache      = "cache"
+)
+
+var Buckets = []string{
+	BucketState,
+	BucketHeaders,
+	BucketMnList,
+	BucketWallet,
+	BucketNodeScores,
+	BucketCache,
+}
+
+//Transaction of key-value database, writes are applied atomically on commit
+type IKeyValueTx interface {
+	Get(bucket string, key []byte) []byte
+	Put(bucket string, key []byte, value []byte) error
+	Delete(bucket string, key []byte) error
+	ForEach(bucket string, fn func(key []byte, value []byte) error) error
+}
+
+type IKeyValueDatabase interface {
+	IDatabase
+	View(fn func(tx IKeyValueTx) error) error
+	Update(fn func(tx IKeyValueTx) error) error
+	Close() error
+}
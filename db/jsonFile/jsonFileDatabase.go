@@ -2,13 +2,22 @@ package jsonFile
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/co-in/dash-dapi/db"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+)
+
+const (
+	backupSuffix = ".bak"
+	fileMode     = 0644
 )
 
 type config struct {
 	db.IBaseDatabase
 	fileName string
+	moved    db.Document
 }
 
 func NewDB(fileName string) *config {
@@ -19,43 +28,161 @@ func NewDB(fileName string) *config {
 	return c
 }
 
-//Save state to JSON config file
+//Save state to JSON config file.
+//Document is written to temporary file and atomically renamed, previous version is kept as .bak
 func (c *config) Save() error {
-	configFile, err := os.OpenFile(c.fileName, os.O_WRONLY, os.ModePerm)
+	dir, base := filepath.Split(c.fileName)
+
+	if dir == "" {
+		dir = "."
+	}
+
+	tempFile, err := ioutil.TempFile(dir, base+".*.tmp")
 
 	if err != nil {
 		return err
 	}
 
+	tempName := tempFile.Name()
+
 	defer func() {
-		_ = configFile.Close()
+		//No-op after successful rename
+		_ = os.Remove(tempName)
 	}()
 
-	jsonEncoder := json.NewEncoder(configFile)
+	err = c.write(tempFile)
+	closeErr := tempFile.Close()
+
+	if err != nil {
+		return err
+	}
+
+	if closeErr != nil {
+		return closeErr
+	}
+
+	mode := os.FileMode(fileMode)
+
+	if info, err := os.Stat(c.fileName); err == nil {
+		mode = info.Mode().Perm()
+
+		//Keep backup only of readable document, corrupt file must not replace good backup
+		if _, _, err := decodeFile(c.fileName); err == nil {
+			err = os.Rename(c.fileName, c.fileName+backupSuffix)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = os.Chmod(tempName, mode)
+
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tempName, c.fileName)
+
+	if err != nil {
+		return err
+	}
+
+	return syncDir(dir)
+}
+
+func (c *config) write(file *os.File) error {
+	jsonEncoder := json.NewEncoder(file)
 	jsonEncoder.SetIndent("", "\t")
-	err = jsonEncoder.Encode(c.IBaseDatabase)
+	err := jsonEncoder.Encode(c.IBaseDatabase)
+
+	if err != nil {
+		return err
+	}
 
-	return err
+	return file.Sync()
 }
 
-//Load Config from JSON file
+//Load Config from JSON file, falls back to backup when file is missing or corrupt
 func (c *config) Load() error {
-	configFile, err := os.Open(c.fileName)
+	state, report, err := decodeFile(c.fileName)
 
 	if err != nil {
-		return err
+		var backupErr error
+		state, report, backupErr = decodeFile(c.fileName + backupSuffix)
+
+		if backupErr != nil {
+			return err
+		}
 	}
 
-	defer func() {
-		_ = configFile.Close()
-	}()
+	c.IBaseDatabase = state
+	c.moved = report.Moved
+
+	return nil
+}
+
+//Fields of legacy document moved out by Load (see db.MigrationReport), they are lost by next Save
+func (c *config) Moved() db.Document {
+	return c.moved
+}
+
+//Read document, upgrade it to current schema version.
+//Trailing data (partially overwritten document) is rejected by decoder
+func decodeFile(fileName string) (db.IBaseDatabase, *db.MigrationReport, error) {
+	data, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	state, report, err := db.DecodeState(data)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %s", fileName, err)
+	}
+
+	return state, report, nil
+}
 
-	jsonParser := json.NewDecoder(configFile)
-	err = jsonParser.Decode(c.IBaseDatabase)
+//Upgrade file to current schema version. Dry run only verifies migrations and reports them,
+//Moved fields of report must be written to configuration file before file is upgraded
+func (c *config) Migrate(dryRun bool) (*db.MigrationReport, error) {
+	data, err := ioutil.ReadFile(c.fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := db.VerifyMigration(data)
+
+	if err != nil || dryRun {
+		return report, err
+	}
+
+	err = c.Load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return report, c.Save()
+}
+
+//Persist rename in directory entry
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
 
 	if err != nil {
 		return err
 	}
 
+	defer func() {
+		_ = d.Close()
+	}()
+
+	//Best effort, some platforms do not support fsync of directories
+	_ = d.Sync()
+
 	return nil
 }
@@ -0,0 +1,250 @@
+package jsonFile
+
+import (
+	"bytes"
+	"errors"
+	"github.com/co-in/dash-dapi/db"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func copyFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join("..", "testdata", name))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileName := filepath.Join(t.TempDir(), "db.json")
+	err = ioutil.WriteFile(fileName, data, fileMode)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName
+}
+
+func TestMigrate(t *testing.T) {
+	fileName := copyFixture(t, "v0.json")
+	legacy, _ := ioutil.ReadFile(fileName)
+	d := NewDB(fileName)
+
+	report, err := d.Migrate(true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.From != 0 || len(report.Applied) != 3 || len(report.Moved) != 2 {
+		t.Fatalf("dry run report %+v", report)
+	}
+
+	if data, _ := ioutil.ReadFile(fileName); !bytes.Equal(data, legacy) {
+		t.Fatal("dry run changed file")
+	}
+
+	_, err = d.Migrate(false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if backup, _ := ioutil.ReadFile(fileName + backupSuffix); !bytes.Equal(backup, legacy) {
+		t.Fatal("legacy document is not kept as backup")
+	}
+
+	upgraded, _ := ioutil.ReadFile(fileName)
+
+	//Upgraded file is migrated again without changes
+	report, err = d.Migrate(false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.From != report.To || len(report.Applied) != 0 || report.Moved != nil {
+		t.Fatalf("report of second run %+v", report)
+	}
+
+	if data, _ := ioutil.ReadFile(fileName); !bytes.Equal(data, upgraded) {
+		t.Fatalf("second run changed file\n%s\nexpected\n%s", data, upgraded)
+	}
+
+	if nodes := d.GetDiscoveredNodes(); len(nodes) != 2 || nodes[0] != "34.214.48.68" {
+		t.Fatalf("discovered nodes %v", nodes)
+	}
+}
+
+//Load migrates in memory and reports settings, which next Save drops
+func TestLoadMoved(t *testing.T) {
+	d := NewDB(copyFixture(t, "v1.json"))
+	err := d.Load()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	moved := d.Moved()
+
+	if string(moved["evo_json_rpc_port"]) != "3000" || string(moved["evo_grpc_port"]) != "3010" {
+		t.Fatalf("moved %v", moved)
+	}
+
+	d = NewDB(copyFixture(t, "v3.json"))
+	err = d.Load()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Moved() != nil {
+		t.Fatalf("moved %v", d.Moved())
+	}
+}
+
+//State failing to encode in the middle of document, like write interrupted by full disk
+type failingState struct {
+	db.IBaseDatabase
+}
+
+func (failingState) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("disk is full")
+}
+
+func saveHash(t *testing.T, d *config, hash string) {
+	t.Helper()
+	d.SetCurrentBlockHash(hash)
+	err := d.Save()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func loadHash(t *testing.T, fileName string) string {
+	t.Helper()
+	d := NewDB(fileName)
+	err := d.Load()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d.GetCurrentBlockHash()
+}
+
+func TestInterruptedSave(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "db.json")
+	d := NewDB(fileName)
+	saveHash(t, d, "first")
+
+	saved, _ := ioutil.ReadFile(fileName)
+	d.IBaseDatabase = failingState{d.IBaseDatabase}
+
+	if d.Save() == nil {
+		t.Fatal("failed write is saved")
+	}
+
+	if data, _ := ioutil.ReadFile(fileName); !bytes.Equal(data, saved) {
+		t.Fatal("failed write changed file")
+	}
+
+	//Temporary file left by killed process is not read and does not block next save
+	err := ioutil.WriteFile(filepath.Join(dir, "db.json.1.tmp"), saved[:len(saved)/2], fileMode)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hash := loadHash(t, fileName); hash != "first" {
+		t.Fatalf("loaded %s", hash)
+	}
+
+	d = NewDB(fileName)
+	saveHash(t, d, "second")
+
+	if hash := loadHash(t, fileName); hash != "second" {
+		t.Fatalf("loaded %s", hash)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "db.json.*.tmp"))
+
+	if len(files) != 1 {
+		t.Fatalf("temporary files %v", files)
+	}
+}
+
+func TestLoadBackup(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(fileName string) error
+	}{
+		{name: "truncated", corrupt: func(fileName string) error {
+			data, err := ioutil.ReadFile(fileName)
+
+			if err != nil {
+				return err
+			}
+
+			return ioutil.WriteFile(fileName, data[:len(data)/2], fileMode)
+		}},
+		{name: "trailing data", corrupt: func(fileName string) error {
+			data, err := ioutil.ReadFile(fileName)
+
+			if err != nil {
+				return err
+			}
+
+			return ioutil.WriteFile(fileName, append(data, data[len(data)/2:]...), fileMode)
+		}},
+		{name: "missing", corrupt: os.Remove},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), "db.json")
+			d := NewDB(fileName)
+			saveHash(t, d, "first")
+			saveHash(t, d, "second")
+
+			err := test.corrupt(fileName)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if hash := loadHash(t, fileName); hash != "first" {
+				t.Fatalf("loaded %s, expected backup", hash)
+			}
+
+			//Corrupt file does not replace good backup
+			saveHash(t, d, "third")
+
+			if backup := loadHash(t, fileName+backupSuffix); backup != "first" {
+				t.Fatalf("backup %s", backup)
+			}
+		})
+	}
+}
+
+func TestLoadCorruptBackup(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "db.json")
+	err := ioutil.WriteFile(fileName, []byte("{"), fileMode)
+
+	if err == nil {
+		err = ioutil.WriteFile(fileName+backupSuffix, []byte("{"), fileMode)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if NewDB(fileName).Load() == nil {
+		t.Fatal("loaded corrupt file and backup")
+	}
+}
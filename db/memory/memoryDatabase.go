@@ -0,0 +1,422 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/co-in/dash-dapi/db"
+	"sort"
+	"sync"
+)
+
+//Names of changed fields passed to hooks
+const (
+	FieldDiscoveredNodes  = "discovered_nodes"
+	FieldCurrentBlockHash = "current_block_hash"
+	FieldNodeRecords      = "nodes"
+	//Whole state and buckets replaced by Load or Restore
+	FieldState = "state"
+	//Keys of bucket written by Update
+	FieldBucket = "bucket"
+)
+
+type Change struct {
+	Field string
+	//Set for FieldBucket, keys are sorted
+	Bucket string
+	Keys   []string
+}
+
+//Snapshot document: state of current schema version and key-value buckets
+type snapshot struct {
+	State   json.RawMessage            `json:"state"`
+	Buckets map[string][]snapshotEntry `json:"buckets"`
+}
+
+//Keys may be binary, so both key and value are base64 strings
+type snapshotEntry struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+//Concurrency-safe IDatabase without filesystem, Save keeps snapshot in memory and Load returns to it
+type database struct {
+	sync.RWMutex
+	state   db.IBaseDatabase
+	saved   []byte
+	buckets map[string]map[string][]byte
+	hooks   []func(Change)
+}
+
+//Pending writes of Update, applied on commit
+type tx struct {
+	d       *database
+	changes map[string]map[string][]byte
+}
+
+func NewDB() *database {
+	return &database{
+		state:   db.NewBaseDatabase(),
+		buckets: newBuckets(),
+	}
+}
+
+func newBuckets() map[string]map[string][]byte {
+	buckets := make(map[string]map[string][]byte)
+
+	for _, name := range db.Buckets {
+		buckets[name] = make(map[string][]byte)
+	}
+
+	return buckets
+}
+
+//Register hook called after every change, hooks are called outside of lock
+func (d *database) OnChange(hook func(Change)) {
+	d.Lock()
+	defer d.Unlock()
+
+	d.hooks = append(d.hooks, hook)
+}
+
+func (d *database) notify(field string) {
+	d.notifyChange(Change{Field: field})
+}
+
+func (d *database) notifyChange(change Change) {
+	d.RLock()
+	hooks := make([]func(Change), len(d.hooks))
+	copy(hooks, d.hooks)
+	d.RUnlock()
+
+	for _, hook := range hooks {
+		hook(change)
+	}
+}
+
+func (d *database) GetVersion() int {
+	d.RLock()
+	defer d.RUnlock()
+
+	return d.state.GetVersion()
+}
+
+func (d *database) GetDiscoveredNodes() []string {
+	d.RLock()
+	defer d.RUnlock()
+
+	return append([]string(nil), d.state.GetDiscoveredNodes()...)
+}
+
+func (d *database) SetDiscoveredNodes(nodes []string) {
+	d.Lock()
+	d.state.SetDiscoveredNodes(append([]string(nil), nodes...))
+	d.Unlock()
+
+	d.notify(FieldDiscoveredNodes)
+}
+
+func (d *database) GetCurrentBlockHash() string {
+	d.RLock()
+	defer d.RUnlock()
+
+	return d.state.GetCurrentBlockHash()
+}
+
+func (d *database) SetCurrentBlockHash(hash string) {
+	d.Lock()
+	d.state.SetCurrentBlockHash(hash)
+	d.Unlock()
+
+	d.notify(FieldCurrentBlockHash)
+}
+
+func (d *database) GetNodeRecords() map[string]db.NodeRecord {
+	d.RLock()
+	defer d.RUnlock()
+
+	records := make(map[string]db.NodeRecord, len(d.state.GetNodeRecords()))
+
+	for node, record := range d.state.GetNodeRecords() {
+		records[node] = record
+	}
+
+	return records
+}
+
+func (d *database) SetNodeRecords(records map[string]db.NodeRecord) {
+	copied := make(map[string]db.NodeRecord, len(records))
+
+	for node, record := range records {
+		copied[node] = record
+	}
+
+	d.Lock()
+	d.state.SetNodeRecords(copied)
+	d.Unlock()
+
+	d.notify(FieldNodeRecords)
+}
+
+//State as JSON document of current schema version with content of buckets
+func (d *database) Snapshot() ([]byte, error) {
+	d.RLock()
+	defer d.RUnlock()
+
+	state, err := json.Marshal(d.state)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s := snapshot{
+		State:   state,
+		Buckets: make(map[string][]snapshotEntry, len(d.buckets)),
+	}
+
+	for name, bucket := range d.buckets {
+		entries := make([]snapshotEntry, 0, len(bucket))
+
+		for key, value := range bucket {
+			entries = append(entries, snapshotEntry{Key: []byte(key), Value: value})
+		}
+
+		sort.Slice(entries, func(i, j int) bool {
+			return string(entries[i].Key) < string(entries[j].Key)
+		})
+
+		s.Buckets[name] = entries
+	}
+
+	return json.Marshal(s)
+}
+
+//Replace state and buckets with snapshot, older schema versions are migrated.
+//State document without buckets (e.g. JSON database file) clears buckets
+func (d *database) Restore(data []byte) error {
+	var s snapshot
+	err := json.Unmarshal(data, &s)
+
+	if err != nil || s.State == nil {
+		s = snapshot{State: data}
+	}
+
+	state, _, err := db.DecodeState(s.State)
+
+	if err != nil {
+		return err
+	}
+
+	buckets := newBuckets()
+
+	for name, entries := range s.Buckets {
+		if _, ok := buckets[name]; !ok {
+			return errors.New("unknown bucket " + name + " in snapshot")
+		}
+
+		for _, entry := range entries {
+			buckets[name][string(entry.Key)] = copyBytes(entry.Value)
+		}
+	}
+
+	d.Lock()
+	d.state = state
+	d.buckets = buckets
+	d.Unlock()
+
+	d.notify(FieldState)
+
+	return nil
+}
+
+func (d *database) Save() error {
+	data, err := d.Snapshot()
+
+	if err != nil {
+		return err
+	}
+
+	d.Lock()
+	d.saved = data
+	d.Unlock()
+
+	return nil
+}
+
+//Return to last saved state, empty state when nothing was saved
+func (d *database) Load() error {
+	d.RLock()
+	saved := d.saved
+	d.RUnlock()
+
+	if saved == nil {
+		d.Lock()
+		d.state = db.NewBaseDatabase()
+		d.buckets = newBuckets()
+		d.Unlock()
+
+		d.notify(FieldState)
+
+		return nil
+	}
+
+	return d.Restore(saved)
+}
+
+func (d *database) View(fn func(t db.IKeyValueTx) error) error {
+	d.RLock()
+	defer d.RUnlock()
+
+	return fn(&tx{d: d})
+}
+
+//Batched writes, applied only when fn succeeds. Hooks get change of every written bucket after commit
+func (d *database) Update(fn func(t db.IKeyValueTx) error) error {
+	changes, err := d.commit(fn)
+
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		d.notifyChange(change)
+	}
+
+	return nil
+}
+
+//Apply writes of fn under lock, returns changes sorted by bucket
+func (d *database) commit(fn func(t db.IKeyValueTx) error) ([]Change, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	t := &tx{
+		d:       d,
+		changes: make(map[string]map[string][]byte),
+	}
+
+	err := fn(t)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+
+	for bucket, values := range t.changes {
+		change := Change{Field: FieldBucket, Bucket: bucket}
+
+		for key, value := range values {
+			if value == nil {
+				delete(d.buckets[bucket], key)
+			} else {
+				d.buckets[bucket][key] = value
+			}
+
+			change.Keys = append(change.Keys, key)
+		}
+
+		sort.Strings(change.Keys)
+		changes = append(changes, change)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Bucket < changes[j].Bucket
+	})
+
+	return changes, nil
+}
+
+func (d *database) Close() error {
+	return nil
+}
+
+//Copy of value, nil when key does not exist
+func (t *tx) Get(bucket string, key []byte) []byte {
+	value, ok := t.changes[bucket][string(key)]
+
+	if !ok {
+		value = t.d.buckets[bucket][string(key)]
+	}
+
+	if value == nil {
+		return nil
+	}
+
+	return copyBytes(value)
+}
+
+func (t *tx) put(bucket string, key []byte, value []byte) error {
+	if _, ok := t.d.buckets[bucket]; !ok {
+		return errors.New("unknown bucket " + bucket)
+	}
+
+	if t.changes == nil {
+		return errors.New("write in read-only transaction")
+	}
+
+	if t.changes[bucket] == nil {
+		t.changes[bucket] = make(map[string][]byte)
+	}
+
+	t.changes[bucket][string(key)] = value
+
+	return nil
+}
+
+//Empty value is stored, unlike nil of Delete
+func (t *tx) Put(bucket string, key []byte, value []byte) error {
+	return t.put(bucket, key, copyBytes(value))
+}
+
+//Non-nil copy, caller may reuse its slice
+func copyBytes(value []byte) []byte {
+	v := make([]byte, len(value))
+	copy(v, value)
+
+	return v
+}
+
+func (t *tx) Delete(bucket string, key []byte) error {
+	return t.put(bucket, key, nil)
+}
+
+//Keys are iterated in byte order like in bolt
+func (t *tx) ForEach(bucket string, fn func(key []byte, value []byte) error) error {
+	if _, ok := t.d.buckets[bucket]; !ok {
+		return errors.New("unknown bucket " + bucket)
+	}
+
+	keys := make(map[string]bool)
+
+	for key := range t.d.buckets[bucket] {
+		keys[key] = true
+	}
+
+	for key := range t.changes[bucket] {
+		keys[key] = true
+	}
+
+	sorted := make([]string, 0, len(keys))
+
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		value := t.Get(bucket, []byte(key))
+
+		if value == nil {
+			continue
+		}
+
+		err := fn([]byte(key), value)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,278 @@
+package memory
+
+import (
+	"errors"
+	"github.com/co-in/dash-dapi/db"
+	"reflect"
+	"testing"
+)
+
+func TestKeyValue(t *testing.T) {
+	d := NewDB()
+	var changes []Change
+
+	d.OnChange(func(change Change) {
+		changes = append(changes, change)
+	})
+
+	value := []byte("value")
+
+	err := d.Update(func(t db.IKeyValueTx) error {
+		err := t.Put(db.BucketCache, []byte("empty"), []byte{})
+
+		if err == nil {
+			err = t.Put(db.BucketCache, []byte("key"), value)
+		}
+
+		if err == nil {
+			err = t.Put(db.BucketWallet, []byte("nil"), nil)
+		}
+
+		return err
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Caller reuses its buffer
+	value[0] = 'X'
+
+	expected := []Change{
+		{Field: FieldBucket, Bucket: db.BucketCache, Keys: []string{"empty", "key"}},
+		{Field: FieldBucket, Bucket: db.BucketWallet, Keys: []string{"nil"}},
+	}
+
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("changes %+v, expected %+v", changes, expected)
+	}
+
+	err = d.View(func(tx db.IKeyValueTx) error {
+		if v := tx.Get(db.BucketCache, []byte("empty")); v == nil || len(v) != 0 {
+			t.Fatalf("empty value %v", v)
+		}
+
+		if v := tx.Get(db.BucketWallet, []byte("nil")); v == nil {
+			t.Fatal("nil value is deleted")
+		}
+
+		v := tx.Get(db.BucketCache, []byte("key"))
+
+		if string(v) != "value" {
+			t.Fatalf("value %q", v)
+		}
+
+		//Returned value is copy
+		v[0] = 'X'
+
+		if v = tx.Get(db.BucketCache, []byte("key")); string(v) != "value" {
+			t.Fatalf("stored value changed to %q", v)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func put(t *testing.T, d *database, bucket string, key string, value string) {
+	t.Helper()
+	err := d.Update(func(tx db.IKeyValueTx) error {
+		return tx.Put(bucket, []byte(key), []byte(value))
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func get(t *testing.T, d *database, bucket string, key string) []byte {
+	t.Helper()
+	var value []byte
+	err := d.View(func(tx db.IKeyValueTx) error {
+		value = tx.Get(bucket, []byte(key))
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return value
+}
+
+func TestSnapshot(t *testing.T) {
+	d := NewDB()
+	d.SetCurrentBlockHash("hash")
+	d.SetDiscoveredNodes([]string{"127.0.0.1"})
+	put(t, d, db.BucketWallet, "\x00\xffkey", "utxo")
+	put(t, d, db.BucketCache, "block", "")
+
+	data, err := d.Snapshot()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewDB()
+	var changes []Change
+
+	restored.OnChange(func(change Change) {
+		changes = append(changes, change)
+	})
+
+	put(t, restored, db.BucketWallet, "stale", "value")
+	changes = nil
+
+	err = restored.Restore(data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(changes, []Change{{Field: FieldState}}) {
+		t.Fatalf("changes %+v", changes)
+	}
+
+	if restored.GetCurrentBlockHash() != "hash" || !reflect.DeepEqual(restored.GetDiscoveredNodes(), []string{"127.0.0.1"}) {
+		t.Fatalf("restored state %s %v", restored.GetCurrentBlockHash(), restored.GetDiscoveredNodes())
+	}
+
+	if string(get(t, restored, db.BucketWallet, "\x00\xffkey")) != "utxo" || get(t, restored, db.BucketCache, "block") == nil {
+		t.Fatal("buckets are not restored")
+	}
+
+	if get(t, restored, db.BucketWallet, "stale") != nil {
+		t.Fatal("value missing in snapshot is kept")
+	}
+
+	//Snapshot of restored database is same
+	again, err := restored.Snapshot()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(again) != string(data) {
+		t.Fatalf("snapshot of restored database\n%s\nexpected\n%s", again, data)
+	}
+}
+
+func TestRestoreStateDocument(t *testing.T) {
+	d := NewDB()
+	put(t, d, db.BucketWallet, "key", "value")
+
+	err := d.Restore([]byte(`{"version": 3, "current_block_hash": "hash"}`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d.GetCurrentBlockHash() != "hash" || get(t, d, db.BucketWallet, "key") != nil {
+		t.Fatal("state document is not restored")
+	}
+
+	if d.Restore([]byte(`{"state": {}, "buckets": {"unknown": []}}`)) == nil {
+		t.Fatal("restored unknown bucket")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	d := NewDB()
+	d.SetCurrentBlockHash("saved")
+	put(t, d, db.BucketWallet, "key", "saved")
+
+	err := d.Save()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d.SetCurrentBlockHash("changed")
+	put(t, d, db.BucketWallet, "key", "changed")
+	put(t, d, db.BucketWallet, "new", "value")
+
+	err = d.Load()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d.GetCurrentBlockHash() != "saved" || string(get(t, d, db.BucketWallet, "key")) != "saved" || get(t, d, db.BucketWallet, "new") != nil {
+		t.Fatal("saved state is not loaded")
+	}
+
+	//Nothing saved loads empty database
+	d = NewDB()
+	put(t, d, db.BucketWallet, "key", "value")
+
+	err = d.Load()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if get(t, d, db.BucketWallet, "key") != nil {
+		t.Fatal("bucket is not emptied")
+	}
+}
+
+func TestStateHooks(t *testing.T) {
+	d := NewDB()
+	var changes []Change
+
+	d.OnChange(func(change Change) {
+		changes = append(changes, change)
+	})
+
+	d.SetDiscoveredNodes(nil)
+	d.SetCurrentBlockHash("hash")
+	d.SetNodeRecords(map[string]db.NodeRecord{"127.0.0.1": {}})
+
+	err := d.Load()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Change{{Field: FieldDiscoveredNodes}, {Field: FieldCurrentBlockHash}, {Field: FieldNodeRecords}, {Field: FieldState}}
+
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("changes %+v, expected %+v", changes, expected)
+	}
+}
+
+func TestUpdateFailure(t *testing.T) {
+	d := NewDB()
+	var changes []Change
+
+	d.OnChange(func(change Change) {
+		changes = append(changes, change)
+	})
+
+	err := d.Update(func(tx db.IKeyValueTx) error {
+		_ = tx.Put(db.BucketWallet, []byte("key"), []byte("value"))
+
+		return errors.New("failed")
+	})
+
+	if err == nil || get(t, d, db.BucketWallet, "key") != nil || changes != nil {
+		t.Fatal("writes of failed update are applied")
+	}
+
+	func() {
+		defer func() {
+			_ = recover()
+		}()
+
+		_ = d.Update(func(tx db.IKeyValueTx) error {
+			panic("update")
+		})
+	}()
+
+	//Panic does not leave database locked
+	put(t, d, db.BucketWallet, "key", "value")
+}
@@ -0,0 +1,196 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+//Schema version written by this build
+const CurrentVersion = 3
+
+//Document as stored on disk, migrations work on raw fields
+type Document map[string]json.RawMessage
+
+//Upgrade of document from Version-1 to Version. Fields removed from document, which belong to configuration file, are added to moved
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(document Document, moved Document) error
+}
+
+type MigrationReport struct {
+	From    int
+	To      int
+	Applied []string
+	//Fields moved out of database, they must be written to configuration file before migrated document is saved
+	Moved Document
+}
+
+var migrations []Migration
+
+func init() {
+	RegisterMigration(Migration{
+		Version:     1,
+		Description: "add schema version",
+		Up: func(document Document, moved Document) error {
+			return nil
+		},
+	})
+	RegisterMigration(Migration{
+		Version:     2,
+		Description: "add node reputation records",
+		Up: func(document Document, moved Document) error {
+			if _, ok := document["nodes"]; !ok {
+				document["nodes"] = json.RawMessage("{}")
+			}
+
+			return nil
+		},
+	})
+	RegisterMigration(Migration{
+		Version:     3,
+		Description: "move ports and seed nodes to configuration file, keep evo_nodes as discovered_nodes",
+		Up: func(document Document, moved Document) error {
+			for _, field := range []string{"evo_json_rpc_port", "evo_grpc_port"} {
+				if value, ok := document[field]; ok {
+					moved[field] = value
+					delete(document, field)
+				}
+			}
+
+			if nodes, ok := document["evo_nodes"]; ok {
+				document["discovered_nodes"] = nodes
+				delete(document, "evo_nodes")
+			}
+
+			return nil
+		},
+	})
+}
+
+//Migrations must be registered in order without gaps
+func RegisterMigration(m Migration) {
+	if m.Version != len(migrations)+1 {
+		panic(fmt.Sprintf("migration %d registered out of order", m.Version))
+	}
+
+	migrations = append(migrations, m)
+}
+
+func documentVersion(document Document) (int, error) {
+	raw, ok := document["version"]
+
+	//Files written before versioning
+	if !ok {
+		return 0, nil
+	}
+
+	var version int
+	err := json.Unmarshal(raw, &version)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid schema version: %s", err)
+	}
+
+	return version, nil
+}
+
+//Upgrade document to CurrentVersion
+func Migrate(data []byte) ([]byte, *MigrationReport, error) {
+	document := make(Document)
+	err := json.Unmarshal(data, &document)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	version, err := documentVersion(document)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if version > CurrentVersion {
+		return nil, nil, fmt.Errorf("database schema version %d is newer than supported %d", version, CurrentVersion)
+	}
+
+	report := &MigrationReport{From: version, To: CurrentVersion}
+
+	if version == CurrentVersion {
+		return data, report, nil
+	}
+
+	moved := make(Document)
+
+	for _, m := range migrations[version:] {
+		err = m.Up(document, moved)
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("migration %d (%s): %s", m.Version, m.Description, err)
+		}
+
+		document["version"] = json.RawMessage(fmt.Sprint(m.Version))
+		report.Applied = append(report.Applied, fmt.Sprintf("%d: %s", m.Version, m.Description))
+	}
+
+	if len(moved) > 0 {
+		report.Moved = moved
+	}
+
+	//Keys are sorted by encoding/json, result does not depend on map order
+	data, err = json.Marshal(document)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return data, report, nil
+}
+
+//Dry run: migrate and check that result decodes into current schema and is stable on second run
+func VerifyMigration(data []byte) (*MigrationReport, error) {
+	migrated, report, err := Migrate(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(migrated))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(NewBaseDatabase())
+
+	if err != nil {
+		return nil, fmt.Errorf("migrated document does not match schema: %s", err)
+	}
+
+	again, _, err := Migrate(migrated)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(again, migrated) {
+		return nil, fmt.Errorf("migration is not idempotent")
+	}
+
+	return report, nil
+}
+
+//Migrate stored document and decode it into state
+func DecodeState(data []byte) (IBaseDatabase, *MigrationReport, error) {
+	migrated, report, err := Migrate(data)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	state := NewBaseDatabase()
+	err = json.Unmarshal(migrated, state)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return state, report, nil
+}
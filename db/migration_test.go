@@ -0,0 +1,144 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var ports = Document{
+	"evo_json_rpc_port": json.RawMessage("3000"),
+	"evo_grpc_port":     json.RawMessage("3010"),
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+//Fixtures are indented in order of fields of schema, migrated documents have sorted keys
+func decode(t *testing.T, data []byte) interface{} {
+	t.Helper()
+	var document interface{}
+	err := json.Unmarshal(data, &document)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return document
+}
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected string
+		from     int
+		applied  int
+		moved    Document
+	}{
+		{fixture: "v0.json", expected: "v3_empty_nodes.json", from: 0, applied: 3, moved: ports},
+		{fixture: "v1.json", expected: "v3_empty_nodes.json", from: 1, applied: 2, moved: ports},
+		{fixture: "v2.json", expected: "v3.json", from: 2, applied: 1, moved: ports},
+		{fixture: "v3.json", expected: "v3.json", from: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			migrated, report, err := Migrate(readFixture(t, test.fixture))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(decode(t, migrated), decode(t, readFixture(t, test.expected))) {
+				t.Fatalf("migrated document\n%s\nexpected %s", migrated, test.expected)
+			}
+
+			if report.From != test.from || report.To != CurrentVersion || len(report.Applied) != test.applied {
+				t.Fatalf("report %+v", report)
+			}
+
+			if !reflect.DeepEqual(report.Moved, test.moved) {
+				t.Fatalf("moved %v, expected %v", report.Moved, test.moved)
+			}
+
+			//Second run changes nothing
+			again, report, err := Migrate(migrated)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(again, migrated) || len(report.Applied) != 0 || report.Moved != nil {
+				t.Fatalf("second run changed document\n%s\nreport %+v", again, report)
+			}
+		})
+	}
+}
+
+func TestVerifyMigration(t *testing.T) {
+	report, err := VerifyMigration(readFixture(t, "v0.json"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"1: add schema version",
+		"2: add node reputation records",
+		"3: move ports and seed nodes to configuration file, keep evo_nodes as discovered_nodes",
+	}
+
+	if report.From != 0 || report.To != CurrentVersion || !reflect.DeepEqual(report.Applied, expected) {
+		t.Fatalf("report %+v", report)
+	}
+
+	if !reflect.DeepEqual(report.Moved, ports) {
+		t.Fatalf("moved %v", report.Moved)
+	}
+}
+
+func TestVerifyMigrationErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		document []byte
+		err      string
+	}{
+		{
+			name:     "unknown field",
+			document: readFixture(t, "unknown_field.json"),
+			err:      `migrated document does not match schema: json: unknown field "evo_json_rpc_port"`,
+		},
+		{
+			name:     "newer version",
+			document: []byte(`{"version": 4}`),
+			err:      "database schema version 4 is newer than supported 3",
+		},
+		{
+			name:     "invalid version",
+			document: []byte(`{"version": "3"}`),
+			err:      "invalid schema version",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := VerifyMigration(test.document)
+
+			if err == nil || !strings.HasPrefix(err.Error(), test.err) {
+				t.Fatalf("error %v, expected %s", err, test.err)
+			}
+		})
+	}
+}
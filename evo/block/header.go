@@ -0,0 +1,109 @@
+package block
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"io"
+)
+
+const HeaderSize = 80
+
+type Header struct {
+	Version    int32
+	PrevBlock  [32]byte
+	MerkleRoot [32]byte
+	Timestamp  uint32
+	Bits       uint32
+	Nonce      uint32
+}
+
+//Parse 80 bytes block header
+func ParseHeader(data []byte) (*Header, error) {
+	if len(data) < HeaderSize {
+		return nil, errors.New("block header is too short")
+	}
+
+	return readHeader(bytes.NewReader(data[:HeaderSize]))
+}
+
+func readHeader(r io.Reader) (*Header, error) {
+	var buf [HeaderSize]byte
+
+	_, err := io.ReadFull(r, buf[:])
+
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Header{
+		Version:   int32(binary.LittleEndian.Uint32(buf[0:4])),
+		Timestamp: binary.LittleEndian.Uint32(buf[68:72]),
+		Bits:      binary.LittleEndian.Uint32(buf[72:76]),
+		Nonce:     binary.LittleEndian.Uint32(buf[76:80]),
+	}
+
+	copy(h.PrevBlock[:], buf[4:36])
+	copy(h.MerkleRoot[:], buf[36:68])
+
+	return h, nil
+}
+
+//Coinbase special transaction (DIP4), its payload starts with version and block height
+const coinbaseTxType = 5
+
+//Height of raw block read from its coinbase: DIP4 payload, or BIP34 height pushed by coinbase script
+func Height(data []byte) (int, error) {
+	r := bytes.NewReader(data)
+	_, err := readHeader(r)
+
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := transaction.ReadVarInt(r)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if count == 0 {
+		return 0, errors.New("block without coinbase")
+	}
+
+	coinbase, err := transaction.Read(r)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if coinbase.Type == coinbaseTxType && len(coinbase.ExtraPayload) >= 6 {
+		return int(binary.LittleEndian.Uint32(coinbase.ExtraPayload[2:6])), nil
+	}
+
+	if len(coinbase.Inputs) != 1 || len(coinbase.Inputs[0].Script) == 0 {
+		return 0, errors.New("invalid coinbase")
+	}
+
+	script := coinbase.Inputs[0].Script
+
+	//OP_1 .. OP_16
+	if script[0] >= 0x51 && script[0] <= 0x60 {
+		return int(script[0] - 0x50), nil
+	}
+
+	size := int(script[0])
+
+	if size < 1 || size > 4 || len(script) < 1+size {
+		return 0, errors.New("coinbase script does not start with height")
+	}
+
+	height := 0
+
+	for i := size; i >= 1; i-- {
+		height = height<<8 | int(script[i])
+	}
+
+	return height, nil
+}
@@ -0,0 +1,65 @@
+package block
+
+import (
+	"encoding/binary"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"testing"
+)
+
+func coinbaseBlock(coinbase *transaction.Transaction) []byte {
+	coinbase.Inputs = []transaction.Input{{PreviousOutput: transaction.OutPoint{Index: 0xffffffff}, Script: coinbase.Inputs[0].Script, Sequence: transaction.DefaultSequence}}
+	coinbase.Outputs = []transaction.Output{{Script: []byte{0x6a}}}
+
+	return append(append(make([]byte, HeaderSize), 1), coinbase.Bytes()...)
+}
+
+func TestHeight(t *testing.T) {
+	payload := make([]byte, 2+4+32)
+	binary.LittleEndian.PutUint16(payload, 2)
+	binary.LittleEndian.PutUint32(payload[2:], 1234567)
+
+	tests := []struct {
+		name     string
+		coinbase *transaction.Transaction
+		height   int
+	}{
+		{
+			name:     "coinbase payload",
+			coinbase: &transaction.Transaction{Version: 3, Type: coinbaseTxType, Inputs: []transaction.Input{{Script: []byte{1, 1}}}, ExtraPayload: payload},
+			height:   1234567,
+		},
+		{
+			name:     "BIP34 script with sign byte",
+			coinbase: &transaction.Transaction{Version: 2, Inputs: []transaction.Input{{Script: []byte{2, 0x95, 0x00, 0xff}}}},
+			height:   149,
+		},
+		{
+			name:     "BIP34 script of three bytes",
+			coinbase: &transaction.Transaction{Version: 2, Inputs: []transaction.Input{{Script: []byte{3, 0x40, 0x42, 0x0f}}}},
+			height:   1000000,
+		},
+		{
+			name:     "small height opcode",
+			coinbase: &transaction.Transaction{Version: 1, Inputs: []transaction.Input{{Script: []byte{0x55}}}},
+			height:   5,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			height, err := Height(coinbaseBlock(test.coinbase))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if height != test.height {
+				t.Fatalf("height %d, expected %d", height, test.height)
+			}
+		})
+	}
+
+	if _, err := Height(make([]byte, HeaderSize+1)); err == nil {
+		t.Fatal("height of block without coinbase")
+	}
+}
@@ -0,0 +1,94 @@
+package block
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"io"
+)
+
+const (
+	blsSignatureSize = 96
+	//Limit of inputs per lock, protects from malformed data
+	maxLockInputs = 1 << 16
+)
+
+//InstantSend lock (DIP10 islock message)
+type InstantSendLock struct {
+	Inputs    []transaction.OutPoint
+	TxHash    [32]byte
+	Signature [blsSignatureSize]byte
+}
+
+//ChainLock signature (DIP8 clsig message)
+type ChainLock struct {
+	Height    int32
+	BlockHash [32]byte
+	Signature [blsSignatureSize]byte
+}
+
+func ParseInstantSendLock(data []byte) (*InstantSendLock, error) {
+	r := bytes.NewReader(data)
+	count, err := transaction.ReadVarInt(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if count > maxLockInputs {
+		return nil, errors.New("too many inputs in InstantSend lock")
+	}
+
+	l := &InstantSendLock{
+		Inputs: make([]transaction.OutPoint, count),
+	}
+
+	for i := range l.Inputs {
+		_, err = io.ReadFull(r, l.Inputs[i].Hash[:])
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = binary.Read(r, binary.LittleEndian, &l.Inputs[i].Index)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = io.ReadFull(r, l.TxHash[:])
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.ReadFull(r, l.Signature[:])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+//Txid in RPC (reversed) hex order
+func (l *InstantSendLock) TxID() string {
+	return transaction.OutPoint{Hash: l.TxHash}.TxID()
+}
+
+func ParseChainLock(data []byte) (*ChainLock, error) {
+	if len(data) != 4+32+blsSignatureSize {
+		return nil, errors.New("invalid ChainLock size")
+	}
+
+	l := &ChainLock{
+		Height: int32(binary.LittleEndian.Uint32(data[:4])),
+	}
+
+	copy(l.BlockHash[:], data[4:36])
+	copy(l.Signature[:], data[36:])
+
+	return l, nil
+}
@@ -0,0 +1,159 @@
+package block
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"io"
+)
+
+//Limit of transactions per block, protects from malformed data
+const maxBlockTransactions = 1 << 20
+
+//BIP37 filtered block
+type MerkleBlock struct {
+	Header       *Header
+	Transactions uint32
+	Hashes       [][32]byte
+	Flags        []byte
+}
+
+func ParseMerkleBlock(data []byte) (*MerkleBlock, error) {
+	r := bytes.NewReader(data)
+	header, err := readHeader(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m := &MerkleBlock{Header: header}
+
+	err = binary.Read(r, binary.LittleEndian, &m.Transactions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := transaction.ReadVarInt(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if count > maxBlockTransactions {
+		return nil, errors.New("too many hashes in merkle block")
+	}
+
+	m.Hashes = make([][32]byte, count)
+
+	for i := range m.Hashes {
+		_, err = io.ReadFull(r, m.Hashes[i][:])
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m.Flags, err = transaction.ReadVarBytes(r, maxBlockTransactions/8+1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+type partialTree struct {
+	*MerkleBlock
+	bitsUsed   int
+	hashesUsed int
+	matches    [][32]byte
+}
+
+func (p *partialTree) width(height uint) uint32 {
+	return (p.Transactions + (1 << height) - 1) >> height
+}
+
+func (p *partialTree) traverse(height uint, pos uint32) ([32]byte, error) {
+	var hash [32]byte
+
+	if p.bitsUsed >= len(p.Flags)*8 {
+		return hash, errors.New("merkle block flags overflow")
+	}
+
+	flag := p.Flags[p.bitsUsed/8]>>(uint(p.bitsUsed)%8)&1 == 1
+	p.bitsUsed++
+
+	if height == 0 || !flag {
+		if p.hashesUsed >= len(p.Hashes) {
+			return hash, errors.New("merkle block hashes overflow")
+		}
+
+		hash = p.Hashes[p.hashesUsed]
+		p.hashesUsed++
+
+		if height == 0 && flag {
+			p.matches = append(p.matches, hash)
+		}
+
+		return hash, nil
+	}
+
+	left, err := p.traverse(height-1, pos*2)
+
+	if err != nil {
+		return hash, err
+	}
+
+	right := left
+
+	if pos*2+1 < p.width(height-1) {
+		right, err = p.traverse(height-1, pos*2+1)
+
+		if err != nil {
+			return hash, err
+		}
+
+		//CVE-2012-2459
+		if right == left {
+			return hash, errors.New("duplicate hashes in merkle branch")
+		}
+	}
+
+	return transaction.DoubleHash(append(left[:], right[:]...)), nil
+}
+
+//Verify partial merkle tree against header and return matched transaction hashes (internal byte order)
+func (m *MerkleBlock) ExtractMatches() ([][32]byte, error) {
+	if m.Transactions == 0 || m.Transactions > maxBlockTransactions {
+		return nil, errors.New("invalid transaction count in merkle block")
+	}
+
+	if len(m.Hashes) > int(m.Transactions) {
+		return nil, errors.New("more hashes than transactions in merkle block")
+	}
+
+	p := &partialTree{MerkleBlock: m}
+	height := uint(0)
+
+	for p.width(height) > 1 {
+		height++
+	}
+
+	root, err := p.traverse(height, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if (p.bitsUsed+7)/8 != len(m.Flags) || p.hashesUsed != len(m.Hashes) {
+		return nil, errors.New("unused data in merkle block")
+	}
+
+	if root != m.Header.MerkleRoot {
+		return nil, errors.New("merkle root mismatch")
+	}
+
+	return p.matches, nil
+}
@@ -0,0 +1,46 @@
+package bloom
+
+import (
+	"errors"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/transaction"
+)
+
+//False positive rate of address filters
+const addressFalsePositiveRate = 0.0001
+
+//Filter matching payments to P2PKH/P2SH addresses.
+//Spending transactions match outpoints added by node (UpdateAll)
+func NewAddressFilter(addresses []string, params transaction.AddressParams, tweak uint32) (*Filter, error) {
+	filter := New(len(addresses), addressFalsePositiveRate, tweak, UpdateAll)
+
+	for _, address := range addresses {
+		script, err := transaction.AddressToScript(address, params)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", address, err)
+		}
+
+		hash, err := scriptHash(script)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", address, err)
+		}
+
+		filter.Add(hash)
+	}
+
+	return filter, nil
+}
+
+//Hash160 pushed by P2PKH/P2SH script, it is matched by bloom filter
+func scriptHash(script []byte) ([]byte, error) {
+	switch {
+	case transaction.IsPayToPubKeyHash(script):
+		return script[3:23], nil
+	case transaction.IsPayToScriptHash(script):
+		return script[2:22], nil
+	}
+
+	return nil, errors.New("unsupported script")
+}
@@ -0,0 +1,162 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"math"
+	"sync"
+)
+
+const (
+	//Filter update flags (BIP37)
+	UpdateNone         uint32 = 0
+	UpdateAll          uint32 = 1
+	UpdateP2PubKeyOnly uint32 = 2
+
+	maxFilterSize = 36000
+	maxHashFuncs  = 50
+	ln2Squared    = math.Ln2 * math.Ln2
+	seedStep      = 0xfba4c795
+)
+
+//BIP37 bloom filter
+type Filter struct {
+	sync.Mutex
+	data      []byte
+	hashFuncs uint32
+	tweak     uint32
+	flags     uint32
+}
+
+func New(elements int, falsePositiveRate float64, tweak uint32, flags uint32) *Filter {
+	if elements < 1 {
+		elements = 1
+	}
+
+	size := int(-1 / ln2Squared * float64(elements) * math.Log(falsePositiveRate) / 8)
+
+	if size > maxFilterSize {
+		size = maxFilterSize
+	}
+
+	if size < 1 {
+		size = 1
+	}
+
+	hashFuncs := uint32(float64(size*8) / float64(elements) * math.Ln2)
+
+	if hashFuncs > maxHashFuncs {
+		hashFuncs = maxHashFuncs
+	}
+
+	if hashFuncs < 1 {
+		hashFuncs = 1
+	}
+
+	return &Filter{
+		data:      make([]byte, size),
+		hashFuncs: hashFuncs,
+		tweak:     tweak,
+		flags:     flags,
+	}
+}
+
+func (f *Filter) index(hashNum uint32, data []byte) uint32 {
+	return murmur3(hashNum*seedStep+f.tweak, data) % uint32(len(f.data)*8)
+}
+
+func (f *Filter) Add(data []byte) {
+	f.Lock()
+	defer f.Unlock()
+
+	for i := uint32(0); i < f.hashFuncs; i++ {
+		idx := f.index(i, data)
+		f.data[idx>>3] |= 1 << (idx & 7)
+	}
+}
+
+//Add outpoint (hash in internal byte order)
+func (f *Filter) AddOutPoint(hash [32]byte, index uint32) {
+	data := make([]byte, 36)
+	copy(data, hash[:])
+	binary.LittleEndian.PutUint32(data[32:], index)
+	f.Add(data)
+}
+
+func (f *Filter) Contains(data []byte) bool {
+	f.Lock()
+	defer f.Unlock()
+
+	for i := uint32(0); i < f.hashFuncs; i++ {
+		idx := f.index(i, data)
+
+		if f.data[idx>>3]&(1<<(idx&7)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+//Filter for SubscribeToTransactionsWithProofs
+func (f *Filter) Request() structures.BloomFilterRequest {
+	f.Lock()
+	defer f.Unlock()
+
+	data := make([]byte, len(f.data))
+	copy(data, f.data)
+
+	return structures.BloomFilterRequest{
+		Data:     data,
+		HashFunc: f.hashFuncs,
+		Tweak:    f.tweak,
+		Flags:    f.flags,
+	}
+}
+
+func murmur3(seed uint32, data []byte) uint32 {
+	const (
+		c1 = 0xcc9e2d51
+		c2 = 0x1b873593
+	)
+
+	h := seed
+	blocks := len(data) / 4
+
+	for i := 0; i < blocks; i++ {
+		k := binary.LittleEndian.Uint32(data[i*4:])
+		k *= c1
+		k = k<<15 | k>>17
+		k *= c2
+		h ^= k
+		h = h<<13 | h>>19
+		h = h*5 + 0xe6546b64
+	}
+
+	var k uint32
+	tail := data[blocks*4:]
+
+	switch len(tail) {
+	case 3:
+		k ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(tail[0])
+		k *= c1
+		k = k<<15 | k>>17
+		k *= c2
+		h ^= k
+	}
+
+	h ^= uint32(len(data))
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+
+	return h
+}
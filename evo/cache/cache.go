@@ -0,0 +1,445 @@
+package cache
+
+import (
+	"container/list"
+	"encoding/hex"
+	"fmt"
+	"github.com/co-in/dash-dapi/db"
+	"github.com/co-in/dash-dapi/evo/block"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Options struct {
+	//Limit of immutable entries kept in memory, least recently used are evicted
+	MaxEntries int
+	//Lifetime of per-node responses, which change with every block
+	StatusTTL        time.Duration
+	BestBlockHashTTL time.Duration
+	//Blocks with at least Confirmations are cached by height before they are chain-locked
+	Confirmations int
+	//Distinct nodes, which must report tip (or same chain lock) before heights below it are final.
+	//Single node may lie about its tip and chain locks are not BLS-verified
+	Quorum int
+	//Verifies BLS signature of chain lock, verified lock is final without Quorum. Nil trusts no lock of single node
+	VerifyChainLock func(lock *block.ChainLock) error
+	//Hash of block header (X11 on Dash), block with other hash than requested or final one is not cached.
+	//Nil caches block only when Quorum nodes returned same block, single node may forge it
+	BlockHash func(header []byte) [32]byte
+	//Optional persistence of immutable entries, they survive restart
+	Store db.IKeyValueDatabase
+}
+
+//Entry of immutable data, which is equal on all nodes of network
+type entry struct {
+	key   string
+	value []byte
+}
+
+//Response of single node with expiration
+type volatile struct {
+	value   interface{}
+	expires time.Time
+}
+
+//Cache of DAPI responses shared by connections of one network
+type Cache struct {
+	options  Options
+	mu       sync.Mutex
+	lru      *list.List
+	entries  map[string]*list.Element
+	volatile map[string]volatile
+	//Highest block reported by each node
+	tips map[string]int
+	//Hashes of chain locks not yet agreed by Quorum, by height and node
+	lockVotes         map[int]map[string]string
+	chainLockedHeight int
+	//Digests of unverified responses not yet agreed by Quorum, by key and node
+	responseVotes map[string]map[string][32]byte
+}
+
+const (
+	heightPrefix = "height/"
+	//Votes of chain locks deeper than this below newest vote are dropped
+	maxLockVotes = 100
+)
+
+func DefaultOptions() Options {
+	return Options{
+		MaxEntries:       10000,
+		StatusTTL:        5 * time.Second,
+		BestBlockHashTTL: 5 * time.Second,
+		Confirmations:    6,
+		Quorum:           2,
+	}
+}
+
+func New(options Options) *Cache {
+	return &Cache{
+		options:       options,
+		lru:           list.New(),
+		entries:       make(map[string]*list.Element),
+		volatile:      make(map[string]volatile),
+		tips:          make(map[string]int),
+		lockVotes:     make(map[int]map[string]string),
+		responseVotes: make(map[string]map[string][32]byte),
+	}
+}
+
+func blockKey(hash string) string {
+	return "block/" + strings.ToLower(hash)
+}
+
+func transactionKey(id string) string {
+	return "tx/" + strings.ToLower(id)
+}
+
+func contractKey(id string) string {
+	return "contract/" + id
+}
+
+//Entries of best chain, they are removed on reorg. Fixed width keeps store sorted by height
+func heightKey(height int, kind string) string {
+	return fmt.Sprintf("%s%010d/%s", heightPrefix, height, kind)
+}
+
+func keyHeight(key string) (int, bool) {
+	if !strings.HasPrefix(key, heightPrefix) {
+		return 0, false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(key, heightPrefix), "/", 2)
+	height, err := strconv.Atoi(parts[0])
+
+	if err != nil {
+		return 0, false
+	}
+
+	return height, true
+}
+
+func (c *Cache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(element)
+
+		return element.Value.(*entry).value, true
+	}
+
+	if c.options.Store == nil {
+		return nil, false
+	}
+
+	var value []byte
+
+	_ = c.options.Store.View(func(tx db.IKeyValueTx) error {
+		if data := tx.Get(db.BucketCache, []byte(key)); data != nil {
+			value = append([]byte(nil), data...)
+		}
+
+		return nil
+	})
+
+	if value == nil {
+		return nil, false
+	}
+
+	c.remember(key, value)
+
+	return value, true
+}
+
+func (c *Cache) put(key string, value []byte) {
+	//Empty response is not a proof of absence
+	if len(value) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remember(key, value)
+
+	//Cache is best effort, failed write only costs another request after restart
+	if c.options.Store != nil {
+		_ = c.options.Store.Update(func(tx db.IKeyValueTx) error {
+			return tx.Put(db.BucketCache, []byte(key), value)
+		})
+	}
+}
+
+//Add entry to memory and evict least recently used ones, c.mu is held
+func (c *Cache) remember(key string, value []byte) {
+	if c.options.MaxEntries <= 0 {
+		return
+	}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*entry).value = value
+		c.lru.MoveToFront(element)
+
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&entry{key: key, value: value})
+
+	for c.lru.Len() > c.options.MaxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry).key)
+	}
+}
+
+func (c *Cache) getVolatile(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.volatile[key]
+
+	if !ok || time.Now().After(v.expires) {
+		delete(c.volatile, key)
+
+		return nil, false
+	}
+
+	return v.value, true
+}
+
+func (c *Cache) putVolatile(key string, value interface{}, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.volatile[key] = volatile{value: value, expires: time.Now().Add(ttl)}
+}
+
+//Remember tip reported by node, blocks deeper than tip of Quorum nodes become cacheable by height
+func (c *Cache) observeHeight(node string, height int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if height > c.tips[node] {
+		c.tips[node] = height
+	}
+}
+
+//Highest height reached by Quorum nodes, c.mu is held
+func (c *Cache) agreedHeight() int {
+	quorum := c.options.Quorum
+
+	if quorum < 1 {
+		quorum = 1
+	}
+
+	if len(c.tips) < quorum {
+		return 0
+	}
+
+	tips := make([]int, 0, len(c.tips))
+
+	for _, tip := range c.tips {
+		tips = append(tips, tip)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(tips)))
+
+	return tips[quorum-1]
+}
+
+//Block at height can not be replaced by reorg
+func (c *Cache) final(height int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if height <= c.chainLockedHeight {
+		return true
+	}
+
+	agreed := c.agreedHeight()
+
+	return agreed > 0 && height <= agreed-c.options.Confirmations
+}
+
+//Chain lock of block with hash (hex, RPC byte order) at height reported by node.
+//Lock is applied when Quorum nodes reported same hash at height
+func (c *Cache) ChainLocked(node string, height int, hash string) {
+	hash = strings.ToLower(hash)
+
+	c.mu.Lock()
+
+	if height <= c.chainLockedHeight {
+		c.mu.Unlock()
+
+		return
+	}
+
+	votes, ok := c.lockVotes[height]
+
+	if !ok {
+		votes = make(map[string]string)
+		c.lockVotes[height] = votes
+	}
+
+	votes[node] = hash
+	agreed := 0
+
+	for _, voted := range votes {
+		if voted == hash {
+			agreed++
+		}
+	}
+
+	for voted := range c.lockVotes {
+		if voted <= height-maxLockVotes {
+			delete(c.lockVotes, voted)
+		}
+	}
+
+	c.mu.Unlock()
+
+	if agreed >= c.options.Quorum {
+		c.applyChainLock(height, hash)
+	}
+}
+
+//Lock verified by Options.VerifyChainLock is applied at once, other locks are votes of node
+func (c *Cache) chainLock(node string, lock *block.ChainLock) {
+	hash := hex.EncodeToString(transaction.ReverseBytes(lock.BlockHash[:]))
+
+	if c.options.VerifyChainLock != nil && c.options.VerifyChainLock(lock) == nil {
+		c.applyChainLock(int(lock.Height), hash)
+
+		return
+	}
+
+	c.ChainLocked(node, int(lock.Height), hash)
+}
+
+//Heights up to locked one are final. Cached blocks, which were considered final only by confirmations,
+//are dropped when lock conflicts with them
+func (c *Cache) applyChainLock(height int, hash string) {
+	cached, ok := c.get(heightKey(height, "hash"))
+
+	c.mu.Lock()
+	locked := c.chainLockedHeight
+	c.mu.Unlock()
+
+	if height <= locked {
+		return
+	}
+
+	if ok && !strings.EqualFold(string(cached), hash) {
+		c.Invalidate(locked + 1)
+	}
+
+	c.mu.Lock()
+
+	if height > c.chainLockedHeight {
+		c.chainLockedHeight = height
+	}
+
+	for voted := range c.lockVotes {
+		if voted <= height {
+			delete(c.lockVotes, voted)
+		}
+	}
+
+	c.mu.Unlock()
+
+	c.put(heightKey(height, "hash"), []byte(hash))
+}
+
+//Header of best chain reported by node names previous block. Cached entries from its height are dropped,
+//when it differs from cached hash, so any node may reveal reorg (dropping entries costs only requests)
+func (c *Cache) observePrevBlock(height int, hash string) {
+	if cached, ok := c.get(heightKey(height, "hash")); ok && !strings.EqualFold(string(cached), hash) {
+		c.Invalidate(height)
+	}
+}
+
+//Drop entries of best chain starting at fromHeight, called on reorg revealed by chain lock or header, or by owner of cache.
+//Blocks, transactions and contracts are addressed by hash and stay valid
+func (c *Cache) Invalidate(fromHeight int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, element := range c.entries {
+		if height, ok := keyHeight(key); ok && height >= fromHeight {
+			c.lru.Remove(element)
+			delete(c.entries, key)
+		}
+	}
+
+	if c.chainLockedHeight >= fromHeight {
+		c.chainLockedHeight = fromHeight - 1
+	}
+
+	for node, tip := range c.tips {
+		if tip >= fromHeight {
+			c.tips[node] = fromHeight - 1
+		}
+	}
+
+	for voted := range c.lockVotes {
+		if voted >= fromHeight {
+			delete(c.lockVotes, voted)
+		}
+	}
+
+	for key := range c.responseVotes {
+		if height, ok := keyHeight(key); ok && height >= fromHeight {
+			delete(c.responseVotes, key)
+		}
+	}
+
+	for key := range c.volatile {
+		delete(c.volatile, key)
+	}
+
+	if c.options.Store == nil {
+		return
+	}
+
+	_ = c.options.Store.Update(func(tx db.IKeyValueTx) error {
+		var keys [][]byte
+
+		err := tx.ForEach(db.BucketCache, func(key []byte, value []byte) error {
+			if height, ok := keyHeight(string(key)); ok && height >= fromHeight {
+				keys = append(keys, append([]byte(nil), key...))
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			err = tx.Delete(db.BucketCache, key)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+//Number of immutable entries in memory
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"errors"
+	"github.com/co-in/dash-dapi/evo/block"
+	"testing"
+)
+
+const (
+	testHash  = "00000000000000112233445566778899aabbccddeeff00112233445566778899"
+	otherHash = "0000000000000011223344556677889900112233445566778899aabbccddeeff"
+)
+
+func TestFinalByTips(t *testing.T) {
+	c := New(DefaultOptions())
+
+	//Tip of single node is not trusted
+	c.observeHeight("a", 1000)
+
+	if c.final(900) {
+		t.Fatal("height is final by tip of single node")
+	}
+
+	c.observeHeight("a", 1010)
+	c.observeHeight("b", 1000)
+
+	if !c.final(994) || c.final(995) {
+		t.Fatal("heights are not final by second highest tip")
+	}
+}
+
+func TestFinalByChainLock(t *testing.T) {
+	c := New(DefaultOptions())
+
+	c.ChainLocked("a", 500, testHash)
+	c.ChainLocked("b", 500, otherHash)
+
+	if c.final(500) {
+		t.Fatal("conflicting chain locks are agreed")
+	}
+
+	c.ChainLocked("a", 500, testHash)
+	c.ChainLocked("c", 500, testHash)
+
+	if !c.final(500) || c.final(501) {
+		t.Fatal("chain lock of two nodes is not applied")
+	}
+
+	if hash, ok := c.get(heightKey(500, "hash")); !ok || string(hash) != testHash {
+		t.Fatalf("hash of locked height %s", hash)
+	}
+}
+
+func TestVerifyChainLock(t *testing.T) {
+	options := DefaultOptions()
+	options.VerifyChainLock = func(lock *block.ChainLock) error {
+		if lock.Height != 700 {
+			return errors.New("invalid signature")
+		}
+
+		return nil
+	}
+
+	c := New(options)
+	c.chainLock("a", &block.ChainLock{Height: 600})
+
+	if c.final(600) {
+		t.Fatal("unverified chain lock of single node is applied")
+	}
+
+	c.chainLock("a", &block.ChainLock{Height: 700})
+
+	if !c.final(700) {
+		t.Fatal("verified chain lock is not applied")
+	}
+}
+
+func TestInvalidateByHeader(t *testing.T) {
+	c := New(DefaultOptions())
+	c.put(heightKey(100, "hash"), []byte(testHash))
+	c.put(heightKey(101, "block"), []byte{1})
+	c.put(blockKey(testHash), []byte{1})
+
+	c.observePrevBlock(100, testHash)
+
+	if c.Len() != 3 {
+		t.Fatal("header of same chain invalidated cache")
+	}
+
+	c.observePrevBlock(100, otherHash)
+
+	if _, ok := c.get(heightKey(101, "block")); ok || c.Len() != 1 {
+		t.Fatal("header of other chain did not invalidate heights")
+	}
+
+	if _, ok := c.get(blockKey(testHash)); !ok {
+		t.Fatal("block addressed by hash was dropped")
+	}
+}
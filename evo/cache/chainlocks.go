@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"context"
+	"encoding/hex"
+	"github.com/co-in/dash-dapi/evo/block"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"time"
+)
+
+//Apply chain locks and headers of best chain until ctx is done.
+//Stream is resumed on random node after retryDelay when it fails
+func (c *Cache) FollowChainLocks(ctx context.Context, dAPI interfaces.IClient, retryDelay time.Duration) {
+	for {
+		node, err := dAPI.SelectRandomNode()
+
+		if err == nil {
+			_ = c.followChainLocks(ctx, node.WithContext(ctx))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+func (c *Cache) followChainLocks(ctx context.Context, node interfaces.IConnection) error {
+	status, err := node.GetStatus()
+
+	if err != nil {
+		return err
+	}
+
+	height := int(status.Blocks)
+	stream, err := node.SubscribeToBlockHeadersWithChainLocks(structures.BlockHeadersWithChainLocksRequest{
+		FromBlockHeight: &height,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	//Header stream starts at FromBlockHeight, each header is next block of best chain
+	height--
+	name := node.GetNodeName()
+
+	for {
+		r, err := stream.Recv()
+
+		if err != nil {
+			return err
+		}
+
+		for _, data := range r.GetBlockHeaders().GetHeaders() {
+			height++
+			header, err := block.ParseHeader(data)
+
+			if err == nil {
+				c.observePrevBlock(height-1, hex.EncodeToString(transaction.ReverseBytes(header.PrevBlock[:])))
+			}
+		}
+
+		c.observeHeight(name, height)
+
+		for _, message := range r.GetChainLockSignatureMessages().GetMessages() {
+			lock, err := block.ParseChainLock(message)
+
+			if err != nil {
+				continue
+			}
+
+			c.chainLock(name, lock)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"context"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/evo/structures"
+)
+
+//Connection answering immutable requests from cache, other calls go to node
+type connection struct {
+	interfaces.IConnection
+	cache *Cache
+}
+
+//Client returning cached connections
+type client struct {
+	interfaces.IClient
+	cache *Cache
+}
+
+func (c *Cache) Wrap(node interfaces.IConnection) interfaces.IConnection {
+	return &connection{
+		IConnection: node,
+		cache:       c,
+	}
+}
+
+func (c *Cache) WrapClient(dAPI interfaces.IClient) interfaces.IClient {
+	return &client{
+		IClient: dAPI,
+		cache:   c,
+	}
+}
+
+func (c *client) SelectRandomNode() (interfaces.IConnection, error) {
+	node, err := c.IClient.SelectRandomNode()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.cache.Wrap(node), nil
+}
+
+func (c *client) SelectNode(hostname string) (interfaces.IConnection, error) {
+	node, err := c.IClient.SelectNode(hostname)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.cache.Wrap(node), nil
+}
+
+func (c *connection) WithContext(ctx context.Context) interfaces.IConnection {
+	return c.cache.Wrap(c.IConnection.WithContext(ctx))
+}
+
+func (c *connection) GetStatus() (*proto.GetStatusResponse, error) {
+	key := c.GetNodeName() + "/status"
+
+	if cached, ok := c.cache.getVolatile(key); ok {
+		response := *cached.(*proto.GetStatusResponse)
+
+		return &response, nil
+	}
+
+	response, err := c.IConnection.GetStatus()
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.observeHeight(c.GetNodeName(), int(response.Blocks))
+	stored := *response
+	c.cache.putVolatile(key, &stored, c.cache.options.StatusTTL)
+
+	return response, nil
+}
+
+func (c *connection) GetBestBlockHash() (*structures.BestBlockHashResponse, error) {
+	key := c.GetNodeName() + "/best_block_hash"
+
+	if cached, ok := c.cache.getVolatile(key); ok {
+		response := cached.(structures.BestBlockHashResponse)
+
+		return &response, nil
+	}
+
+	response, err := c.IConnection.GetBestBlockHash()
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.putVolatile(key, *response, c.cache.options.BestBlockHashTTL)
+
+	return response, nil
+}
+
+func (c *connection) GetBlockHash(height int) (*structures.BlockHashResponse, error) {
+	key := heightKey(height, "hash")
+
+	if cached, ok := c.cache.get(key); ok {
+		response := structures.BlockHashResponse(cached)
+
+		return &response, nil
+	}
+
+	response, err := c.IConnection.GetBlockHash(height)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if c.cache.final(height) {
+		c.cache.put(key, []byte(*response))
+	}
+
+	return response, nil
+}
+
+func (c *connection) GetBlock(request structures.BlockRequest) (*proto.GetBlockResponse, error) {
+	var key string
+
+	if request.Hash != nil && request.Height == nil {
+		key = blockKey(*request.Hash)
+	} else if request.Height != nil && request.Hash == nil {
+		key = heightKey(*request.Height, "block")
+	} else {
+		return c.IConnection.GetBlock(request)
+	}
+
+	if cached, ok := c.cache.get(key); ok {
+		return &proto.GetBlockResponse{Block: cached}, nil
+	}
+
+	response, err := c.IConnection.GetBlock(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	//Block at height may change until it is final. Response of single node is not trusted
+	if request.Hash != nil && c.cache.verifiedBlock(c.GetNodeName(), key, response.GetBlock(), *request.Hash) {
+		c.cache.put(key, response.GetBlock())
+	} else if request.Height != nil && c.cache.final(*request.Height) && c.cache.verifiedBlockAtHeight(c.GetNodeName(), key, response.GetBlock(), *request.Height) {
+		c.cache.put(key, response.GetBlock())
+	}
+
+	return response, nil
+}
+
+func (c *connection) GetTransaction(id string) (*proto.GetTransactionResponse, error) {
+	key := transactionKey(id)
+
+	if cached, ok := c.cache.get(key); ok {
+		return &proto.GetTransactionResponse{Transaction: cached}, nil
+	}
+
+	response, err := c.IConnection.GetTransaction(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if verifiedTransaction(response.GetTransaction(), id) {
+		c.cache.put(key, response.GetTransaction())
+	}
+
+	return response, nil
+}
+
+func (c *connection) GetDataContract(id string) (*proto.GetDataContractResponse, error) {
+	key := contractKey(id)
+
+	if cached, ok := c.cache.get(key); ok {
+		return &proto.GetDataContractResponse{DataContract: cached}, nil
+	}
+
+	response, err := c.IConnection.GetDataContract(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.put(key, response.GetDataContract())
+
+	return response, nil
+}
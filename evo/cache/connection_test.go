@@ -0,0 +1,234 @@
+package cache
+
+import (
+	"encoding/binary"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"testing"
+)
+
+//Node answering every request with same payload, e.g. forged one
+type fakeNode struct {
+	interfaces.IConnection
+	name    string
+	payload []byte
+	calls   int
+}
+
+func (n *fakeNode) GetNodeName() string {
+	return n.name
+}
+
+func (n *fakeNode) GetBlock(request structures.BlockRequest) (*proto.GetBlockResponse, error) {
+	n.calls++
+
+	return &proto.GetBlockResponse{Block: n.payload}, nil
+}
+
+func (n *fakeNode) GetTransaction(id string) (*proto.GetTransactionResponse, error) {
+	n.calls++
+
+	return &proto.GetTransactionResponse{Transaction: n.payload}, nil
+}
+
+func testTransaction(value int64) *transaction.Transaction {
+	return &transaction.Transaction{
+		Version: transaction.DefaultVersion,
+		Inputs:  []transaction.Input{{PreviousOutput: transaction.OutPoint{Hash: [32]byte{1}}, Script: []byte{1, 1}, Sequence: transaction.DefaultSequence}},
+		Outputs: []transaction.Output{{Value: value, Script: []byte{0x6a}}},
+	}
+}
+
+//Raw block with DIP4 coinbase of height, nonce makes blocks of same height differ
+func rawBlock(height int, nonce uint32) []byte {
+	payload := make([]byte, 2+4+32)
+	binary.LittleEndian.PutUint16(payload, 2)
+	binary.LittleEndian.PutUint32(payload[2:], uint32(height))
+
+	coinbase := &transaction.Transaction{
+		Version:      3,
+		Type:         5,
+		Inputs:       []transaction.Input{{PreviousOutput: transaction.OutPoint{Index: 0xffffffff}, Script: []byte{1, 1}, Sequence: transaction.DefaultSequence}},
+		Outputs:      []transaction.Output{{Script: []byte{0x6a}}},
+		ExtraPayload: payload,
+	}
+
+	header := make([]byte, 80)
+	binary.LittleEndian.PutUint32(header[76:], nonce)
+
+	return append(append(header, 1), coinbase.Bytes()...)
+}
+
+//Stand-in of X11 in tests
+func testBlockHash(header []byte) [32]byte {
+	return transaction.DoubleHash(header)
+}
+
+func blockHashString(data []byte) string {
+	return hashString(testBlockHash(data[:80]))
+}
+
+//Cached response is served to next caller without request to node
+func served(t *testing.T, c *Cache, request func(node interfaces.IConnection) error) bool {
+	t.Helper()
+	node := &fakeNode{name: "other"}
+	err := request(c.Wrap(node))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return node.calls == 0
+}
+
+func TestTransactionPoisoning(t *testing.T) {
+	c := New(DefaultOptions())
+	tx := testTransaction(1000)
+	getTransaction := func(node interfaces.IConnection) error {
+		_, err := node.GetTransaction(tx.TxID())
+
+		return err
+	}
+
+	//Other transaction returned under requested id is not cached
+	err := getTransaction(c.Wrap(&fakeNode{name: "liar", payload: testTransaction(2000).Bytes()}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if served(t, c, getTransaction) {
+		t.Fatal("forged transaction is cached")
+	}
+
+	err = getTransaction(c.Wrap(&fakeNode{name: "honest", payload: tx.Bytes()}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !served(t, c, getTransaction) {
+		t.Fatal("transaction is not cached")
+	}
+}
+
+func TestBlockPoisoning(t *testing.T) {
+	options := DefaultOptions()
+	options.BlockHash = testBlockHash
+	c := New(options)
+
+	honest := rawBlock(500, 1)
+	forged := rawBlock(500, 2)
+	hash := blockHashString(honest)
+	height := 500
+
+	byHash := func(node interfaces.IConnection) error {
+		_, err := node.GetBlock(structures.BlockRequest{Hash: &hash})
+
+		return err
+	}
+
+	byHeight := func(node interfaces.IConnection) error {
+		_, err := node.GetBlock(structures.BlockRequest{Height: &height})
+
+		return err
+	}
+
+	//Hash of forged block differs from requested one
+	err := byHash(c.Wrap(&fakeNode{name: "liar", payload: forged}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if served(t, c, byHash) {
+		t.Fatal("forged block is cached by hash")
+	}
+
+	err = byHash(c.Wrap(&fakeNode{name: "honest", payload: honest}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !served(t, c, byHash) {
+		t.Fatal("block is not cached by hash")
+	}
+
+	//Height is final by chain lock of honest block, forged block of same height has other hash
+	c.ChainLocked("a", 500, hash)
+	c.ChainLocked("b", 500, hash)
+
+	err = byHeight(c.Wrap(&fakeNode{name: "liar", payload: forged}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if served(t, c, byHeight) {
+		t.Fatal("forged block is cached by height")
+	}
+
+	//Block of other height is not cached either
+	err = byHeight(c.Wrap(&fakeNode{name: "liar", payload: rawBlock(499, 1)}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if served(t, c, byHeight) {
+		t.Fatal("block of other height is cached")
+	}
+
+	err = byHeight(c.Wrap(&fakeNode{name: "honest", payload: honest}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !served(t, c, byHeight) {
+		t.Fatal("block is not cached by height")
+	}
+}
+
+//Without block hash function block is cached when Quorum nodes returned it
+func TestBlockQuorum(t *testing.T) {
+	c := New(DefaultOptions())
+	hash := blockHashString(rawBlock(500, 1))
+	byHash := func(node interfaces.IConnection) error {
+		_, err := node.GetBlock(structures.BlockRequest{Hash: &hash})
+
+		return err
+	}
+
+	nodes := []*fakeNode{
+		{name: "a", payload: rawBlock(500, 1)},
+		{name: "liar", payload: rawBlock(500, 2)},
+		{name: "a", payload: rawBlock(500, 1)},
+	}
+
+	for _, node := range nodes {
+		err := byHash(c.Wrap(node))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	//Node repeating its answer is single vote, liar does not agree
+	if c.Len() != 0 {
+		t.Fatal("block of single node is cached")
+	}
+
+	err := byHash(c.Wrap(&fakeNode{name: "b", payload: rawBlock(500, 1)}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !served(t, c, byHash) {
+		t.Fatal("block returned by quorum is not cached")
+	}
+}
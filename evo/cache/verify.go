@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"encoding/hex"
+	"github.com/co-in/dash-dapi/evo/block"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"strings"
+)
+
+//Unverified responses waiting for votes of other nodes, older votes are dropped when limit is reached
+const maxResponseVotes = 1000
+
+//Hash in RPC byte order
+func hashString(hash [32]byte) string {
+	return hex.EncodeToString(transaction.ReverseBytes(hash[:]))
+}
+
+//Transaction returned by node has requested id
+func verifiedTransaction(data []byte, id string) bool {
+	return strings.EqualFold(hashString(transaction.DoubleHash(data)), id)
+}
+
+//Block returned by node for request by hash has requested hash, or Quorum nodes returned it when hash can not be computed
+func (c *Cache) verifiedBlock(node string, key string, data []byte, hash string) bool {
+	if c.options.BlockHash == nil {
+		return c.agreed(key, node, data)
+	}
+
+	return len(data) >= block.HeaderSize && strings.EqualFold(hashString(c.options.BlockHash(data[:block.HeaderSize])), hash)
+}
+
+//Block returned by node for final height has coinbase of height and hash of height, when it is known.
+//Blocks without height in coinbase (before BIP34) are not cached by height
+func (c *Cache) verifiedBlockAtHeight(node string, key string, data []byte, height int) bool {
+	blockHeight, err := block.Height(data)
+
+	if err != nil || blockHeight != height {
+		return false
+	}
+
+	if hash, ok := c.get(heightKey(height, "hash")); ok && c.options.BlockHash != nil {
+		return c.verifiedBlock(node, key, data, string(hash))
+	}
+
+	return c.agreed(key, node, data)
+}
+
+//Vote of node for response of key, response is agreed when Quorum distinct nodes returned same data
+func (c *Cache) agreed(key string, node string, data []byte) bool {
+	quorum := c.options.Quorum
+
+	if quorum <= 1 {
+		return true
+	}
+
+	digest := transaction.DoubleHash(data)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	votes, ok := c.responseVotes[key]
+
+	if !ok {
+		if len(c.responseVotes) >= maxResponseVotes {
+			c.responseVotes = make(map[string]map[string][32]byte)
+		}
+
+		votes = make(map[string][32]byte)
+		c.responseVotes[key] = votes
+	}
+
+	votes[node] = digest
+	agreed := 0
+
+	for _, voted := range votes {
+		if voted == digest {
+			agreed++
+		}
+	}
+
+	if agreed < quorum {
+		return false
+	}
+
+	delete(c.responseVotes, key)
+
+	return true
+}
@@ -4,33 +4,91 @@ import (
 	"context"
 	"crypto/rand"
 	"errors"
+	"fmt"
+	"github.com/co-in/dash-dapi/db"
 	"github.com/co-in/dash-dapi/evo/interfaces"
-	"log"
+	"github.com/co-in/dash-dapi/evo/network"
+	"github.com/co-in/dash-dapi/logging"
 	"math/big"
 	"strconv"
 	"sync"
+	"time"
 )
 
 type client struct {
-	*log.Logger
+	logger         logging.Logger
 	evoJsonRpcPort string
 	evoGRpcPort    string
 	connections    map[string]*connection
 	connectionKeys []string
 	ctx            context.Context
 	sync.Mutex
-	id           int
-	verboseLevel int
+	id         int
+	reputation ReputationPolicy
+	//Reputation restored from database, applied when node is added
+	records map[string]db.NodeRecord
+	//Nodes of other networks are refused, nil disables verification
+	network *network.Params
+	//Limit of dial and unary requests, zero disables it. Streams are limited by context only
+	timeout time.Duration
+	//Nil unless WithMetrics is given
+	metrics *clientMetrics
+	//Chain of every call, first is outermost
+	middleware []Middleware
+	//Calls are recorded to fixture or replayed from it when set
+	fixture *Fixture
+	replay  bool
 }
 
-func NewClient(log *log.Logger, verboseLevel int, nodeAddress string, jsonRpcPort uint16, gRpcPort uint16) (*client, error) {
+type Option func(c *client)
+
+//Restore node reputation saved by previous run (see NodeRecords)
+func WithNodeRecords(records map[string]db.NodeRecord) Option {
+	return func(c *client) {
+		for node, record := range records {
+			c.records[node] = record
+		}
+	}
+}
+
+//Refuse nodes which do not belong to network
+func WithNetwork(params *network.Params) Option {
+	return func(c *client) {
+		c.network = params
+	}
+}
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *client) {
+		c.timeout = timeout
+	}
+}
+
+func WithReputationPolicy(policy ReputationPolicy) Option {
+	return func(c *client) {
+		c.reputation = policy
+	}
+}
+
+//Nil logger discards records
+func NewClient(logger logging.Logger, nodeAddress string, jsonRpcPort uint16, gRpcPort uint16, options ...Option) (*client, error) {
+	if logger == nil {
+		logger = logging.Nop()
+	}
+
 	c := &client{
-		Logger:         log,
-		verboseLevel:   verboseLevel,
+		logger:         logger,
 		ctx:            context.Background(),
 		evoJsonRpcPort: strconv.Itoa(int(jsonRpcPort)),
 		evoGRpcPort:    strconv.Itoa(int(gRpcPort)),
 		connections:    make(map[string]*connection),
+		reputation:     DefaultReputationPolicy(),
+		records:        make(map[string]db.NodeRecord),
+		middleware:     []Middleware{LoggingMiddleware(logger)},
+	}
+
+	for _, option := range options {
+		option(c)
 	}
 
 	err := c.AddNode(nodeAddress, 0)
@@ -50,37 +108,128 @@ func (c *client) AddNode(nodeAddress string, fraud int) error {
 		return nil
 	}
 
+	stats := newNodeStats(c.reputation, c.records[nodeAddress])
+	stats.addFraud(float64(fraud))
+
 	c.connectionKeys = append(c.connectionKeys, nodeAddress)
 	c.connections[nodeAddress] = &connection{
 		client: c,
+		ctx:    c.ctx,
 		name:   nodeAddress,
-		fraud:  fraud,
+		stats:  stats,
+		dial:   new(sync.Mutex),
 	}
 
 	return nil
 }
 
+//Reputation of known nodes for persistence, includes nodes removed during this run
+func (c *client) NodeRecords() map[string]db.NodeRecord {
+	c.Lock()
+	defer c.Unlock()
+
+	records := make(map[string]db.NodeRecord, len(c.records)+len(c.connections))
+
+	for node, record := range c.records {
+		records[node] = record
+	}
+
+	for node, conn := range c.connections {
+		records[node] = conn.stats.snapshot()
+	}
+
+	return records
+}
+
+//Random node of selected network, banned nodes are used only when all nodes are banned.
+//When every node fails verification, error of last verified node is wrapped
 func (c *client) SelectRandomNode() (interfaces.IConnection, error) {
+	excluded := make(map[string]bool)
+	var lastNode string
+	var lastErr error
+
+	for {
+		conn, err := c.selectRandom(excluded)
+
+		if err != nil && lastErr != nil {
+			return nil, fmt.Errorf("%s, last node %s: %w", err, lastNode, lastErr)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = conn.verifyNetwork()
+
+		if err == nil {
+			return conn, nil
+		}
+
+		logging.Info(c.logger, "Skip node", logging.Node(conn.name), logging.Err(err))
+
+		excluded[conn.name] = true
+		lastNode, lastErr = conn.name, err
+	}
+}
+
+func (c *client) selectRandom(excluded map[string]bool) (*connection, error) {
 	c.Lock()
 	defer c.Unlock()
 
-	//TODO Order By Fraud
-	randIndex, err := rand.Int(rand.Reader, big.NewInt(int64(len(c.connectionKeys))))
+	now := time.Now()
+	var candidates, banned []string
+
+	for _, key := range c.connectionKeys {
+		stats := c.connections[key].stats
+
+		if excluded[key] || stats.mismatched() {
+			continue
+		}
+
+		if stats.banned(now) {
+			banned = append(banned, key)
+		} else {
+			candidates = append(candidates, key)
+		}
+	}
+
+	if len(candidates) == 0 {
+		candidates = banned
+	}
+
+	if len(candidates) == 0 {
+		return nil, errors.New("no available nodes")
+	}
+
+	randIndex, err := rand.Int(rand.Reader, big.NewInt(int64(len(candidates))))
 
 	if err != nil {
 		return nil, err
 	}
 
-	return c.connections[c.connectionKeys[int(randIndex.Int64())]], nil
+	return c.connections[candidates[int(randIndex.Int64())]], nil
+}
+
+func (c *client) GetNetwork() *network.Params {
+	return c.network
 }
 
 func (c *client) SelectNode(address string) (interfaces.IConnection, error) {
 	var conn *connection
 	var ok bool
 
-	if conn, ok = c.connections[address]; !ok {
+	c.Lock()
+	conn, ok = c.connections[address]
+	c.Unlock()
+
+	if !ok {
 		return nil, errors.New("the node does not exist")
 	}
 
+	//Unreachable node is returned, caller checks availability
+	if err := conn.verifyNetwork(); errors.Is(err, ErrNetworkMismatch) {
+		return nil, err
+	}
+
 	return conn, nil
 }
@@ -0,0 +1,401 @@
+package evo_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/co-in/dash-dapi/evo"
+	"github.com/co-in/dash-dapi/evo/dapitest"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	"github.com/co-in/dash-dapi/evo/network"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newServer(t *testing.T) *dapitest.Server {
+	t.Helper()
+	s, err := dapitest.NewServer()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func newClient(t *testing.T, s *dapitest.Server, options ...evo.Option) interfaces.IClient {
+	t.Helper()
+	c, err := evo.NewClient(nil, s.Host, s.JSONRPCPort, s.GRPCPort, append([]evo.Option{evo.WithTimeout(5 * time.Second)}, options...)...)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func newNode(t *testing.T, s *dapitest.Server, options ...evo.Option) interfaces.IConnection {
+	t.Helper()
+	node, err := newClient(t, s, options...).SelectNode(s.Host)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return node
+}
+
+func sameJSON(t *testing.T, a json.RawMessage, b string) bool {
+	t.Helper()
+	var valueA, valueB interface{}
+
+	if json.Unmarshal(a, &valueA) != nil || json.Unmarshal([]byte(b), &valueB) != nil {
+		t.Fatalf("invalid JSON %s or %s", a, b)
+	}
+
+	return reflect.DeepEqual(valueA, valueB)
+}
+
+func TestJSONRPCMethods(t *testing.T) {
+	from := 0
+	blockHash := structures.BlockHashResponse("0000000000000000000000000000000000000000000000000000000000000005")
+	bestBlockHash := structures.BestBlockHashResponse("00000000000000000000000000000000000000000000000000000000000000ff")
+	mnListDiff := structures.MnListDiffResponse{BaseBlockHash: "base", BlockHash: "tip", MerkleRootMNList: "root"}
+	utxo := structures.UTXOResponse{TotalItems: 1, Items: []structures.UTXOItemResponse{{Address: "yNdiwCY1pUBijwayLXh5Qz3ZFVnMkQXHgb", Txid: "ab", Satoshis: 1000}}}
+	summary := structures.AddressSummaryResponse{AddrStr: []string{"yNdiwCY1pUBijwayLXh5Qz3ZFVnMkQXHgb"}, BalanceSat: 1000, Transactions: []string{"ab"}}
+
+	tests := []struct {
+		method   string
+		response interface{}
+		call     func(node interfaces.IConnection) (interface{}, error)
+		params   string
+	}{
+		{
+			method:   dapitest.MethodGetBestBlockHash,
+			response: bestBlockHash,
+			call: func(node interfaces.IConnection) (interface{}, error) {
+				return node.GetBestBlockHash()
+			},
+			params: `{}`,
+		},
+		{
+			method:   dapitest.MethodGetBlockHash,
+			response: blockHash,
+			call: func(node interfaces.IConnection) (interface{}, error) {
+				return node.GetBlockHash(5)
+			},
+			params: `{"height":5}`,
+		},
+		{
+			method:   dapitest.MethodGetMnListDiff,
+			response: mnListDiff,
+			call: func(node interfaces.IConnection) (interface{}, error) {
+				return node.GetMnListDiff("base", "tip")
+			},
+			params: `{"baseBlockHash":"base","blockHash":"tip"}`,
+		},
+		{
+			method:   dapitest.MethodGetUTXO,
+			response: utxo,
+			call: func(node interfaces.IConnection) (interface{}, error) {
+				return node.GetUTXO(structures.UTXORequest{From: &from, Addresses: []string{"yNdiwCY1pUBijwayLXh5Qz3ZFVnMkQXHgb"}})
+			},
+			params: `{"from":0,"address":["yNdiwCY1pUBijwayLXh5Qz3ZFVnMkQXHgb"]}`,
+		},
+		{
+			method:   dapitest.MethodGetAddressSummary,
+			response: summary,
+			call: func(node interfaces.IConnection) (interface{}, error) {
+				return node.GetAddressSummary([]string{"yNdiwCY1pUBijwayLXh5Qz3ZFVnMkQXHgb"})
+			},
+			params: `{"address":["yNdiwCY1pUBijwayLXh5Qz3ZFVnMkQXHgb"]}`,
+		},
+	}
+
+	s := newServer(t)
+	node := newNode(t, s)
+
+	for _, test := range tests {
+		t.Run(test.method, func(t *testing.T) {
+			s.SetResponse(test.method, test.response)
+			result, err := test.call(node)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			//Methods return pointer to response
+			if value := reflect.ValueOf(result).Elem().Interface(); !reflect.DeepEqual(value, test.response) {
+				t.Fatalf("result %+v, expected %+v", value, test.response)
+			}
+
+			requests := s.Requests(test.method)
+
+			if len(requests) == 0 || !sameJSON(t, requests[len(requests)-1].(json.RawMessage), test.params) {
+				t.Fatalf("params %s, expected %s", requests, test.params)
+			}
+
+			s.SetError(test.method, &evo.RPCError{Code: -5, Message: "not found"})
+			defer s.SetError(test.method, nil)
+
+			_, err = test.call(node)
+			var rpcErr *evo.RPCError
+
+			if !errors.As(err, &rpcErr) || rpcErr.Code != -5 || rpcErr.Node != s.Host {
+				t.Fatalf("error %v, expected JSON-RPC error -5", err)
+			}
+		})
+	}
+}
+
+func TestRetryFailTimes(t *testing.T) {
+	s := newServer(t)
+	node := newNode(t, s, evo.WithMiddleware(evo.RetryMiddleware(3, time.Millisecond, nil)))
+
+	s.FailTimes(dapitest.MethodGetStatus, 2, status.Error(codes.Unavailable, "node is down"))
+
+	_, err := node.GetStatus()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(s.Requests(dapitest.MethodGetStatus)); n != 3 {
+		t.Fatalf("%d attempts, expected 3", n)
+	}
+
+	s.FailTimes(dapitest.MethodGetStatus, 3, status.Error(codes.Unavailable, "node is down"))
+
+	if _, err = node.GetStatus(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("error %v after all attempts failed, expected Unavailable", err)
+	}
+}
+
+//Node failing network check is skipped until it recovers
+func TestFailoverFailTimes(t *testing.T) {
+	failing := newServer(t)
+	healthy, err := dapitest.NewServerAt("127.0.0.2", failing.JSONRPCPort, failing.GRPCPort)
+
+	if err != nil {
+		t.Skipf("second loopback address: %s", err)
+	}
+
+	t.Cleanup(healthy.Close)
+
+	params := &network.Params{Name: network.RegTestName, StatusNetworks: []string{network.RegTestName}}
+
+	for _, s := range []*dapitest.Server{failing, healthy} {
+		s.SetResponse(dapitest.MethodGetStatus, &proto.GetStatusResponse{Network: network.RegTestName, Connections: 8})
+	}
+
+	failing.FailTimes(dapitest.MethodGetStatus, 1, status.Error(codes.Unavailable, "node is down"))
+
+	c, err := evo.NewClient(nil, failing.Host, failing.JSONRPCPort, failing.GRPCPort, evo.WithNetwork(params), evo.WithTimeout(5*time.Second))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.AddNode(healthy.Host, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	selected := make(map[string]bool)
+
+	for i := 0; i < 200 && !selected[failing.Host]; i++ {
+		failed := len(failing.Requests(dapitest.MethodGetStatus))
+		node, err := c.SelectRandomNode()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		//Failed check moves selection to other node
+		if failed == 0 && len(failing.Requests(dapitest.MethodGetStatus)) == 1 && node.GetNodeName() != healthy.Host {
+			t.Fatalf("selected %s after its check failed", node.GetNodeName())
+		}
+
+		selected[node.GetNodeName()] = true
+	}
+
+	if !selected[failing.Host] || !selected[healthy.Host] {
+		t.Fatalf("selected %v, expected both nodes after recovery", selected)
+	}
+}
+
+//Error of selection names failure of last checked node, not only lack of nodes
+func TestSelectRandomNodeLastError(t *testing.T) {
+	s := newServer(t)
+	s.SetResponse(dapitest.MethodGetStatus, &proto.GetStatusResponse{Network: network.MainNetName, Connections: 8})
+
+	c := newClient(t, s, evo.WithNetwork(&network.RegTest))
+	_, err := c.SelectRandomNode()
+
+	if !errors.Is(err, evo.ErrNetworkMismatch) {
+		t.Fatalf("error %v, expected %s", err, evo.ErrNetworkMismatch)
+	}
+
+	s.SetError(dapitest.MethodGetStatus, status.Error(codes.Unavailable, "node is down"))
+
+	c = newClient(t, s, evo.WithNetwork(&network.RegTest))
+	_, err = c.SelectRandomNode()
+
+	if status.Code(errors.Unwrap(err)) != codes.Unavailable {
+		t.Fatalf("error %v, expected unavailable node", err)
+	}
+}
+
+func TestLatencyContextTimeout(t *testing.T) {
+	s := newServer(t)
+	node := newNode(t, s)
+
+	s.SetLatency(dapitest.AnyMethod, time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := node.WithContext(ctx).GetBestBlockHash()
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("JSON-RPC error %v, expected deadline exceeded", err)
+	}
+
+	_, err = node.WithContext(ctx).GetStatus()
+
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("gRPC error %v, expected DeadlineExceeded", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("calls took %s, latency was not cut by context", elapsed)
+	}
+}
+
+func TestTransactionsStream(t *testing.T) {
+	s := newServer(t)
+	node := newNode(t, s)
+
+	s.SetStream(dapitest.MethodSubscribeToTransactionsWithProofs,
+		dapitest.Transactions([]byte{1, 2}),
+		dapitest.StreamEvent{Delay: 10 * time.Millisecond, Message: dapitest.MerkleBlock([]byte{3}).Message},
+		dapitest.InstantLocks([]byte{4}),
+		dapitest.Fail(status.Error(codes.Aborted, "reorg")),
+	)
+
+	height := 10
+	stream, err := node.SubscribeToTransactionsWithProofs(structures.SubscribeToTransactionsWithProofsRequest{
+		BloomFilter:     structures.BloomFilterRequest{Data: []byte{0xff}, HashFunc: 3},
+		FromBlockHeight: &height,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := stream.Recv()
+
+	if err != nil || len(response.GetRawTransactions().GetTransactions()) != 1 || !bytes.Equal(response.GetRawTransactions().GetTransactions()[0], []byte{1, 2}) {
+		t.Fatalf("first message %v, error %v, expected transaction", response, err)
+	}
+
+	response, err = stream.Recv()
+
+	if err != nil || !bytes.Equal(response.GetRawMerkleBlock(), []byte{3}) {
+		t.Fatalf("second message %v, error %v, expected merkle block", response, err)
+	}
+
+	response, err = stream.Recv()
+
+	if err != nil || len(response.GetInstantSendLockMessages().GetMessages()) != 1 {
+		t.Fatalf("third message %v, error %v, expected instant lock", response, err)
+	}
+
+	if _, err = stream.Recv(); status.Code(err) != codes.Aborted {
+		t.Fatalf("end of stream %v, expected Aborted", err)
+	}
+
+	requests := s.Requests(dapitest.MethodSubscribeToTransactionsWithProofs)
+	request, ok := requests[0].(*proto.TransactionsWithProofsRequest)
+
+	if len(requests) != 1 || !ok || request.GetFromBlockHeight() != 10 || !bytes.Equal(request.GetBloomFilter().GetVData(), []byte{0xff}) {
+		t.Fatalf("requests %v, expected filter from height 10", requests)
+	}
+}
+
+//Gateway copies node for every forwarded call, first copies dial shared connection concurrently
+func TestConcurrentWithContext(t *testing.T) {
+	s := newServer(t)
+	node := newNode(t, s)
+	errs := make(chan error, 8)
+
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			_, err := node.WithContext(context.Background()).GetStatus()
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < cap(errs); i++ {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReputation(t *testing.T) {
+	s := newServer(t)
+	c := newClient(t, s)
+	node, err := c.SelectNode(s.Host)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !node.CheckAvailability() {
+		t.Fatal("fake node is not available")
+	}
+
+	if record := c.NodeRecords()[s.Host]; !record.LastSuccess.IsZero() || len(record.LatencySamples) != 0 {
+		t.Fatalf("probe changed reputation %+v", record)
+	}
+
+	s.FailTimes(dapitest.MethodGetStatus, 1, status.Error(codes.Unavailable, "node is down"))
+
+	if _, err = node.GetStatus(); err == nil {
+		t.Fatal("injected failure is lost")
+	}
+
+	record := c.NodeRecords()[s.Host]
+
+	if record.Fraud <= 0 || record.LastFailure.IsZero() {
+		t.Fatalf("failed gRPC call is not counted %+v", record)
+	}
+
+	//Error of request itself is answer of healthy node
+	s.SetError(dapitest.MethodGetStatus, status.Error(codes.InvalidArgument, "bad request"))
+	_, _ = node.GetStatus()
+	s.SetError(dapitest.MethodGetStatus, nil)
+
+	_, err = node.GetStatus()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next := c.NodeRecords()[s.Host]
+
+	if next.Fraud > record.Fraud || len(next.LatencySamples) != 2 || next.LastSuccess.IsZero() {
+		t.Fatalf("successful gRPC calls are not counted %+v", next)
+	}
+}
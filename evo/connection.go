@@ -1,18 +1,23 @@
 package evo
 
 import (
+	"context"
+	"github.com/co-in/dash-dapi/evo/interfaces"
 	proto "github.com/co-in/dash-dapi/evo/protobuf"
 	"github.com/co-in/dash-dapi/evo/structures"
 	"google.golang.org/grpc"
 	"sort"
+	"sync"
 )
 
 type connection struct {
 	*client
 	conn *grpc.ClientConn
-	name string
-	//TODO Implement
-	fraud int
+	//Guards lazy dial of conn, shared with copies made by WithContext
+	dial  *sync.Mutex
+	ctx   context.Context
+	name  string
+	stats *nodeStats
 	//TODO Implement
 	ping int
 	//TODO implement LazyClient
@@ -22,17 +27,28 @@ type connection struct {
 }
 
 func (c *connection) CheckAvailability() bool {
+	//Calls of probe do not change reputation
+	ctx := context.WithValue(c.ctx, probeKey{}, true)
 	body := make(map[string][]string)
 	response := new(structures.BestBlockHashResponse)
 
-	err := c.requestJSON(false, false, jsonEndpointGetBestBlockHash, body, response)
+	err := c.requestJSON(ctx, jsonEndpointGetBestBlockHash, body, response)
 
 	//Check JSON RPC
 	if err != nil || *response == "" {
 		return false
 	}
 
-	status, err := c.GetStatus()
+	//Probe copy shares gRPC connection of node
+	err = c.LazyConnection()
+
+	if err != nil {
+		return false
+	}
+
+	probe := *c
+	probe.ctx = ctx
+	status, err := probe.GetStatus()
 
 	//Check gRPC
 	if err != nil || status == nil || status.Connections < 2 {
@@ -43,12 +59,26 @@ func (c *connection) CheckAvailability() bool {
 }
 
 func (c *connection) LazyConnection() error {
+	c.dial.Lock()
+	defer c.dial.Unlock()
+
+	return c.connect()
+}
+
+//Dial gRPC connection unless it exists, caller holds dial mutex
+func (c *connection) connect() error {
 	if c.conn != nil {
 		return nil
 	}
 
 	var opts []grpc.DialOption
-	opts = append(opts, grpc.WithBlock())
+
+	//Replayed calls do not reach node, connection is never established
+	if !c.replay {
+		opts = append(opts, grpc.WithBlock())
+	}
+	opts = append(opts, grpc.WithUnaryInterceptor(c.unaryInterceptor))
+	opts = append(opts, grpc.WithStreamInterceptor(c.streamInterceptor))
 
 	//TODO #ManInTheMiddle How to get Cert of Node?
 	if false {
@@ -65,7 +95,10 @@ func (c *connection) LazyConnection() error {
 		opts = append(opts, grpc.WithInsecure())
 	}
 
-	conn, err := grpc.Dial(c.name+":"+c.evoGRpcPort, opts...)
+	ctx, cancel := c.requestContext()
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, c.name+":"+c.evoGRpcPort, opts...)
 
 	if err != nil {
 		return err
@@ -76,6 +109,32 @@ func (c *connection) LazyConnection() error {
 	return nil
 }
 
+//Copy of connection bound to context, cancel it to abort requests and streams
+func (c *connection) WithContext(ctx context.Context) interfaces.IConnection {
+	//Copies are made concurrently (e.g. by gateway), only one of them dials
+	c.dial.Lock()
+	defer c.dial.Unlock()
+
+	n := *c
+	n.ctx = ctx
+
+	//Share gRPC connection with original node, dial is bounded by new context
+	if c.conn == nil && n.connect() == nil {
+		c.conn = n.conn
+	}
+
+	return &n
+}
+
+//Context of single request, bounded by client timeout
+func (c *connection) requestContext() (context.Context, context.CancelFunc) {
+	if c.timeout > 0 {
+		return context.WithTimeout(c.ctx, c.timeout)
+	}
+
+	return context.WithCancel(c.ctx)
+}
+
 func (c *connection) GetNodeName() string {
 	return c.name
 }
@@ -84,10 +143,15 @@ func (c *connection) Remove() {
 	c.client.Lock()
 	defer c.client.Unlock()
 
-	if c.client.connections[c.name].conn != nil {
-		_ = c.client.connections[c.name].conn.Close()
+	node := c.client.connections[c.name]
+	node.dial.Lock()
+
+	if node.conn != nil {
+		_ = node.conn.Close()
 	}
 
+	node.dial.Unlock()
+
 	//TODO Investigate Benchmark, full rebuild connectionKeys
 	i := sort.SearchStrings(c.client.connectionKeys, c.name)
 	connectionNewLen := len(c.client.connectionKeys) - 1
@@ -100,4 +164,5 @@ func (c *connection) Remove() {
 	c.client.connectionKeys[connectionNewLen] = ""
 	c.client.connectionKeys = c.client.connectionKeys[:connectionNewLen]
 	delete(c.client.connections, c.name)
+	c.client.records[c.name] = c.stats.snapshot()
 }
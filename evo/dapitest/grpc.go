@@ -0,0 +1,115 @@
+package dapitest
+
+import (
+	"context"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	protobuf "github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"reflect"
+)
+
+type coreServer struct {
+	*Server
+}
+
+type platformServer struct {
+	*Server
+}
+
+type transactionsFilterStreamServer struct {
+	*Server
+}
+
+func (s *coreServer) GetStatus(ctx context.Context, r *proto.GetStatusRequest) (*proto.GetStatusResponse, error) {
+	response := new(proto.GetStatusResponse)
+
+	return response, s.unary(ctx, MethodGetStatus, r, response)
+}
+
+func (s *coreServer) GetBlock(ctx context.Context, r *proto.GetBlockRequest) (*proto.GetBlockResponse, error) {
+	response := new(proto.GetBlockResponse)
+
+	return response, s.unary(ctx, MethodGetBlock, r, response)
+}
+
+func (s *coreServer) SendTransaction(ctx context.Context, r *proto.SendTransactionRequest) (*proto.SendTransactionResponse, error) {
+	response := new(proto.SendTransactionResponse)
+
+	return response, s.unary(ctx, MethodSendTransaction, r, response)
+}
+
+func (s *coreServer) GetTransaction(ctx context.Context, r *proto.GetTransactionRequest) (*proto.GetTransactionResponse, error) {
+	response := new(proto.GetTransactionResponse)
+
+	return response, s.unary(ctx, MethodGetTransaction, r, response)
+}
+
+func (s *coreServer) GetEstimatedTransactionFee(ctx context.Context, r *proto.GetEstimatedTransactionFeeRequest) (*proto.GetEstimatedTransactionFeeResponse, error) {
+	response := new(proto.GetEstimatedTransactionFeeResponse)
+
+	return response, s.unary(ctx, MethodGetEstimatedTransactionFee, r, response)
+}
+
+func (s *coreServer) SubscribeToBlockHeadersWithChainLocks(r *proto.BlockHeadersWithChainLocksRequest, server proto.Core_SubscribeToBlockHeadersWithChainLocksServer) error {
+	return s.stream(server.Context(), MethodSubscribeToBlockHeadersWithChainLocks, r, new(proto.BlockHeadersWithChainLocksResponse), server.SendMsg)
+}
+
+func (s *platformServer) ApplyStateTransition(ctx context.Context, r *proto.ApplyStateTransitionRequest) (*proto.ApplyStateTransitionResponse, error) {
+	response := new(proto.ApplyStateTransitionResponse)
+
+	return response, s.unary(ctx, MethodApplyStateTransition, r, response)
+}
+
+func (s *platformServer) GetIdentity(ctx context.Context, r *proto.GetIdentityRequest) (*proto.GetIdentityResponse, error) {
+	response := new(proto.GetIdentityResponse)
+
+	return response, s.unary(ctx, MethodGetIdentity, r, response)
+}
+
+func (s *platformServer) GetDataContract(ctx context.Context, r *proto.GetDataContractRequest) (*proto.GetDataContractResponse, error) {
+	response := new(proto.GetDataContractResponse)
+
+	return response, s.unary(ctx, MethodGetDataContract, r, response)
+}
+
+func (s *platformServer) GetDocuments(ctx context.Context, r *proto.GetDocumentsRequest) (*proto.GetDocumentsResponse, error) {
+	response := new(proto.GetDocumentsResponse)
+
+	return response, s.unary(ctx, MethodGetDocuments, r, response)
+}
+
+func (s *transactionsFilterStreamServer) SubscribeToTransactionsWithProofs(r *proto.TransactionsWithProofsRequest, server proto.TransactionsFilterStream_SubscribeToTransactionsWithProofsServer) error {
+	return s.stream(server.Context(), MethodSubscribeToTransactionsWithProofs, r, new(proto.TransactionsWithProofsResponse), server.SendMsg)
+}
+
+//Copy result of handler into response, result must be message of same type
+func (s *Server) unary(ctx context.Context, method string, request protobuf.Message, response protobuf.Message) error {
+	handler, err := s.call(ctx, method, request)
+
+	if err != nil {
+		return err
+	}
+
+	if handler == nil {
+		return status.Errorf(codes.Unimplemented, "method %s is not programmed", method)
+	}
+
+	result, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	}
+
+	return checkMessage(method, result, response)
+}
+
+func checkMessage(method string, message interface{}, expected protobuf.Message) error {
+	if message == nil || reflect.TypeOf(message) != reflect.TypeOf(expected) {
+		return status.Errorf(codes.Internal, "response of %s is %T, expected %T", method, message, expected)
+	}
+
+	protobuf.Merge(expected, message.(protobuf.Message))
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package dapitest
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/co-in/dash-dapi/evo"
+	"google.golang.org/grpc/metadata"
+	"net/http"
+)
+
+//JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInternalError  = -32603
+)
+
+type jsonRPCRequest struct {
+	Id     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type jsonRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *jsonRPCError   `json:"error,omitempty"`
+}
+
+func (s *Server) jsonRPCHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request jsonRPCRequest
+		response := jsonRPCResponse{JSONRPC: "2.0"}
+
+		err := json.NewDecoder(r.Body).Decode(&request)
+
+		if err != nil {
+			response.Error = &jsonRPCError{Code: codeParseError, Message: err.Error()}
+		} else {
+			response.Id = request.Id
+			response.Result, response.Error = s.serveJSON(r, request)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
+}
+
+//Handlers see HTTP headers as incoming metadata with lowercase keys, like metadata of gRPC calls
+func (s *Server) serveJSON(r *http.Request, request jsonRPCRequest) (interface{}, *jsonRPCError) {
+	md := metadata.MD{}
+
+	for key, values := range r.Header {
+		md.Append(key, values...)
+	}
+
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	handler, err := s.call(ctx, request.Method, request.Params)
+
+	if err == nil && handler == nil {
+		return nil, &jsonRPCError{Code: codeMethodNotFound, Message: "Method not found: " + request.Method}
+	}
+
+	var result interface{}
+
+	if err == nil {
+		result, err = handler(ctx, request.Params)
+	}
+
+	if err != nil {
+		var rpcErr *evo.RPCError
+
+		if errors.As(err, &rpcErr) {
+			return nil, &jsonRPCError{Code: rpcErr.Code, Message: rpcErr.Message}
+		}
+
+		return nil, &jsonRPCError{Code: codeInternalError, Message: err.Error()}
+	}
+
+	//Null result of successful call is kept, unlike omitted result of error
+	if result == nil {
+		result = json.RawMessage("null")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,256 @@
+package dapitest
+
+import (
+	"context"
+	"fmt"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"google.golang.org/grpc"
+	"net"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+//Methods served by fake node, names are used by DAPI on wire
+const (
+	MethodGetBestBlockHash  = "getBestBlockHash"
+	MethodGetBlockHash      = "getBlockHash"
+	MethodGetMnListDiff     = "getMnListDiff"
+	MethodGetUTXO           = "getUTXO"
+	MethodGetAddressSummary = "getAddressSummary"
+
+	MethodGetStatus                             = "getStatus"
+	MethodGetBlock                              = "getBlock"
+	MethodSendTransaction                       = "sendTransaction"
+	MethodGetTransaction                        = "getTransaction"
+	MethodGetEstimatedTransactionFee            = "getEstimatedTransactionFee"
+	MethodSubscribeToBlockHeadersWithChainLocks = "subscribeToBlockHeadersWithChainLocks"
+	MethodApplyStateTransition                  = "applyStateTransition"
+	MethodGetIdentity                           = "getIdentity"
+	MethodGetDataContract                       = "getDataContract"
+	MethodGetDocuments                          = "getDocuments"
+	MethodSubscribeToTransactionsWithProofs     = "subscribeToTransactionsWithProofs"
+
+	//Latency and errors set for AnyMethod apply to every method without own setting
+	AnyMethod = "*"
+)
+
+//Default best block hash, so fresh server passes availability check of client
+const DefaultBestBlockHash = "0000000000000000000000000000000000000000000000000000000000000001"
+
+//Programmable response of method. Request is json.RawMessage params of JSON-RPC method or gRPC request message.
+//Response is marshalled as JSON-RPC result or must be gRPC response message of method.
+//Incoming metadata of ctx are gRPC metadata or HTTP headers of JSON-RPC request, e.g. traceparent
+type Handler func(ctx context.Context, request interface{}) (interface{}, error)
+
+//Fake evonode serving JSON-RPC and gRPC on loopback, for tests without live network. Safe for concurrent use
+type Server struct {
+	sync.Mutex
+	Host        string
+	JSONRPCPort uint16
+	GRPCPort    uint16
+	json        *httptest.Server
+	grpc        *grpc.Server
+	handlers    map[string]Handler
+	latency     map[string]time.Duration
+	faults      map[string]*fault
+	streams     map[string][]StreamEvent
+	requests    map[string][]interface{}
+}
+
+type fault struct {
+	err error
+	//Calls left to fail, negative fails until fault is cleared
+	remaining int
+}
+
+//Start fake node on free ports of 127.0.0.1. Only getStatus and getBestBlockHash respond until handlers are set,
+//other methods are unimplemented
+func NewServer() (*Server, error) {
+	return NewServerAt("127.0.0.1", 0, 0)
+}
+
+//Start fake node on host, zero port is chosen by system. Nodes of one client share ports,
+//so several fake nodes listen on same ports of different loopback addresses (127.0.0.2, ...)
+func NewServerAt(host string, jsonRPCPort uint16, gRPCPort uint16) (*Server, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(int(gRPCPort))))
+
+	if err != nil {
+		return nil, err
+	}
+
+	jsonListener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(int(jsonRPCPort))))
+
+	if err != nil {
+		_ = listener.Close()
+
+		return nil, err
+	}
+
+	s := &Server{
+		Host:     host,
+		handlers: make(map[string]Handler),
+		latency:  make(map[string]time.Duration),
+		faults:   make(map[string]*fault),
+		streams:  make(map[string][]StreamEvent),
+		requests: make(map[string][]interface{}),
+	}
+
+	s.SetResponse(MethodGetStatus, &proto.GetStatusResponse{Connections: 8})
+	s.SetResponse(MethodGetBestBlockHash, DefaultBestBlockHash)
+
+	s.grpc = grpc.NewServer()
+	proto.RegisterCoreServer(s.grpc, &coreServer{s})
+	proto.RegisterPlatformServer(s.grpc, &platformServer{s})
+	proto.RegisterTransactionsFilterStreamServer(s.grpc, &transactionsFilterStreamServer{s})
+
+	go func() {
+		_ = s.grpc.Serve(listener)
+	}()
+
+	s.json = httptest.NewUnstartedServer(s.jsonRPCHandler())
+	_ = s.json.Listener.Close()
+	s.json.Listener = jsonListener
+	s.json.Start()
+
+	s.GRPCPort, err = port(listener.Addr())
+
+	if err == nil {
+		s.JSONRPCPort, err = port(jsonListener.Addr())
+	}
+
+	if err != nil {
+		s.Close()
+
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func port(addr net.Addr) (uint16, error) {
+	_, value, err := net.SplitHostPort(addr.String())
+
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.ParseUint(value, 10, 16)
+
+	if err != nil {
+		return 0, fmt.Errorf("port of %s: %s", addr, err)
+	}
+
+	return uint16(n), nil
+}
+
+//Stop both endpoints, open streams are cancelled
+func (s *Server) Close() {
+	s.grpc.Stop()
+	s.json.Close()
+}
+
+func (s *Server) Handle(method string, handler Handler) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.handlers[method] = handler
+}
+
+//Respond to every call of method with response, see Handler
+func (s *Server) SetResponse(method string, response interface{}) {
+	s.Handle(method, func(context.Context, interface{}) (interface{}, error) {
+		return response, nil
+	})
+}
+
+//Delay every call of method, zero removes delay. Delay ends early when caller cancels request
+func (s *Server) SetLatency(method string, latency time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.latency[method] = latency
+}
+
+//Fail every call of method until it is cleared by nil err. gRPC calls return status of err,
+//JSON-RPC calls return code of *evo.RPCError or internal error
+func (s *Server) SetError(method string, err error) {
+	s.FailTimes(method, -1, err)
+}
+
+//Fail next times calls of method, e.g. to test retries and failover
+func (s *Server) FailTimes(method string, times int, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if err == nil || times == 0 {
+		delete(s.faults, method)
+
+		return
+	}
+
+	s.faults[method] = &fault{err: err, remaining: times}
+}
+
+//Requests received by method in order of arrival
+func (s *Server) Requests(method string) []interface{} {
+	s.Lock()
+	defer s.Unlock()
+
+	return append([]interface{}(nil), s.requests[method]...)
+}
+
+//Record request, wait for latency and return injected error or handler of method.
+//Nil handler with nil error means method is not programmed
+func (s *Server) call(ctx context.Context, method string, request interface{}) (Handler, error) {
+	s.Lock()
+	s.requests[method] = append(s.requests[method], request)
+
+	latency, ok := s.latency[method]
+
+	if !ok {
+		latency = s.latency[AnyMethod]
+	}
+
+	f, ok := s.faults[method]
+
+	if !ok {
+		f = s.faults[AnyMethod]
+	}
+
+	var err error
+
+	if f != nil {
+		err = f.err
+
+		if f.remaining > 0 {
+			f.remaining--
+
+			if f.remaining == 0 {
+				//Counted fault of AnyMethod is shared by all methods
+				for name, other := range s.faults {
+					if other == f {
+						delete(s.faults, name)
+					}
+				}
+			}
+		}
+	}
+
+	handler := s.handlers[method]
+	s.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return handler, err
+}
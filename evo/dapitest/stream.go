@@ -0,0 +1,130 @@
+package dapitest
+
+import (
+	"context"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	protobuf "github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+//Step of scripted stream: wait Delay, then send Message, end stream with Err or hold it open
+type StreamEvent struct {
+	Delay time.Duration
+	//Response message of stream method
+	Message protobuf.Message
+	Err     error
+	//Keep stream open until client cancels it
+	Hold bool
+}
+
+//Play events to every subscription of stream method. Stream ends after last event unless it holds
+func (s *Server) SetStream(method string, events ...StreamEvent) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.streams[method] = append([]StreamEvent(nil), events...)
+}
+
+func Transactions(transactions ...[]byte) StreamEvent {
+	return StreamEvent{Message: &proto.TransactionsWithProofsResponse{
+		Responses: &proto.TransactionsWithProofsResponse_RawTransactions{
+			RawTransactions: &proto.RawTransactions{Transactions: transactions},
+		},
+	}}
+}
+
+func MerkleBlock(block []byte) StreamEvent {
+	return StreamEvent{Message: &proto.TransactionsWithProofsResponse{
+		Responses: &proto.TransactionsWithProofsResponse_RawMerkleBlock{RawMerkleBlock: block},
+	}}
+}
+
+func InstantLocks(locks ...[]byte) StreamEvent {
+	return StreamEvent{Message: &proto.TransactionsWithProofsResponse{
+		Responses: &proto.TransactionsWithProofsResponse_InstantSendLockMessages{
+			InstantSendLockMessages: &proto.InstantSendLockMessages{Messages: locks},
+		},
+	}}
+}
+
+func BlockHeaders(headers ...[]byte) StreamEvent {
+	return StreamEvent{Message: &proto.BlockHeadersWithChainLocksResponse{
+		Responses: &proto.BlockHeadersWithChainLocksResponse_BlockHeaders{
+			BlockHeaders: &proto.BlockHeaders{Headers: headers},
+		},
+	}}
+}
+
+func ChainLocks(signatures ...[]byte) StreamEvent {
+	return StreamEvent{Message: &proto.BlockHeadersWithChainLocksResponse{
+		Responses: &proto.BlockHeadersWithChainLocksResponse_ChainLockSignatureMessages{
+			ChainLockSignatureMessages: &proto.ChainLockSignatureMessages{Messages: signatures},
+		},
+	}}
+}
+
+//Stream ending with error, e.g. to test reconnection
+func Fail(err error) StreamEvent {
+	return StreamEvent{Err: err}
+}
+
+func Hold() StreamEvent {
+	return StreamEvent{Hold: true}
+}
+
+func (s *Server) stream(ctx context.Context, method string, request protobuf.Message, expected protobuf.Message, send func(interface{}) error) error {
+	_, err := s.call(ctx, method, request)
+
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	events, ok := s.streams[method]
+	s.Unlock()
+
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "stream %s is not scripted", method)
+	}
+
+	for _, event := range events {
+		if event.Delay > 0 {
+			timer := time.NewTimer(event.Delay)
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		if event.Message != nil {
+			message := protobuf.Clone(expected)
+			err = checkMessage(method, event.Message, message)
+
+			if err == nil {
+				err = send(message)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if event.Err != nil {
+			return event.Err
+		}
+
+		if event.Hold {
+			<-ctx.Done()
+
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
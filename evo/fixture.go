@@ -0,0 +1,479 @@
+package evo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang/protobuf/jsonpb"
+	protobuf "github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+//Call without recorded exchange in replay mode
+var ErrUnmatched = errors.New("no recorded exchange")
+
+//Recorded call: request and response as JSON, gRPC messages in protobuf JSON mapping
+type Exchange struct {
+	Transport string `json:"transport"`
+	Method    string `json:"method"`
+	//Node of recording, ignored by replay
+	Node     string          `json:"node,omitempty"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	//Messages received from stream, in order
+	Messages []json.RawMessage `json:"messages,omitempty"`
+	Stream   bool              `json:"stream,omitempty"`
+	Error    *ExchangeError    `json:"error,omitempty"`
+}
+
+//Failure of call or end of stream. Code is gRPC status code name or JSON-RPC error code, empty for transport failures
+type ExchangeError struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+//Request/response pairs of calls made through client, written by WithRecording and served by WithReplay
+type Fixture struct {
+	sync.Mutex
+	Exchanges []Exchange `json:"exchanges"`
+	//Serve last matching exchange again when all of them were served, e.g. for polling calls.
+	//Replay of more calls than were recorded fails with ErrUnmatched otherwise
+	Repeat bool `json:"repeat,omitempty"`
+	//Exchanges already served by replay
+	used []bool
+}
+
+func NewFixture() *Fixture {
+	return &Fixture{}
+}
+
+func LoadFixture(fileName string) (*Fixture, error) {
+	data, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	f := NewFixture()
+	err = json.Unmarshal(data, f)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", fileName, err)
+	}
+
+	return f, nil
+}
+
+func (f *Fixture) Save(fileName string) error {
+	f.Lock()
+	data, err := json.MarshalIndent(f, "", "  ")
+	f.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(fileName, append(data, '\n'), 0644)
+}
+
+//Append exchange, returns its index
+func (f *Fixture) add(exchange Exchange) int {
+	f.Lock()
+	defer f.Unlock()
+
+	f.Exchanges = append(f.Exchanges, exchange)
+
+	return len(f.Exchanges) - 1
+}
+
+//Replace exchange added before, e.g. stream receiving messages
+func (f *Fixture) set(index int, exchange Exchange) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.Exchanges[index] = exchange
+}
+
+//First unused exchange of method with equal request. Exchanges are served once in recorded order,
+//when all of them were served the last one is repeated only by Repeat fixture
+func (f *Fixture) match(transport string, method string, request json.RawMessage, stream bool) (Exchange, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if len(f.used) != len(f.Exchanges) {
+		f.used = append(f.used, make([]bool, len(f.Exchanges)-len(f.used))...)
+	}
+
+	last := -1
+
+	for i, exchange := range f.Exchanges {
+		if exchange.Transport != transport || exchange.Method != method || exchange.Stream != stream || !sameJSON(exchange.Request, request) {
+			continue
+		}
+
+		if !f.used[i] {
+			f.used[i] = true
+
+			return exchange, nil
+		}
+
+		last = i
+	}
+
+	if last >= 0 && f.Repeat {
+		return f.Exchanges[last], nil
+	}
+
+	return Exchange{}, fmt.Errorf("%w: %s %s %s", ErrUnmatched, transport, method, request)
+}
+
+//Fixture files are indented and may be edited by hand, so requests are compared compacted
+func sameJSON(a json.RawMessage, b json.RawMessage) bool {
+	var compactA, compactB bytes.Buffer
+
+	if json.Compact(&compactA, a) != nil || json.Compact(&compactB, b) != nil {
+		return bytes.Equal(a, b)
+	}
+
+	return bytes.Equal(compactA.Bytes(), compactB.Bytes())
+}
+
+//Record every call to fixture, save it when client is not needed anymore
+func WithRecording(fixture *Fixture) Option {
+	return func(c *client) {
+		c.fixture = fixture
+		c.replay = false
+	}
+}
+
+//Serve calls from fixture without network, calls without unused recorded exchange fail with ErrUnmatched
+func WithReplay(fixture *Fixture) Option {
+	return func(c *client) {
+		c.fixture = fixture
+		c.replay = true
+	}
+}
+
+//Innermost middleware of fixture, every attempt is recorded or replayed
+func (c *connection) fixtureMiddleware(next Invoker) Invoker {
+	return func(ctx context.Context, call *Call) error {
+		if c.replay {
+			return c.replayCall(ctx, call)
+		}
+
+		err := next(ctx, call)
+
+		if call.Stream {
+			if err == nil {
+				call.Response = newRecordingStream(ctx, call.Response.(grpc.ClientStream), c.fixture,
+					Exchange{Transport: call.Transport, Method: call.Method, Node: call.Node, Stream: true})
+			} else {
+				c.fixture.add(Exchange{Transport: call.Transport, Method: call.Method, Node: call.Node, Stream: true, Error: exchangeError(err)})
+			}
+
+			return err
+		}
+
+		exchange := Exchange{Transport: call.Transport, Method: call.Method, Node: call.Node}
+		exchange.Request, _ = marshalPayload(call.Request)
+
+		if err == nil {
+			exchange.Response, err = marshalPayload(call.Response)
+		} else {
+			exchange.Error = exchangeError(err)
+		}
+
+		c.fixture.add(exchange)
+
+		return err
+	}
+}
+
+func (c *connection) replayCall(ctx context.Context, call *Call) error {
+	if call.Stream {
+		//Request of stream is sent after it is opened
+		call.Response = &replayStream{ctx: ctx, fixture: c.fixture, call: *call}
+
+		return nil
+	}
+
+	request, err := marshalPayload(call.Request)
+
+	if err != nil {
+		return err
+	}
+
+	exchange, err := c.fixture.match(call.Transport, call.Method, request, false)
+
+	if err != nil {
+		return err
+	}
+
+	if exchange.Error != nil {
+		return exchange.Error.err(call)
+	}
+
+	return unmarshalPayload(exchange.Response, call.Response)
+}
+
+//Canonical JSON of payload, requests are matched by it
+func marshalPayload(payload interface{}) (json.RawMessage, error) {
+	if message, ok := payload.(protobuf.Message); ok {
+		var b bytes.Buffer
+		err := (&jsonpb.Marshaler{}).Marshal(&b, message)
+
+		return b.Bytes(), err
+	}
+
+	data, err := json.Marshal(payload)
+
+	if err != nil {
+		return nil, err
+	}
+
+	//Raw params are compacted, so formatting of caller does not matter
+	var b bytes.Buffer
+	err = json.Compact(&b, data)
+
+	return b.Bytes(), err
+}
+
+func unmarshalPayload(data json.RawMessage, payload interface{}) error {
+	if message, ok := payload.(protobuf.Message); ok {
+		return jsonpb.Unmarshal(bytes.NewReader(data), message)
+	}
+
+	return json.Unmarshal(data, payload)
+}
+
+func exchangeError(err error) *ExchangeError {
+	var rpcErr *RPCError
+
+	if errors.As(err, &rpcErr) {
+		return &ExchangeError{Code: fmt.Sprint(rpcErr.Code), Message: rpcErr.Message}
+	}
+
+	if s, ok := status.FromError(err); ok {
+		return &ExchangeError{Code: s.Code().String(), Message: s.Message()}
+	}
+
+	return &ExchangeError{Message: err.Error()}
+}
+
+//Error of recorded exchange as it was returned to caller
+func (e *ExchangeError) err(call *Call) error {
+	if e.Code == "" {
+		return errors.New(e.Message)
+	}
+
+	if call.Transport == TransportJSONRPC {
+		rpcErr := &RPCError{MessageId: call.Id, Node: call.Node, Message: e.Message}
+		_, err := fmt.Sscan(e.Code, &rpcErr.Code)
+
+		if err != nil {
+			return fmt.Errorf("code of recorded error %s: %s", e.Code, err)
+		}
+
+		return rpcErr
+	}
+
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		if code.String() == e.Code {
+			return status.Error(code, e.Message)
+		}
+	}
+
+	return status.Error(codes.Unknown, e.Message)
+}
+
+//Stream keeping its exchange in fixture up to date: exchange is added when request is sent,
+//received messages are appended as they arrive. Stream ends by error of RecvMsg or by context of caller
+type recordingStream struct {
+	grpc.ClientStream
+	mu       sync.Mutex
+	fixture  *Fixture
+	exchange Exchange
+	//Index of exchange in fixture, -1 until it is added
+	index int
+	ended bool
+	done  chan struct{}
+}
+
+func newRecordingStream(ctx context.Context, stream grpc.ClientStream, fixture *Fixture, exchange Exchange) *recordingStream {
+	s := &recordingStream{
+		ClientStream: stream,
+		fixture:      fixture,
+		exchange:     exchange,
+		index:        -1,
+		done:         make(chan struct{}),
+	}
+
+	//Consumer may cancel stream without receiving its end
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.end(status.FromContextError(ctx.Err()).Err())
+		case <-s.done:
+		}
+	}()
+
+	return s
+}
+
+func (s *recordingStream) SendMsg(m interface{}) error {
+	s.mu.Lock()
+	s.exchange.Request, _ = marshalPayload(m)
+	s.flush()
+	s.mu.Unlock()
+
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *recordingStream) CloseSend() error {
+	s.mu.Lock()
+	s.flush()
+	s.mu.Unlock()
+
+	return s.ClientStream.CloseSend()
+}
+
+func (s *recordingStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+
+	if err != nil {
+		if err == io.EOF {
+			err = nil
+		}
+
+		s.end(err)
+
+		return s.recvErr(err)
+	}
+
+	message, _ := marshalPayload(m)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.ended {
+		s.exchange.Messages = append(s.exchange.Messages, message)
+		s.flush()
+	}
+
+	return nil
+}
+
+func (s *recordingStream) recvErr(err error) error {
+	if err == nil {
+		return io.EOF
+	}
+
+	return err
+}
+
+//Record end of stream once, nil err is io.EOF
+func (s *recordingStream) end(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ended {
+		return
+	}
+
+	s.ended = true
+	close(s.done)
+
+	if err != nil {
+		s.exchange.Error = exchangeError(err)
+	}
+
+	s.flush()
+}
+
+//Write exchange to fixture, s.mu is held
+func (s *recordingStream) flush() {
+	if s.index < 0 {
+		s.index = s.fixture.add(s.exchange)
+
+		return
+	}
+
+	s.fixture.set(s.index, s.exchange)
+}
+
+//Stream playing messages of exchange matching its request, then recorded error or io.EOF
+type replayStream struct {
+	ctx      context.Context
+	fixture  *Fixture
+	call     Call
+	exchange *Exchange
+	err      error
+	next     int
+}
+
+func (s *replayStream) SendMsg(m interface{}) error {
+	request, err := marshalPayload(m)
+
+	if err == nil {
+		var exchange Exchange
+		exchange, err = s.fixture.match(s.call.Transport, s.call.Method, request, true)
+		s.exchange = &exchange
+	}
+
+	s.err = err
+
+	return err
+}
+
+func (s *replayStream) RecvMsg(m interface{}) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	//Like gRPC stream, replayed one ends when its context is done
+	err := s.ctx.Err()
+
+	if err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	if s.exchange == nil {
+		return fmt.Errorf("%w: %s %s, request was not sent", ErrUnmatched, s.call.Transport, s.call.Method)
+	}
+
+	if s.next < len(s.exchange.Messages) {
+		s.next++
+
+		return unmarshalPayload(s.exchange.Messages[s.next-1], m)
+	}
+
+	if s.exchange.Error != nil {
+		return s.exchange.Error.err(&s.call)
+	}
+
+	return io.EOF
+}
+
+func (s *replayStream) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+func (s *replayStream) Trailer() metadata.MD {
+	return metadata.MD{}
+}
+
+func (s *replayStream) CloseSend() error {
+	return nil
+}
+
+func (s *replayStream) Context() context.Context {
+	return s.ctx
+}
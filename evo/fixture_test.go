@@ -0,0 +1,115 @@
+package evo_test
+
+import (
+	"context"
+	"errors"
+	"github.com/co-in/dash-dapi/evo"
+	"github.com/co-in/dash-dapi/evo/dapitest"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestReplayUsedUp(t *testing.T) {
+	s := newServer(t)
+	s.SetResponse(dapitest.MethodGetBestBlockHash, "00000000000000000000000000000000000000000000000000000000000000ff")
+	fixture := evo.NewFixture()
+
+	_, err := newNode(t, s, evo.WithRecording(fixture)).GetBestBlockHash()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node := newNode(t, s, evo.WithReplay(fixture))
+	hash, err := node.GetBestBlockHash()
+
+	if err != nil || *hash != "00000000000000000000000000000000000000000000000000000000000000ff" {
+		t.Fatalf("replayed %v, %v", hash, err)
+	}
+
+	//Program made more calls than were recorded
+	_, err = node.GetBestBlockHash()
+
+	if !errors.Is(err, evo.ErrUnmatched) {
+		t.Fatalf("error %v, expected %s", err, evo.ErrUnmatched)
+	}
+
+	fixture.Repeat = true
+	_, err = node.GetBestBlockHash()
+
+	if err != nil {
+		t.Fatalf("repeated exchange: %s", err)
+	}
+}
+
+func TestRecordCanceledStream(t *testing.T) {
+	s := newServer(t)
+	s.SetStream(dapitest.MethodSubscribeToTransactionsWithProofs, dapitest.MerkleBlock([]byte{1, 2, 3}), dapitest.Hold())
+	fixture := evo.NewFixture()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	params := structures.SubscribeToTransactionsWithProofsRequest{BloomFilter: structures.BloomFilterRequest{Data: []byte{0xff}, HashFunc: 3}}
+	stream, err := newNode(t, s, evo.WithRecording(fixture)).WithContext(ctx).SubscribeToTransactionsWithProofs(params)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = stream.Recv()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Stream is in fixture before it ends
+	if exchange := streamExchange(fixture); exchange == nil || len(exchange.Messages) != 1 || exchange.Error != nil {
+		t.Fatalf("exchange of open stream %+v", exchange)
+	}
+
+	//Consumer cancels stream without receiving its end
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+
+	for exchange := streamExchange(fixture); exchange.Error == nil; exchange = streamExchange(fixture) {
+		if time.Now().After(deadline) {
+			t.Fatal("cancellation of stream is not recorded")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	stream, err = newNode(t, s, evo.WithReplay(fixture)).SubscribeToTransactionsWithProofs(params)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := stream.Recv()
+
+	if err != nil || len(response.GetRawMerkleBlock()) != 3 {
+		t.Fatalf("replayed %v, %v", response, err)
+	}
+
+	_, err = stream.Recv()
+
+	if status.Code(err) != codes.Canceled {
+		t.Fatalf("end of replayed stream %v", err)
+	}
+}
+
+func streamExchange(fixture *evo.Fixture) *evo.Exchange {
+	fixture.Lock()
+	defer fixture.Unlock()
+
+	for _, exchange := range fixture.Exchanges {
+		if exchange.Stream {
+			return &exchange
+		}
+	}
+
+	return nil
+}
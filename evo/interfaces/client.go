@@ -1,6 +1,10 @@
 package interfaces
 
 import (
+	"context"
+	"encoding/json"
+	"github.com/co-in/dash-dapi/db"
+	"github.com/co-in/dash-dapi/evo/network"
 	proto "github.com/co-in/dash-dapi/evo/protobuf"
 	"github.com/co-in/dash-dapi/evo/structures"
 )
@@ -9,11 +13,14 @@ type IClient interface {
 	AddNode(hostname string, fraud int) error
 	SelectRandomNode() (IConnection, error)
 	SelectNode(hostname string) (IConnection, error)
+	NodeRecords() map[string]db.NodeRecord
+	GetNetwork() *network.Params
 }
 
 type IConnection interface {
 	ILayer1
 	ILayer2
+	WithContext(ctx context.Context) IConnection
 	GetNodeName() string
 	CheckAvailability() bool
 	Remove()
@@ -30,6 +37,7 @@ type ILayer1JSON interface {
 	GetMnListDiff(baseBlockHash string, blockHash string) (*structures.MnListDiffResponse, error)
 	GetAddressSummary(addresses []string) (*structures.AddressSummaryResponse, error)
 	GetUTXO(request structures.UTXORequest) (*structures.UTXOResponse, error)
+	CallJSON(method string, params json.RawMessage) (json.RawMessage, error)
 }
 
 type ILayer1GRPC interface {
@@ -37,7 +45,9 @@ type ILayer1GRPC interface {
 	GetBlock(block structures.BlockRequest) (*proto.GetBlockResponse, error)
 	GetTransaction(id string) (*proto.GetTransactionResponse, error)
 	SendTransaction(data []byte, allowHighFees bool, bypassLimits bool) (*proto.SendTransactionResponse, error)
+	GetEstimatedTransactionFee(blocks int) (*proto.GetEstimatedTransactionFeeResponse, error)
 	SubscribeToTransactionsWithProofs(params structures.SubscribeToTransactionsWithProofsRequest) (proto.TransactionsFilterStream_SubscribeToTransactionsWithProofsClient, error)
+	SubscribeToBlockHeadersWithChainLocks(params structures.BlockHeadersWithChainLocksRequest) (proto.Core_SubscribeToBlockHeadersWithChainLocksClient, error)
 }
 
 type ILayer2 interface {
@@ -2,14 +2,18 @@ package evo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	proto "github.com/co-in/dash-dapi/evo/protobuf"
 	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/logging"
+	"github.com/co-in/dash-dapi/tracing"
 
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 )
 
 type jsonRPCRequest struct {
@@ -27,6 +31,18 @@ type jsonRPCResponse struct {
 	}
 }
 
+//Error returned by node in JSON-RPC response
+type RPCError struct {
+	MessageId int
+	Node      string
+	Code      int
+	Message   string
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("[ERROR] Recv JSON-RPC packet #%d from %s: [%d] %s", e.MessageId, e.Node, e.Code, e.Message)
+}
+
 const (
 	jsonEndpointGetAddressSummary = "getAddressSummary"
 	jsonEndpointGetBestBlockHash  = "getBestBlockHash"
@@ -55,7 +71,7 @@ func (c *connection) GetBestBlockHash() (*structures.BestBlockHashResponse, erro
 	body := make(map[string][]string)
 
 	response := new(structures.BestBlockHashResponse)
-	err := c.requestJSON(true, true, jsonEndpointGetBestBlockHash, body, &response)
+	err := c.requestJSON(c.ctx, jsonEndpointGetBestBlockHash, body, &response)
 
 	if err != nil {
 		return nil, err
@@ -69,7 +85,7 @@ func (c *connection) GetBlockHash(height int) (*structures.BlockHashResponse, er
 	body["height"] = height
 
 	response := new(structures.BlockHashResponse)
-	err := c.requestJSON(true, true, jsonEndpointGetBlockHash, body, &response)
+	err := c.requestJSON(c.ctx, jsonEndpointGetBlockHash, body, &response)
 
 	if err != nil {
 		return nil, err
@@ -84,7 +100,7 @@ func (c *connection) GetMnListDiff(baseBlockHash string, blockHash string) (*str
 	body["blockHash"] = blockHash
 
 	response := new(structures.MnListDiffResponse)
-	err := c.requestJSON(true, true, jsonEndpointGetMnListDiff, body, &response)
+	err := c.requestJSON(c.ctx, jsonEndpointGetMnListDiff, body, &response)
 
 	if err != nil {
 		return nil, err
@@ -99,7 +115,7 @@ func (c *connection) GetUTXO(request structures.UTXORequest) (*structures.UTXORe
 	}
 
 	response := new(structures.UTXOResponse)
-	err := c.requestJSON(true, true, jsonEndpointGetUTXO, request, &response)
+	err := c.requestJSON(c.ctx, jsonEndpointGetUTXO, request, &response)
 
 	if err != nil {
 		return nil, err
@@ -113,7 +129,19 @@ func (c *connection) GetAddressSummary(addresses []string) (*structures.AddressS
 	body["address"] = addresses
 
 	response := new(structures.AddressSummaryResponse)
-	err := c.requestJSON(true, true, jsonEndpointGetAddressSummary, body, response)
+	err := c.requestJSON(c.ctx, jsonEndpointGetAddressSummary, body, response)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+//Call JSON-RPC method with raw params and return raw result, used to forward requests as is
+func (c *connection) CallJSON(method string, params json.RawMessage) (json.RawMessage, error) {
+	var response json.RawMessage
+	err := c.requestJSON(c.ctx, method, params, &response)
 
 	if err != nil {
 		return nil, err
@@ -136,7 +164,7 @@ func (c *connection) GetBlock(block structures.BlockRequest) (*proto.GetBlockRes
 	layer1 := proto.NewCoreClient(c.conn)
 	request := new(proto.GetBlockRequest)
 
-	if block.Hash == nil {
+	if block.Hash != nil {
 		r := new(proto.GetBlockRequest_Hash)
 		r.Hash = *block.Hash
 		request.Block = r
@@ -216,6 +244,27 @@ func (c *connection) SendTransaction(data []byte, allowHighFees bool, bypassLimi
 	return response, nil
 }
 
+func (c *connection) GetEstimatedTransactionFee(blocks int) (*proto.GetEstimatedTransactionFeeResponse, error) {
+	err := c.LazyConnection()
+
+	if err != nil {
+		return nil, err
+	}
+
+	layer1 := proto.NewCoreClient(c.conn)
+	request := &proto.GetEstimatedTransactionFeeRequest{
+		Blocks: uint32(blocks),
+	}
+
+	response, err := layer1.GetEstimatedTransactionFee(c.ctx, request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
 //func (c *connection) SubscribeToTransactionsWithProofs(params structures.SubscribeToTransactionsWithProofsRequest) (*proto.TransactionsWithProofsResponse, error) {
 func (c *connection) SubscribeToTransactionsWithProofs(params structures.SubscribeToTransactionsWithProofsRequest) (proto.TransactionsFilterStream_SubscribeToTransactionsWithProofsClient, error) {
 	if params.FromBlockHash != nil && params.FromBlockHeight != nil {
@@ -267,19 +316,71 @@ func (c *connection) SubscribeToTransactionsWithProofs(params structures.Subscri
 	return response, nil
 }
 
-func (c *connection) requestJSON(withVerbose bool, withFraud bool, method string, params interface{}, response interface{}) error {
+func (c *connection) SubscribeToBlockHeadersWithChainLocks(params structures.BlockHeadersWithChainLocksRequest) (proto.Core_SubscribeToBlockHeadersWithChainLocksClient, error) {
+	if params.FromBlockHash != nil && params.FromBlockHeight != nil {
+		return nil, errors.New("only one of fields (FromBlockHash, FromBlockHeight)")
+	}
+
+	err := c.LazyConnection()
+
+	if err != nil {
+		return nil, err
+	}
+
+	layer1 := proto.NewCoreClient(c.conn)
+	request := new(proto.BlockHeadersWithChainLocksRequest)
+
+	if params.Count != nil {
+		request.Count = uint32(*params.Count)
+	}
+
+	if params.FromBlockHash != nil {
+		request.FromBlock = &proto.BlockHeadersWithChainLocksRequest_FromBlockHash{
+			FromBlockHash: *params.FromBlockHash,
+		}
+	}
+
+	if params.FromBlockHeight != nil {
+		request.FromBlock = &proto.BlockHeadersWithChainLocksRequest_FromBlockHeight{
+			FromBlockHeight: uint32(*params.FromBlockHeight),
+		}
+	}
+
+	response, err := layer1.SubscribeToBlockHeadersWithChainLocks(c.ctx, request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+//Call JSON-RPC method through middleware chain
+func (c *connection) requestJSON(ctx context.Context, method string, params interface{}, response interface{}) error {
 	c.Lock()
 	c.id++
 	id := c.id
 	c.Unlock()
 
+	call := &Call{
+		Transport: TransportJSONRPC,
+		Method:    method,
+		Id:        id,
+		Request:   params,
+		Response:  response,
+	}
+
+	return c.invoke(ctx, call, c.sendJSON)
+}
+
+func (c *connection) sendJSON(ctx context.Context, call *Call) error {
 	//TODO #ManInTheMiddle HTTPS?
 	nodeAddressURL := "http://" + c.name + ":" + c.evoJsonRpcPort
 
 	request, err := (jsonRPCRequest{
-		messageId: id,
-		method:    method,
-		params:    params,
+		messageId: call.Id,
+		method:    call.Method,
+		params:    call.Request,
 	}).Marshal()
 
 	if err != nil {
@@ -288,25 +389,30 @@ func (c *connection) requestJSON(withVerbose bool, withFraud bool, method string
 
 	r := bytes.NewReader(request)
 
-	if withVerbose && c.verboseLevel > 2 {
-		c.Printf("Send JSON-RPC packet #%d to %s: %s\n", c.id, nodeAddressURL, request)
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
 	}
 
-	resp, err := http.Post(nodeAddressURL, "application/json", r)
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, nodeAddressURL, r)
 
 	if err != nil {
-		if withFraud {
-			c.fraud++
-		}
+		return err
+	}
 
-		if withVerbose && c.verboseLevel > 1 {
-			c.Printf("Increase Fraud score: %s to %d. (Reason:%s)\n",
-				nodeAddressURL,
-				c.fraud,
-				err,
-			)
-		}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	if sc, ok := tracing.SpanContextFromContext(ctx); ok {
+		httpRequest.Header.Set(tracing.TraceparentKey, sc.Traceparent())
+	}
+
+	probe := ctx.Value(probeKey{}) != nil
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(httpRequest)
+	c.observe(ctx, start, err)
 
+	if err != nil {
 		return err
 	}
 
@@ -319,8 +425,8 @@ func (c *connection) requestJSON(withVerbose bool, withFraud bool, method string
 	err = resp.Body.Close()
 
 	if err != nil {
-		if withVerbose && c.verboseLevel > 1 {
-			c.Println(err)
+		if !probe {
+			logging.Info(c.logger, "Close JSON-RPC response", logging.Node(c.name), logging.Err(err))
 		}
 	}
 
@@ -332,19 +438,15 @@ func (c *connection) requestJSON(withVerbose bool, withFraud bool, method string
 	}
 
 	if jsonRPCResponse.Error != nil {
-		return fmt.Errorf("[ERROR] Recv JSON-RPC packet #%d from %s: [%d] %s\n",
-			jsonRPCResponse.MessageId,
-			c.name,
-			jsonRPCResponse.Error.Code,
-			jsonRPCResponse.Error.Message,
-		)
-	}
-
-	if withVerbose && c.verboseLevel > 2 {
-		c.Printf("Recv JSON-RPC packet #%d from %s: %s\n", jsonRPCResponse.MessageId, c.name, jsonRPCResponse.Result)
+		return &RPCError{
+			MessageId: jsonRPCResponse.MessageId,
+			Node:      c.name,
+			Code:      jsonRPCResponse.Error.Code,
+			Message:   jsonRPCResponse.Error.Message,
+		}
 	}
 
-	err = json.Unmarshal(jsonRPCResponse.Result, response)
+	err = json.Unmarshal(jsonRPCResponse.Result, call.Response)
 
 	if err != nil {
 		return err
@@ -0,0 +1,99 @@
+package evo
+
+import (
+	"context"
+	"errors"
+	"github.com/co-in/dash-dapi/metrics"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"time"
+)
+
+//Gauges of node pool
+type clientMetrics struct {
+	nodes   *metrics.GaugeVec
+	healthy *metrics.GaugeVec
+	fraud   *metrics.GaugeVec
+}
+
+//Collect request, node pool and fraud metrics into registry. Request metrics are installed as middleware
+func WithMetrics(registry *metrics.Registry) Option {
+	return func(c *client) {
+		c.metrics = &clientMetrics{
+			nodes:   registry.Gauge("dapi_client_nodes", "Nodes in pool"),
+			healthy: registry.Gauge("dapi_client_healthy_nodes", "Nodes in pool, which are neither banned nor of other network"),
+			fraud:   registry.Gauge("dapi_client_node_fraud_score", "Current fraud score of node", "node"),
+		}
+
+		registry.OnCollect(c.collectMetrics)
+		c.middleware = append(c.middleware, MetricsMiddleware(registry))
+	}
+}
+
+//Count calls and errors, observe latency. Streams are counted when they are opened, their duration is not observed
+func MetricsMiddleware(registry *metrics.Registry) Middleware {
+	requests := registry.Counter("dapi_client_requests_total", "DAPI calls by transport, method and node", "transport", "method", "node")
+	errorsTotal := registry.Counter("dapi_client_errors_total", "Failed DAPI calls by transport, method, node and error code", "transport", "method", "node", "code")
+	duration := registry.Histogram("dapi_client_request_duration_seconds", "Latency of DAPI calls", metrics.DefaultBuckets, "transport", "method", "node")
+
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, call *Call) error {
+			start := time.Now()
+			err := next(ctx, call)
+			requests.Inc(call.Transport, call.Method, call.Node)
+
+			if !call.Stream {
+				duration.Observe(time.Since(start).Seconds(), call.Transport, call.Method, call.Node)
+			}
+
+			if err != nil {
+				errorsTotal.Inc(call.Transport, call.Method, call.Node, errorCode(err))
+			}
+
+			return err
+		}
+	}
+}
+
+//Update node pool gauges before scrape
+func (c *client) collectMetrics() {
+	c.Lock()
+	defer c.Unlock()
+
+	now := time.Now()
+	healthy := 0
+	c.metrics.fraud.Reset()
+
+	for name, conn := range c.connections {
+		if !conn.stats.banned(now) && !conn.stats.mismatched() {
+			healthy++
+		}
+
+		c.metrics.fraud.Set(conn.stats.fraud(), name)
+	}
+
+	c.metrics.nodes.Set(float64(len(c.connections)))
+	c.metrics.healthy.Set(float64(healthy))
+}
+
+//Code label of failed call: gRPC status code, JSON-RPC error code or transport failure
+func errorCode(err error) string {
+	var rpcErr *RPCError
+
+	if errors.As(err, &rpcErr) {
+		return strconv.Itoa(rpcErr.Code)
+	}
+
+	if s, ok := status.FromError(err); ok {
+		return s.Code().String()
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "DeadlineExceeded"
+	case errors.Is(err, context.Canceled):
+		return "Canceled"
+	}
+
+	return "transport"
+}
@@ -0,0 +1,389 @@
+package evo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/co-in/dash-dapi/logging"
+	"github.com/co-in/dash-dapi/tracing"
+	protobuf "github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"path"
+	"sync"
+	"time"
+)
+
+//Transports of DAPI calls
+const (
+	TransportJSONRPC = "json-rpc"
+	TransportGRPC    = "grpc"
+)
+
+//DAPI call passing through middleware chain
+type Call struct {
+	Transport string
+	//JSON-RPC method or gRPC method without service, e.g. getBlockHash, getStatus
+	Method string
+	//Full gRPC method, empty for JSON-RPC
+	FullMethod string
+	Node       string
+	//JSON-RPC message id, zero for gRPC
+	Id int
+	//JSON-RPC params or gRPC request message
+	Request interface{}
+	//Pointer to JSON-RPC result or gRPC reply, filled by successful call. Opened grpc.ClientStream for streams
+	Response interface{}
+	Stream   bool
+	//Attempt of call on node, counted by RetryMiddleware from 1
+	Attempt int
+}
+
+//Performs call, next invoker of chain or transport itself
+type Invoker func(ctx context.Context, call *Call) error
+
+//Wraps every call of connection, e.g. logging, metrics, retries, rate limiting
+type Middleware func(next Invoker) Invoker
+
+//Calls made with probe context do not change reputation of node and are not logged
+type probeKey struct{}
+
+//Install middleware, first given is outermost. Transport updates reputation of node on every attempt
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+//Run call through middleware chain, transport performs call itself
+func (c *connection) invoke(ctx context.Context, call *Call, transport Invoker) error {
+	call.Node = c.name
+	invoker := transport
+
+	if c.fixture != nil {
+		invoker = c.fixtureMiddleware(invoker)
+	}
+
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		invoker = c.middleware[i](invoker)
+	}
+
+	return invoker(ctx, call)
+}
+
+//Unary gRPC calls pass middleware, client timeout is applied to each attempt
+func (c *connection) unaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	call := &Call{
+		Transport:  TransportGRPC,
+		Method:     path.Base(method),
+		FullMethod: method,
+		Request:    req,
+		Response:   reply,
+	}
+
+	return c.invoke(ctx, call, func(ctx context.Context, call *Call) error {
+		if c.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+		}
+
+		start := time.Now()
+		err := invoker(ctx, method, call.Request, call.Response, cc, opts...)
+		c.observe(ctx, start, err)
+
+		return err
+	})
+}
+
+//Streams pass middleware when they are opened, messages are not intercepted
+func (c *connection) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	call := &Call{
+		Transport:  TransportGRPC,
+		Method:     path.Base(method),
+		FullMethod: method,
+		Stream:     true,
+	}
+
+	err := c.invoke(ctx, call, func(ctx context.Context, call *Call) error {
+		start := time.Now()
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		c.observe(ctx, start, err)
+
+		if err != nil {
+			return err
+		}
+
+		call.Response = stream
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return call.Response.(grpc.ClientStream), nil
+}
+
+//Update reputation of node by attempt of call. Node answering with error of request itself is healthy,
+//probes do not change reputation
+func (c *connection) observe(ctx context.Context, start time.Time, err error) {
+	if ctx.Value(probeKey{}) != nil {
+		return
+	}
+
+	if err == nil || !NodeFailure(err) {
+		c.stats.success(time.Since(start))
+
+		return
+	}
+
+	fraud := c.stats.failure()
+
+	logging.Info(c.logger, "Increase fraud score", logging.Node(c.name), logging.Any("fraud", fraud), logging.Err(err))
+}
+
+//Node is unreachable or overloaded, unlike errors of request itself. Such calls may be repeated on same or another node
+func NodeFailure(err error) bool {
+	var rpcErr *RPCError
+
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+
+	s, ok := status.FromError(err)
+
+	if !ok {
+		//Dial, HTTP and timeout errors
+		return true
+	}
+
+	switch s.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	}
+
+	return false
+}
+
+//Methods carrying transactions or state transitions, their payloads are never logged
+var RedactedMethods = map[string]bool{
+	"getTransaction":       true,
+	"sendTransaction":      true,
+	"applyStateTransition": true,
+}
+
+//Log failed calls at info level, sent and received payloads at debug level. Payloads of RedactedMethods are replaced by their size
+func LoggingMiddleware(logger logging.Logger) Middleware {
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, call *Call) error {
+			if ctx.Value(probeKey{}) != nil || !logger.Enabled(logging.LevelInfo) {
+				return next(ctx, call)
+			}
+
+			fields := []logging.Field{logging.Node(call.Node), logging.Any("transport", call.Transport), logging.Method(call.Method)}
+
+			if call.Id != 0 {
+				fields = append(fields, logging.RequestID(call.Id))
+			}
+
+			if logger.Enabled(logging.LevelDebug) {
+				logging.Debug(logger, "Send", append(fields, logPayload(call.Method, call.Request))...)
+			}
+
+			start := time.Now()
+			err := next(ctx, call)
+			fields = append(fields, logging.Duration(time.Since(start)))
+
+			if err != nil {
+				logging.Info(logger, "Call failed", append(fields, logging.Err(err))...)
+			} else if logger.Enabled(logging.LevelDebug) && !call.Stream {
+				logging.Debug(logger, "Recv", append(fields, logPayload(call.Method, call.Response))...)
+			}
+
+			return err
+		}
+	}
+}
+
+func logPayload(method string, payload interface{}) logging.Field {
+	if payload == nil {
+		return logging.Any("payload", nil)
+	}
+
+	if RedactedMethods[method] {
+		return logging.Any("payload", fmt.Sprintf("[redacted %d bytes]", payloadSize(payload)))
+	}
+
+	data, err := json.Marshal(payload)
+
+	if err != nil {
+		return logging.Any("payload", err.Error())
+	}
+
+	return logging.Any("payload", json.RawMessage(data))
+}
+
+//Encoded size of gRPC message or JSON-RPC params and result
+func payloadSize(payload interface{}) int {
+	if payload == nil {
+		return 0
+	}
+
+	if message, ok := payload.(protobuf.Message); ok {
+		return protobuf.Size(message)
+	}
+
+	data, err := json.Marshal(payload)
+
+	if err != nil {
+		return 0
+	}
+
+	return len(data)
+}
+
+//Repeat failed call on same node. Nil retryable retries node failures (unreachable, overloaded, timed out).
+//Streams are retried only while they are opened
+func RetryMiddleware(attempts int, delay time.Duration, retryable func(error) bool) Middleware {
+	if retryable == nil {
+		retryable = NodeFailure
+	}
+
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, call *Call) error {
+			var err error
+
+			for attempt := 1; ; attempt++ {
+				call.Attempt = attempt
+				err = next(ctx, call)
+
+				if err == nil || attempt >= attempts || ctx.Err() != nil || !retryable(err) {
+					return err
+				}
+
+				select {
+				case <-ctx.Done():
+					return err
+				case <-time.After(delay):
+				}
+			}
+		}
+	}
+}
+
+//Token bucket shared by all nodes of client: rate calls per second with bursts up to burst calls.
+//Call waits for token until its context is done
+func RateLimitMiddleware(rate float64, burst int) Middleware {
+	if burst < 1 {
+		burst = 1
+	}
+
+	limiter := &tokenBucket{
+		rate:    rate,
+		burst:   float64(burst),
+		tokens:  float64(burst),
+		updated: time.Now(),
+	}
+
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, call *Call) error {
+			err := limiter.wait(ctx)
+
+			if err != nil {
+				return err
+			}
+
+			return next(ctx, call)
+		}
+	}
+}
+
+type tokenBucket struct {
+	sync.Mutex
+	rate    float64
+	burst   float64
+	tokens  float64
+	updated time.Time
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.updated).Seconds() * b.rate
+		b.updated = now
+
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.Unlock()
+
+			return nil
+		}
+
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+//Span around call of tracer given to WithTracer, it is outermost middleware so retries are inside of span
+func WithTracer(tracer *tracing.Tracer) Option {
+	return func(c *client) {
+		c.middleware = append([]Middleware{TracingMiddleware(tracer)}, c.middleware...)
+	}
+}
+
+//Span per call with node, method, attempts and payload sizes, child of span in context of call.
+//Span context is sent to node as traceparent gRPC metadata or HTTP header
+func TracingMiddleware(tracer *tracing.Tracer) Middleware {
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, call *Call) error {
+			ctx, span := tracer.Start(ctx, "dapi."+call.Method)
+
+			if span == nil {
+				return next(ctx, call)
+			}
+
+			defer span.End()
+
+			span.SetAttribute("dapi.transport", call.Transport)
+			span.SetAttribute("dapi.method", call.Method)
+			span.SetAttribute("dapi.node", call.Node)
+			span.SetAttribute("dapi.request_size", payloadSize(call.Request))
+
+			if call.Transport == TransportGRPC {
+				ctx = metadata.AppendToOutgoingContext(ctx, tracing.TraceparentKey, span.Context().Traceparent())
+			}
+
+			err := next(ctx, call)
+
+			if call.Attempt > 0 {
+				span.SetAttribute("dapi.attempts", call.Attempt)
+			} else {
+				span.SetAttribute("dapi.attempts", 1)
+			}
+
+			if err != nil {
+				span.SetError(err)
+			} else if !call.Stream {
+				span.SetAttribute("dapi.response_size", payloadSize(call.Response))
+			}
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package evo_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/co-in/dash-dapi/evo"
+	"github.com/co-in/dash-dapi/evo/dapitest"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/logging"
+	"github.com/co-in/dash-dapi/tracing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+//Invoker failing with errors in order, then succeeding. Attempts of calls are recorded
+type failingInvoker struct {
+	errors   []error
+	attempts []int
+}
+
+func (f *failingInvoker) invoke(ctx context.Context, call *evo.Call) error {
+	f.attempts = append(f.attempts, call.Attempt)
+
+	if len(f.attempts) <= len(f.errors) {
+		return f.errors[len(f.attempts)-1]
+	}
+
+	return nil
+}
+
+func TestRetryMiddleware(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "node is down")
+	invalid := status.Error(codes.InvalidArgument, "invalid height")
+
+	tests := []struct {
+		name     string
+		errors   []error
+		attempts int
+		code     codes.Code
+	}{
+		{name: "success", attempts: 1, code: codes.OK},
+		{name: "unavailable once", errors: []error{unavailable}, attempts: 2, code: codes.OK},
+		{name: "unavailable until exhausted", errors: []error{unavailable, unavailable, unavailable, unavailable}, attempts: 3, code: codes.Unavailable},
+		{name: "invalid argument", errors: []error{invalid}, attempts: 1, code: codes.InvalidArgument},
+		{name: "invalid argument after unavailable", errors: []error{unavailable, invalid}, attempts: 2, code: codes.InvalidArgument},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := &failingInvoker{errors: test.errors}
+			delay := 10 * time.Millisecond
+			start := time.Now()
+			err := evo.RetryMiddleware(3, delay, nil)(next.invoke)(context.Background(), &evo.Call{Method: "getStatus"})
+
+			if status.Code(err) != test.code {
+				t.Fatalf("error %v, expected %s", err, test.code)
+			}
+
+			if len(next.attempts) != test.attempts {
+				t.Fatalf("%d attempts, expected %d", len(next.attempts), test.attempts)
+			}
+
+			for i, attempt := range next.attempts {
+				if attempt != i+1 {
+					t.Fatalf("attempts %v", next.attempts)
+				}
+			}
+
+			//Backoff before every repeated attempt
+			if elapsed := time.Since(start); elapsed < time.Duration(test.attempts-1)*delay {
+				t.Fatalf("%d attempts in %s", test.attempts, elapsed)
+			}
+		})
+	}
+}
+
+//Retry stops waiting for next attempt when context of call is done
+func TestRetryMiddlewareCancel(t *testing.T) {
+	next := &failingInvoker{errors: []error{status.Error(codes.Unavailable, "node is down")}}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := evo.RetryMiddleware(3, time.Minute, nil)(next.invoke)(ctx, &evo.Call{})
+
+	if status.Code(err) != codes.Unavailable || len(next.attempts) != 1 || time.Since(start) > 5*time.Second {
+		t.Fatalf("error %v after %d attempts in %s", err, len(next.attempts), time.Since(start))
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	calls := 0
+	next := func(ctx context.Context, call *evo.Call) error {
+		calls++
+
+		return nil
+	}
+
+	//Burst passes without waiting, next call waits for token
+	limited := evo.RateLimitMiddleware(20, 2)(next)
+	start := time.Now()
+
+	for i := 0; i < 3; i++ {
+		err := limited(context.Background(), &evo.Call{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if i == 1 && time.Since(start) > 25*time.Millisecond {
+			t.Fatalf("burst waited %s", time.Since(start))
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond || calls != 3 {
+		t.Fatalf("%d calls in %s", calls, elapsed)
+	}
+
+	//Waiting call gives up with its context, next invoker is not called
+	limited = evo.RateLimitMiddleware(0.001, 1)(next)
+	calls = 0
+	err := limited(context.Background(), &evo.Call{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err = limited(ctx, &evo.Call{})
+
+	if err != context.DeadlineExceeded || calls != 1 {
+		t.Fatalf("error %v, %d calls", err, calls)
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		level    logging.Level
+		err      error
+		expected []string
+	}{
+		{name: "debug", level: logging.LevelDebug, expected: []string{"DEBUG Send node=node transport=grpc method=getStatus", "DEBUG Recv node=node transport=grpc method=getStatus", "connections"}},
+		{name: "debug failure", level: logging.LevelDebug, err: status.Error(codes.Unavailable, "node is down"), expected: []string{"DEBUG Send", "INFO Call failed node=node transport=grpc method=getStatus", "node is down"}},
+		{name: "info", level: logging.LevelInfo},
+		{name: "info failure", level: logging.LevelInfo, err: status.Error(codes.Unavailable, "node is down"), expected: []string{"INFO Call failed", "request_id=7"}},
+		{name: "warn failure", level: logging.LevelWarn, err: status.Error(codes.Unavailable, "node is down")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := logging.NewStdLogger(log.New(&buf, "", 0), test.level)
+			next := func(ctx context.Context, call *evo.Call) error {
+				call.Response = &proto.GetStatusResponse{Connections: 8}
+
+				return test.err
+			}
+
+			call := &evo.Call{Transport: evo.TransportGRPC, Method: "getStatus", Node: "node", Id: 7, Request: &proto.GetStatusRequest{}}
+			err := evo.LoggingMiddleware(logger)(next)(context.Background(), call)
+
+			if err != test.err {
+				t.Fatalf("error %v, expected %v", err, test.err)
+			}
+
+			output := buf.String()
+
+			if len(test.expected) == 0 && output != "" {
+				t.Fatalf("logged %q", output)
+			}
+
+			for _, expected := range test.expected {
+				if !strings.Contains(output, expected) {
+					t.Fatalf("output %q does not contain %q", output, expected)
+				}
+			}
+
+			if test.level > logging.LevelDebug && strings.Contains(output, "payload") {
+				t.Fatalf("payload logged at %s level: %q", test.level, output)
+			}
+		})
+	}
+}
+
+//First given middleware is outermost, retries of inner middleware are invisible to outer one
+func TestMiddlewareOrder(t *testing.T) {
+	s := newServer(t)
+	s.FailTimes(dapitest.MethodGetStatus, 2, status.Error(codes.Unavailable, "node is down"))
+
+	var mu sync.Mutex
+	var order []string
+
+	record := func(name string) evo.Middleware {
+		return func(next evo.Invoker) evo.Invoker {
+			return func(ctx context.Context, call *evo.Call) error {
+				mu.Lock()
+				order = append(order, name+" in")
+				mu.Unlock()
+
+				err := next(ctx, call)
+
+				mu.Lock()
+				order = append(order, name+" out")
+				mu.Unlock()
+
+				return err
+			}
+		}
+	}
+
+	node := newNode(t, s, evo.WithMiddleware(record("outer"), evo.RetryMiddleware(3, time.Millisecond, nil), record("inner")))
+	_, err := node.GetStatus()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"outer in", "inner in", "inner out", "inner in", "inner out", "inner in", "inner out", "outer out"}
+
+	if strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Fatalf("order %v, expected %v", order, expected)
+	}
+}
+
+//Span context of call reaches node as traceparent of gRPC metadata and of JSON-RPC HTTP header
+func TestTraceparent(t *testing.T) {
+	s := newServer(t)
+	var mu sync.Mutex
+	received := make(map[string]string)
+
+	capture := func(method string, response interface{}) {
+		s.Handle(method, func(ctx context.Context, request interface{}) (interface{}, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if values := md.Get(tracing.TraceparentKey); len(values) == 1 {
+				received[method] = values[0]
+			}
+
+			return response, nil
+		})
+	}
+
+	capture(dapitest.MethodGetStatus, &proto.GetStatusResponse{Connections: 8})
+	capture(dapitest.MethodGetBestBlockHash, dapitest.DefaultBestBlockHash)
+
+	recorder := tracing.NewRecorder()
+	node := newNode(t, s, evo.WithTracer(tracing.NewTracer(recorder)))
+	recorder.Reset()
+
+	//Caller did not sample its trace
+	remote, err := tracing.ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	traced := node.WithContext(tracing.WithRemoteParent(context.Background(), remote))
+
+	_, err = traced.GetStatus()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = traced.GetBestBlockHash()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Spans()
+
+	if len(spans) != 2 {
+		t.Fatalf("spans %+v", spans)
+	}
+
+	for i, method := range []string{dapitest.MethodGetStatus, dapitest.MethodGetBestBlockHash} {
+		span := spans[i]
+
+		if span.Name != "dapi."+method || span.Context.TraceID != remote.TraceID || span.Parent != remote.SpanID {
+			t.Fatalf("span %+v is not child of remote parent", span)
+		}
+
+		if received[method] != span.Context.Traceparent() || span.Context.Flags != 0 {
+			t.Fatalf("%s: node received traceparent %q, expected %q", method, received[method], span.Context.Traceparent())
+		}
+	}
+}
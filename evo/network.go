@@ -0,0 +1,87 @@
+package evo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/network"
+	"time"
+)
+
+var ErrNetworkMismatch = errors.New("node belongs to another network")
+
+//Limit of network check when client has no timeout, unresponsive node must not block selection
+var networkCheckTimeout = 10 * time.Second
+
+//Verify node once, mismatch is remembered, connection errors are retried on next selection
+func (c *connection) verifyNetwork() error {
+	if c.client.network == nil {
+		return nil
+	}
+
+	c.stats.Lock()
+	verified, err := c.stats.verified, c.stats.verifyErr
+	c.stats.Unlock()
+
+	if verified {
+		return err
+	}
+
+	timeout := c.timeout
+
+	if timeout <= 0 {
+		timeout = networkCheckTimeout
+	}
+
+	//Dial and requests of check have own deadline
+	ctx, cancel := context.WithTimeout(c.ctx, timeout)
+	err = c.WithContext(ctx).(*connection).checkNetwork(c.client.network)
+	cancel()
+
+	if err != nil && !errors.Is(err, ErrNetworkMismatch) {
+		return err
+	}
+
+	c.stats.Lock()
+	c.stats.verified = true
+	c.stats.verifyErr = err
+	c.stats.Unlock()
+
+	return err
+}
+
+func (c *connection) checkNetwork(params *network.Params) error {
+	status, err := c.GetStatus()
+
+	if err != nil {
+		return err
+	}
+
+	if !params.MatchStatusNetwork(status.Network) {
+		return fmt.Errorf("%w: %s reports %s, expected %s", ErrNetworkMismatch, c.name, status.Network, params.Name)
+	}
+
+	checkpoints := params.Checkpoints
+
+	if params.GenesisHash != "" {
+		checkpoints = append([]network.Checkpoint{{Height: 0, Hash: params.GenesisHash}}, checkpoints...)
+	}
+
+	for _, checkpoint := range checkpoints {
+		if checkpoint.Height > int(status.Blocks) {
+			continue
+		}
+
+		hash, err := c.GetBlockHash(checkpoint.Height)
+
+		if err != nil {
+			return err
+		}
+
+		if string(*hash) != checkpoint.Hash {
+			return fmt.Errorf("%w: %s has block %s at height %d, expected %s", ErrNetworkMismatch, c.name, *hash, checkpoint.Height, checkpoint.Hash)
+		}
+	}
+
+	return nil
+}
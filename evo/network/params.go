@@ -0,0 +1,152 @@
+package network
+
+import (
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"sort"
+)
+
+//LLMQ types (DIP6)
+const (
+	LLMQ50_60  = 1
+	LLMQ400_60 = 2
+	LLMQ400_85 = 3
+	LLMQTest   = 100
+	LLMQDevnet = 101
+)
+
+const (
+	MainNetName = "mainnet"
+	TestNetName = "testnet"
+	EvoNetName  = "evonet"
+	DevNetName  = "devnet"
+	RegTestName = "regtest"
+)
+
+type Checkpoint struct {
+	Height int
+	Hash   string
+}
+
+type Params struct {
+	Name        string
+	JsonRpcPort uint16
+	GRpcPort    uint16
+	SeedNodes   []string
+	//Values of GetStatusResponse.Network reported by nodes of this network
+	StatusNetworks []string
+	//Empty when genesis differs between deployments (devnets)
+	GenesisHash         string
+	Address             transaction.AddressParams
+	InstantSendLLMQType int
+	ChainLocksLLMQType  int
+	Checkpoints         []Checkpoint
+	//Empty when DPNS is not deployed or differs between deployments
+	DPNSContractID string
+}
+
+//Seeds are evonodes serving DAPI, P2P DNS seeds (dnsseed.dash.org) do not
+var MainNet = Params{
+	Name:                MainNetName,
+	JsonRpcPort:         3000,
+	GRpcPort:            3010,
+	SeedNodes:           []string{"seed-1.mainnet.networks.dash.org", "seed-2.mainnet.networks.dash.org", "seed-3.mainnet.networks.dash.org", "seed-4.mainnet.networks.dash.org"},
+	StatusNetworks:      []string{"livenet", "mainnet", "main"},
+	GenesisHash:         "00000ffd590b1485b3caadc19b22e6379c733355108f107a430458cdf3407ab6",
+	Address:             transaction.MainNetParams,
+	InstantSendLLMQType: LLMQ50_60,
+	ChainLocksLLMQType:  LLMQ400_60,
+	Checkpoints: []Checkpoint{
+		{Height: 1500, Hash: "000000aaf0300f59f49bc3e970bad15c11f961fe2347accffff19d96ec9778e3"},
+	},
+}
+
+var TestNet = Params{
+	Name:                TestNetName,
+	JsonRpcPort:         3000,
+	GRpcPort:            3010,
+	SeedNodes:           []string{"testnet-seed.dashdot.io"},
+	StatusNetworks:      []string{"testnet", "test"},
+	GenesisHash:         "00000bafbc94add76cb75e2ec92894837288a481e5c005f6563d91623bf8bc2c",
+	Address:             transaction.TestNetParams,
+	InstantSendLLMQType: LLMQ50_60,
+	ChainLocksLLMQType:  LLMQ50_60,
+}
+
+//Platform devnet. Genesis differs between deployments, so nodes reporting testnet are refused:
+//they can not be told from public testnet
+var EvoNet = Params{
+	Name:                EvoNetName,
+	JsonRpcPort:         3000,
+	GRpcPort:            3010,
+	SeedNodes:           []string{"evonet.thephez.com"},
+	StatusNetworks:      []string{"devnet", "evonet"},
+	Address:             transaction.TestNetParams,
+	InstantSendLLMQType: LLMQ50_60,
+	ChainLocksLLMQType:  LLMQ50_60,
+	DPNSContractID:      "77w8Xqn25HwJhjodrHW133aXhjuTsTv9ozQaYpSHACE3",
+}
+
+//Same as EvoNet, testnet nodes are refused
+var DevNet = Params{
+	Name:                DevNetName,
+	JsonRpcPort:         3000,
+	GRpcPort:            3010,
+	StatusNetworks:      []string{"devnet"},
+	Address:             transaction.TestNetParams,
+	InstantSendLLMQType: LLMQ50_60,
+	ChainLocksLLMQType:  LLMQ50_60,
+}
+
+var RegTest = Params{
+	Name:                RegTestName,
+	JsonRpcPort:         3000,
+	GRpcPort:            3010,
+	SeedNodes:           []string{"127.0.0.1"},
+	StatusNetworks:      []string{"regtest", "testnet"},
+	GenesisHash:         "000008ca1832a4baf228eb1553c03d3a2c8e02399550dd6ea8d65cec3ef23d2e",
+	Address:             transaction.TestNetParams,
+	InstantSendLLMQType: LLMQTest,
+	ChainLocksLLMQType:  LLMQTest,
+}
+
+var profiles = map[string]*Params{
+	MainNetName: &MainNet,
+	TestNetName: &TestNet,
+	EvoNetName:  &EvoNet,
+	DevNetName:  &DevNet,
+	RegTestName: &RegTest,
+}
+
+func Get(name string) (*Params, error) {
+	params, ok := profiles[name]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown network %s (available: %v)", name, Names())
+	}
+
+	return params, nil
+}
+
+func Names() []string {
+	names := make([]string, 0, len(profiles))
+
+	for name := range profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+//Check network reported by GetStatus
+func (p *Params) MatchStatusNetwork(network string) bool {
+	for _, v := range p.StatusNetworks {
+		if v == network {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,57 @@
+package evo
+
+import (
+	"github.com/co-in/dash-dapi/evo/network"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+//Node accepting connections without answering does not block selection of client without timeout
+func TestVerifyNetworkDeadline(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+
+			if err != nil {
+				return
+			}
+
+			defer conn.Close()
+		}
+	}()
+
+	_, value, _ := net.SplitHostPort(listener.Addr().String())
+	port, _ := strconv.Atoi(value)
+
+	defer func(timeout time.Duration) {
+		networkCheckTimeout = timeout
+	}(networkCheckTimeout)
+
+	networkCheckTimeout = 200 * time.Millisecond
+
+	c, err := NewClient(nil, "127.0.0.1", uint16(port), uint16(port), WithNetwork(&network.RegTest))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+
+	if _, err = c.SelectRandomNode(); err == nil {
+		t.Fatal("silent node passed network check")
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("network check took %s", elapsed)
+	}
+}
@@ -0,0 +1,115 @@
+package platform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/fxamacker/cbor/v2"
+	"strings"
+)
+
+//Size of protocol version prefix of serialized objects (newer Platform versions)
+const protocolVersionSize = 4
+
+//Decode CBOR serialized identity, contract or document into JSON compatible value.
+//Maps become map[string]interface{}, byte strings become []byte (base64 in JSON)
+func Decode(data []byte) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty payload")
+	}
+
+	var value interface{}
+	err := cbor.Unmarshal(data, &value)
+
+	if err != nil && len(data) > protocolVersionSize {
+		//Retry without protocol version prefix
+		if cbor.Unmarshal(data[protocolVersionSize:], &value) == nil {
+			err = nil
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return normalize(value), nil
+}
+
+//Decode each document of GetDocuments response
+func DecodeDocuments(documents [][]byte) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(documents))
+
+	for i, document := range documents {
+		value, err := Decode(document)
+
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %s", i, err)
+		}
+
+		result = append(result, value)
+	}
+
+	return result, nil
+}
+
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+
+		for key, item := range v {
+			m[fmt.Sprint(key)] = normalize(item)
+		}
+
+		return m
+	case []interface{}:
+		for i := range v {
+			v[i] = normalize(v[i])
+		}
+	}
+
+	return value
+}
+
+//Encode JSON array of where conditions or order-by rules for GetDocuments
+func QueryFromJSON(query string) ([]byte, error) {
+	decoder := json.NewDecoder(strings.NewReader(query))
+	decoder.UseNumber()
+
+	var value interface{}
+	err := decoder.Decode(&value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := value.([]interface{}); !ok {
+		return nil, errors.New("JSON array expected")
+	}
+
+	return cbor.Marshal(jsonNumbers(value))
+}
+
+//Integers are encoded as CBOR integers, not floats
+func jsonNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := v.Float64()
+
+		return f
+	case []interface{}:
+		for i := range v {
+			v[i] = jsonNumbers(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = jsonNumbers(v[k])
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,102 @@
+package platform
+
+import (
+	"errors"
+	"sort"
+)
+
+//Fields present in every document, may be used in queries
+var SystemFields = []string{"$id", "$ownerId"}
+
+//Decoded data contract
+type Contract struct {
+	ID string
+	//Document type schemas by type name
+	Documents map[string]map[string]interface{}
+	Value     map[string]interface{}
+}
+
+func ParseContract(data []byte) (*Contract, error) {
+	value, err := Decode(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := value.(map[string]interface{})
+
+	if !ok {
+		return nil, errors.New("data contract is not an object")
+	}
+
+	c := &Contract{
+		Documents: make(map[string]map[string]interface{}),
+		Value:     m,
+	}
+
+	if id, ok := m["$id"].(string); ok {
+		c.ID = id
+	}
+
+	documents, _ := m["documents"].(map[string]interface{})
+
+	for name, schema := range documents {
+		if s, ok := schema.(map[string]interface{}); ok {
+			c.Documents[name] = s
+		}
+	}
+
+	return c, nil
+}
+
+func (c *Contract) DocumentTypes() []string {
+	types := make([]string, 0, len(c.Documents))
+
+	for name := range c.Documents {
+		types = append(types, name)
+	}
+
+	sort.Strings(types)
+
+	return types
+}
+
+//Fields of document type indices, only they can be used in where and orderBy
+func (c *Contract) IndexedFields(documentType string) []string {
+	schema, ok := c.Documents[documentType]
+
+	if !ok {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var fields []string
+
+	indices, _ := schema["indices"].([]interface{})
+
+	for _, index := range indices {
+		i, _ := index.(map[string]interface{})
+		properties, _ := i["properties"].([]interface{})
+
+		for _, property := range properties {
+			p, _ := property.(map[string]interface{})
+
+			for field := range p {
+				if !seen[field] {
+					seen[field] = true
+					fields = append(fields, field)
+				}
+			}
+		}
+	}
+
+	sort.Strings(fields)
+
+	for _, field := range SystemFields {
+		if !seen[field] {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,1161 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: evo/protobuf/core.proto
+
+package org_dash_platform_dapi_v0
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStatusRequest) Reset()         { *m = GetStatusRequest{} }
+func (m *GetStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStatusRequest) ProtoMessage()    {}
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6021e5c62632e3a7, []int{0}
+}
+
+func (m *GetStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStatusRequest.Unmarshal(m, b)
+}
+func (m *GetStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatusRequest.Merge(m, src)
+}
+func (m *GetStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStatusRequest.Size(m)
+}
+func (m *GetStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStatusRequest proto.InternalMessageInfo
+
+type GetStatusResponse struct {
+	CoreVersion          uint32   `protobuf:"varint,1,opt,name=core_version,json=coreVersion,proto3" json:"core_version,omitempty"`
+	ProtocolVersion      uint32   `protobuf:"varint,2,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Blocks               uint32   `protobuf:"varint,3,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	TimeOffset           uint32   `protobuf:"varint,4,opt,name=time_offset,json=timeOffset,proto3" json:"time_offset,omitempty"`
+	Connections          uint32   `protobuf:"varint,5,opt,name=connections,proto3" json:"connections,omitempty"`
+	Proxy                string   `protobuf:"bytes,6,opt,name=proxy,proto3" json:"proxy,omitempty"`
+	Difficulty           float64  `protobuf:"fixed64,7,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Testnet              bool     `protobuf:"varint,8,opt,name=testnet,proto3" json:"testnet,omitempty"`
+	RelayFee             float64  `protobuf:"fixed64,9,opt,name=relay_fee,json=relayFee,proto3" json:"relay_fee,omitempty"`
+	Errors               string   `protobuf:"bytes,10,opt,name=errors,proto3" json:"errors,omitempty"`
+	Network              string   `protobuf:"bytes,11,opt,name=network,proto3" json:"network,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStatusResponse) Reset()         { *m = GetStatusResponse{} }
+func (m *GetStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStatusResponse) ProtoMessage()    {}
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6021e5c62632e3a7, []int{1}
+}
+
+func (m *GetStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStatusResponse.Unmarshal(m, b)
+}
+func (m *GetStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatusResponse.Merge(m, src)
+}
+func (m *GetStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStatusResponse.Size(m)
+}
+func (m *GetStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStatusResponse proto.InternalMessageInfo
+
+func (m *GetStatusResponse) GetCoreVersion() uint32 {
+	if m != nil {
+		return m.CoreVersion
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetBlocks() uint32 {
+	if m != nil {
+		return m.Blocks
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetTimeOffset() uint32 {
+	if m != nil {
+		return m.TimeOffset
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetConnections() uint32 {
+	if m != nil {
+		return m.Connections
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetProxy() string {
+	if m != nil {
+		return m.Proxy
+	}
+	return ""
+}
+
+func (m *GetStatusResponse) GetDifficulty() float64 {
+	if m != nil {
+		return m.Difficulty
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetTestnet() bool {
+	if m != nil {
+		return m.Testnet
+	}
+	return false
+}
+
+func (m *GetStatusResponse) GetRelayFee() float64 {
+	if m != nil {
+		return m.RelayFee
+	}
+	return 0
+}
+
+func (m *GetStatusResponse) GetErrors() string {
+	if m != nil {
+		return m.Errors
+	}
+	return ""
+}
+
+func (m *GetStatusResponse) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+type GetBlockRequest struct {
+	// Types that are valid to be assigned to Block:
+	//	*GetBlockRequest_Height
+	//	*GetBlockRequest_Hash
+	Block                isGetBlockRequest_Block `protobuf_oneof:"block"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetBlockRequest) Reset()         { *m = GetBlockRequest{} }
+func (m *GetBlockRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockRequest) ProtoMessage()    {}
+func (*GetBlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6021e5c62632e3a7, []int{2}
+}
+
+func (m *GetBlockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockRequest.Unmarshal(m, b)
+}
+func (m *GetBlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockRequest.Merge(m, src)
+}
+func (m *GetBlockRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockRequest.Size(m)
+}
+func (m *GetBlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockRequest proto.InternalMessageInfo
+
+type isGetBlockRequest_Block interface {
+	isGetBlockRequest_Block()
+}
+
+type GetBlockRequest_Height struct {
+	Height uint32 `protobuf:"varint,1,opt,name=height,proto3,oneof"`
+}
+
+type GetBlockRequest_Hash struct {
+	Hash string `protobuf:"bytes,2,opt,name=hash,proto3,oneof"`
+}
+
+func (*GetBlockRequest_Height) isGetBlockRequest_Block() {}
+
+func (*GetBlockRequest_Hash) isGetBlockRequest_Block() {}
+
+func (m *GetBlockRequest) GetBlock() isGetBlockRequest_Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *GetBlockRequest) GetHeight() uint32 {
+	if x, ok := m.GetBlock().(*GetBlockRequest_Height); ok {
+		return x.Height
+	}
+	return 0
+}
+
+func (m *GetBlockRequest) GetHash() string {
+	if x, ok := m.GetBlock().(*GetBlockRequest_Hash); ok {
+		return x.Hash
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*GetBlockRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*GetBlockRequest_Height)(nil),
+		(*GetBlockRequest_Hash)(nil),
+	}
+}
+
+type GetBlockResponse struct {
+	Block                []byte   `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockResponse) Reset()         { *m = GetBlockResponse{} }
+func (m *GetBlockResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockResponse) ProtoMessage()    {}
+func (*GetBlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6021e5c62632e3a7, []int{3}
+}
+
+func (m *GetBlockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockResponse.Unmarshal(m, b)
+}
+func (m *GetBlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockResponse.Merge(m, src)
+}
+func (m *GetBlockResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlockResponse.Size(m)
+}
+func (m *GetBlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockResponse proto.InternalMessageInfo
+
+func (m *GetBlockResponse) GetBlock() []byte {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+type SendTransactionRequest struct {
+	Transaction          []byte   `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	AllowHighFees        bool     `protobuf:"varint,2,opt,name=allow_high_fees,json=allowHighFees,proto3" json:"allow_high_fees,omitempty"`
+	BypassLimits         bool     `protobuf:"varint,3,opt,name=bypass_limits,json=bypassLimits,proto3" json:"bypass_limits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendTransactionRequest) Reset()         { *m = SendTransactionRequest{} }
+func (m *SendTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*SendTransactionRequest) ProtoMessage()    {}
+func (*SendTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6021e5c62632e3a7, []int{4}
+}
+
+func (m *SendTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendTransactionRequest.Unmarshal(m, b)
+}
+func (m *SendTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *SendTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendTransactionRequest.Merge(m, src)
+}
+func (m *SendTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_SendTransactionRequest.Size(m)
+}
+func (m *SendTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendTransactionRequest proto.InternalMessageInfo
+
+func (m *SendTransactionRequest) GetTransaction() []byte {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *SendTransactionRequest) GetAllowHighFees() bool {
+	if m != nil {
+		return m.AllowHighFees
+	}
+	return false
+}
+
+func (m *SendTransactionRequest) GetBypassLimits() bool {
+	if m != nil {
+		return m.BypassLimits
+	}
+	return false
+}
+
+type SendTransactionResponse struct {
+	TransactionId        string   `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendTransactionResponse) Reset()         { *m = SendTransactionResponse{} }
+func (m *SendTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*SendTransactionResponse) ProtoMessage()    {}
+func (*SendTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6021e5c62632e3a7, []int{5}
+}
+
+func (m *SendTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendTransactionResponse.Unmarshal(m, b)
+}
+func (m *SendTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *SendTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendTransactionResponse.Merge(m, src)
+}
+func (m *SendTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_SendTransactionResponse.Size(m)
+}
+func (m *SendTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendTransactionResponse proto.InternalMessageInfo
+
+func (m *SendTransactionResponse) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+type GetTransactionRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTransactionRequest) Reset()         { *m = GetTransactionRequest{} }
+func (m *GetTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionRequest) ProtoMessage()    {}
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6021e5c62632e3a7, []int{6}
+}
+
+func (m *GetTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionRequest.Unmarshal(m, b)
+}
+func (m *GetTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionRequest.Merge(m, src)
+}
+func (m *GetTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionRequest.Size(m)
+}
+func (m *GetTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionRequest proto.InternalMessageInfo
+
+func (m *GetTransactionRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetTransactionResponse struct {
+	Transaction          []byte   `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTransactionResponse) Reset()         { *m = GetTransactionResponse{} }
+func (m *GetTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionResponse) ProtoMessage()    {}
+func (*GetTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6021e5c62632e3a7, []int{7}
+}
+
+func (m *GetTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionResponse.Unmarshal(m, b)
+}
+func (m *GetTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionResponse.Merge(m, src)
+}
+func (m *GetTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionResponse.Size(m)
+}
+func (m *GetTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionResponse proto.InternalMessageInfo
+
+func (m *GetTransactionResponse) GetTransaction() []byte {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+type BlockHeadersWithChainLocksRequest struct {
+	// Types that are valid to be assigned to FromBlock:
+	//	*BlockHeadersWithChainLocksRequest_FromBlockHash
+	//	*BlockHeadersWithChainLocksRequest_FromBlockHeight
+	FromBlock            isBlockHeadersWithChainLocksRequest_FromBlock `protobuf_oneof:"from_block"`
+	Count                uint32                                        `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                      `json:"-"`
+	XXX_unrecognized     []byte                                        `json:"-"`
+	XXX_sizecache        int32                                         `json:"-"`
+}
+
+func (m *BlockHeadersWithChainLocksRequest) Reset()         { *m = BlockHeadersWithChainLocksRequest{} }
+func (m *BlockHeadersWithChainLocksRequest) String() string { return proto.CompactTextString(m) }
+func (*BlockHeadersWithChainLocksRequest) ProtoMessage()    {}
+func (*BlockHeadersWithChainLocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6021e5c62632e3a7, []int{8}
+}
+
+func (m *BlockHeadersWithChainLocksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeadersWithChainLocksRequest.Unmarshal(m, b)
+}
+func (m *BlockHeadersWithChainLocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeadersWithChainLocksRequest.Marshal(b, m, deterministic)
+}
+func (m *BlockHeadersWithChainLocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeadersWithChainLocksRequest.Merge(m, src)
+}
+func (m *BlockHeadersWithChainLocksRequest) XXX_Size() int {
+	return xxx_messageInfo_BlockHeadersWithChainLocksRequest.Size(m)
+}
+func (m *BlockHeadersWithChainLocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeadersWithChainLocksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeadersWithChainLocksRequest proto.InternalMessageInfo
+
+type isBlockHeadersWithChainLocksRequest_FromBlock interface {
+	isBlockHeadersWithChainLocksRequest_FromBlock()
+}
+
+type BlockHeadersWithChainLocksRequest_FromBlockHash struct {
+	FromBlockHash []byte `protobuf:"bytes,1,opt,name=from_block_hash,json=fromBlockHash,proto3,oneof"`
+}
+
+type BlockHeadersWithChainLocksRequest_FromBlockHeight struct {
+	FromBlockHeight uint32 `protobuf:"varint,2,opt,name=from_block_height,json=fromBlockHeight,proto3,oneof"`
+}
+
+func (*BlockHeadersWithChainLocksRequest_FromBlockHash) isBlockHeadersWithChainLocksRequest_FromBlock() {
+}
+
+func (*BlockHeadersWithChainLocksRequest_FromBlockHeight) isBlockHeadersWithChainLocksRequest_FromBlock() {
+}
+
+func (m *BlockHeadersWithChainLocksRequest) GetFromBlock() isBlockHeadersWithChainLocksRequest_FromBlock {
+	if m != nil {
+		return m.FromBlock
+	}
+	return nil
+}
+
+func (m *BlockHeadersWithChainLocksRequest) GetFromBlockHash() []byte {
+	if x, ok := m.GetFromBlock().(*BlockHeadersWithChainLocksRequest_FromBlockHash); ok {
+		return x.FromBlockHash
+	}
+	return nil
+}
+
+func (m *BlockHeadersWithChainLocksRequest) GetFromBlockHeight() uint32 {
+	if x, ok := m.GetFromBlock().(*BlockHeadersWithChainLocksRequest_FromBlockHeight); ok {
+		return x.FromBlockHeight
+	}
+	return 0
+}
+
+func (m *BlockHeadersWithChainLocksRequest) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*BlockHeadersWithChainLocksRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*BlockHeadersWithChainLocksRequest_FromBlockHash)(nil),
+		(*BlockHeadersWithChainLocksRequest_FromBlockHeight)(nil),
+	}
+}
+
+type BlockHeadersWithChainLocksResponse struct {
+	// Types that are valid to be assigned to Responses:
+	//	*BlockHeadersWithChainLocksResponse_BlockHeaders
+	//	*BlockHeadersWithChainLocksResponse_ChainLockSignatureMessages
+	Responses            isBlockHeadersWithChainLocksResponse_Responses `protobuf_oneof:"responses"`
+	XXX_NoUnkeyedLiteral struct{}                                       `json:"-"`
+	XXX_unrecognized     []byte                                         `json:"-"`
+	XXX_sizecache        int32                                          `json:"-"`
+}
+
+func (m *BlockHeadersWithChainLocksResponse) Reset()         { *m = BlockHeadersWithChainLocksResponse{} }
+func (m *BlockHeadersWithChainLocksResponse) String() string { return proto.CompactTextString(m) }
+func (*BlockHeadersWithChainLocksResponse) ProtoMessage()    {}
+func (*BlockHeadersWithChainLocksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6021e5c62632e3a7, []int{9}
+}
+
+func (m *BlockHeadersWithChainLocksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeadersWithChainLocksResponse.Unmarshal(m, b)
+}
+func (m *BlockHeadersWithChainLocksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeadersWithChainLocksResponse.Marshal(b, m, deterministic)
+}
+func (m *BlockHeadersWithChainLocksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeadersWithChainLocksResponse.Merge(m, src)
+}
+func (m *BlockHeadersWithChainLocksResponse) XXX_Size() int {
+	return xxx_messageInfo_BlockHeadersWithChainLocksResponse.Size(m)
+}
+func (m *BlockHeadersWithChainLocksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeadersWithChainLocksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeadersWithChainLocksResponse proto.InternalMessageInfo
+
+type isBlockHeadersWithChainLocksResponse_Responses interface {
+	isBlockHeadersWithChainLocksResponse_Responses()
+}
+
+type BlockHeadersWithChainLocksResponse_BlockHeaders struct {
+	BlockHeaders *BlockHeaders `protobuf:"bytes,1,opt,name=block_headers,json=blockHeaders,proto3,oneof"`
+}
+
+type BlockHeadersWithChainLocksResponse_ChainLockSignatureMessages struct {
+	ChainLockSignatureMessages *ChainLockSignatureMessages `protobuf:"bytes,2,opt,name=chain_lock_signature_messages,json=chainLockSignatureMessages,proto3,oneof"`
+}
+
+func (*BlockHeadersWithChainLocksResponse_BlockHeaders) isBlockHeadersWithChainLocksResponse_Responses() {
+}
+
+func (*BlockHeadersWithChainLocksResponse_ChainLockSignatureMessages) isBlockHeadersWithChainLocksResponse_Responses() {
+}
+
+func (m *BlockHeadersWithChainLocksResponse) GetResponses() isBlockHeadersWithChainLocksResponse_Responses {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+func (m *BlockHeadersWithChainLocksResponse) GetBlockHeaders() *BlockHeaders {
+	if x, ok := m.GetResponses().(*BlockHeadersWithChainLocksResponse_BlockHeaders); ok {
+		return x.BlockHeaders
+	}
+	return nil
+}
+
+func (m *BlockHeadersWithChainLocksResponse) GetChainLockSignatureMessages() *ChainLockSignatureMessages {
+	if x, ok := m.GetResponses().(*BlockHeadersWithChainLocksResponse_ChainLockSignatureMessages); ok {
+		return x.ChainLockSignatureMessages
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*BlockHeadersWithChainLocksResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*BlockHeadersWithChainLocksResponse_BlockHeaders)(nil),
+		(*BlockHeadersWithChainLocksResponse_ChainLockSignatureMessages)(nil),
+	}
+}
+
+type BlockHeaders struct {
+	Headers              [][]byte `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockHeaders) Reset()         { *m = BlockHeaders{} }
+func (m *BlockHeaders) String() string { return proto.CompactTextString(m) }
+func (*BlockHeaders) ProtoMessage()    {}
+func (*BlockHeaders) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6021e5c62632e3a7, []int{10}
+}
+
+func (m *BlockHeaders) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeaders.Unmarshal(m, b)
+}
+func (m *BlockHeaders) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeaders.Marshal(b, m, deterministic)
+}
+func (m *BlockHeaders) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeaders.Merge(m, src)
+}
+func (m *BlockHeaders) XXX_Size() int {
+	return xxx_messageInfo_BlockHeaders.Size(m)
+}
+func (m *BlockHeaders) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeaders.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeaders proto.InternalMessageInfo
+
+func (m *BlockHeaders) GetHeaders() [][]byte {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+type ChainLockSignatureMessages struct {
+	Messages             [][]byte `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChainLockSignatureMessages) Reset()         { *m = ChainLockSignatureMessages{} }
+func (m *ChainLockSignatureMessages) String() string { return proto.CompactTextString(m) }
+func (*ChainLockSignatureMessages) ProtoMessage()    {}
+func (*ChainLockSignatureMessages) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6021e5c62632e3a7, []int{11}
+}
+
+func (m *ChainLockSignatureMessages) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChainLockSignatureMessages.Unmarshal(m, b)
+}
+func (m *ChainLockSignatureMessages) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChainLockSignatureMessages.Marshal(b, m, deterministic)
+}
+func (m *ChainLockSignatureMessages) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainLockSignatureMessages.Merge(m, src)
+}
+func (m *ChainLockSignatureMessages) XXX_Size() int {
+	return xxx_messageInfo_ChainLockSignatureMessages.Size(m)
+}
+func (m *ChainLockSignatureMessages) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainLockSignatureMessages.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainLockSignatureMessages proto.InternalMessageInfo
+
+func (m *ChainLockSignatureMessages) GetMessages() [][]byte {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+type GetEstimatedTransactionFeeRequest struct {
+	Blocks               uint32   `protobuf:"varint,1,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEstimatedTransactionFeeRequest) Reset()         { *m = GetEstimatedTransactionFeeRequest{} }
+func (m *GetEstimatedTransactionFeeRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEstimatedTransactionFeeRequest) ProtoMessage()    {}
+func (*GetEstimatedTransactionFeeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6021e5c62632e3a7, []int{12}
+}
+
+func (m *GetEstimatedTransactionFeeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEstimatedTransactionFeeRequest.Unmarshal(m, b)
+}
+func (m *GetEstimatedTransactionFeeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEstimatedTransactionFeeRequest.Marshal(b, m, deterministic)
+}
+func (m *GetEstimatedTransactionFeeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEstimatedTransactionFeeRequest.Merge(m, src)
+}
+func (m *GetEstimatedTransactionFeeRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEstimatedTransactionFeeRequest.Size(m)
+}
+func (m *GetEstimatedTransactionFeeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEstimatedTransactionFeeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEstimatedTransactionFeeRequest proto.InternalMessageInfo
+
+func (m *GetEstimatedTransactionFeeRequest) GetBlocks() uint32 {
+	if m != nil {
+		return m.Blocks
+	}
+	return 0
+}
+
+type GetEstimatedTransactionFeeResponse struct {
+	Fee                  float64  `protobuf:"fixed64,1,opt,name=fee,proto3" json:"fee,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEstimatedTransactionFeeResponse) Reset()         { *m = GetEstimatedTransactionFeeResponse{} }
+func (m *GetEstimatedTransactionFeeResponse) String() string { return proto.CompactTextString(m) }
+func (*GetEstimatedTransactionFeeResponse) ProtoMessage()    {}
+func (*GetEstimatedTransactionFeeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6021e5c62632e3a7, []int{13}
+}
+
+func (m *GetEstimatedTransactionFeeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEstimatedTransactionFeeResponse.Unmarshal(m, b)
+}
+func (m *GetEstimatedTransactionFeeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEstimatedTransactionFeeResponse.Marshal(b, m, deterministic)
+}
+func (m *GetEstimatedTransactionFeeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEstimatedTransactionFeeResponse.Merge(m, src)
+}
+func (m *GetEstimatedTransactionFeeResponse) XXX_Size() int {
+	return xxx_messageInfo_GetEstimatedTransactionFeeResponse.Size(m)
+}
+func (m *GetEstimatedTransactionFeeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEstimatedTransactionFeeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEstimatedTransactionFeeResponse proto.InternalMessageInfo
+
+func (m *GetEstimatedTransactionFeeResponse) GetFee() float64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*GetStatusRequest)(nil), "org.dash.platform.dapi.v0.GetStatusRequest")
+	proto.RegisterType((*GetStatusResponse)(nil), "org.dash.platform.dapi.v0.GetStatusResponse")
+	proto.RegisterType((*GetBlockRequest)(nil), "org.dash.platform.dapi.v0.GetBlockRequest")
+	proto.RegisterType((*GetBlockResponse)(nil), "org.dash.platform.dapi.v0.GetBlockResponse")
+	proto.RegisterType((*SendTransactionRequest)(nil), "org.dash.platform.dapi.v0.SendTransactionRequest")
+	proto.RegisterType((*SendTransactionResponse)(nil), "org.dash.platform.dapi.v0.SendTransactionResponse")
+	proto.RegisterType((*GetTransactionRequest)(nil), "org.dash.platform.dapi.v0.GetTransactionRequest")
+	proto.RegisterType((*GetTransactionResponse)(nil), "org.dash.platform.dapi.v0.GetTransactionResponse")
+	proto.RegisterType((*BlockHeadersWithChainLocksRequest)(nil), "org.dash.platform.dapi.v0.BlockHeadersWithChainLocksRequest")
+	proto.RegisterType((*BlockHeadersWithChainLocksResponse)(nil), "org.dash.platform.dapi.v0.BlockHeadersWithChainLocksResponse")
+	proto.RegisterType((*BlockHeaders)(nil), "org.dash.platform.dapi.v0.BlockHeaders")
+	proto.RegisterType((*ChainLockSignatureMessages)(nil), "org.dash.platform.dapi.v0.ChainLockSignatureMessages")
+	proto.RegisterType((*GetEstimatedTransactionFeeRequest)(nil), "org.dash.platform.dapi.v0.GetEstimatedTransactionFeeRequest")
+	proto.RegisterType((*GetEstimatedTransactionFeeResponse)(nil), "org.dash.platform.dapi.v0.GetEstimatedTransactionFeeResponse")
+}
+
+func init() {
+	proto.RegisterFile("evo/protobuf/core.proto", fileDescriptor_6021e5c62632e3a7)
+}
+
+var fileDescriptor_6021e5c62632e3a7 = []byte{
+	// 855 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x6d, 0x8f, 0xdb, 0x44,
+	0x10, 0x8e, 0x73, 0x77, 0xb9, 0x64, 0x92, 0x5c, 0xae, 0xab, 0xe3, 0x6a, 0x8c, 0x80, 0x9c, 0x51,
+	0x69, 0xa0, 0x55, 0x7a, 0x3d, 0x04, 0x42, 0x40, 0x25, 0x74, 0x15, 0x77, 0x01, 0x15, 0x90, 0x7c,
+	0x15, 0x7c, 0xb4, 0x36, 0xf6, 0xd8, 0x5e, 0xd5, 0xf1, 0x86, 0xdd, 0xf5, 0xb5, 0xc7, 0x1f, 0x40,
+	0xfc, 0x04, 0x3e, 0xf1, 0x8d, 0x3f, 0xc5, 0x6f, 0x41, 0x42, 0x5e, 0xaf, 0x13, 0xb7, 0xbd, 0xb8,
+	0x2f, 0xdf, 0x32, 0xcf, 0xce, 0xcc, 0xf3, 0xec, 0xcc, 0xfa, 0x51, 0xe0, 0x26, 0x5e, 0xf2, 0x7b,
+	0x4b, 0xc1, 0x15, 0x9f, 0xe7, 0xd1, 0xbd, 0x80, 0x0b, 0x9c, 0xea, 0x88, 0xbc, 0xcb, 0x45, 0x3c,
+	0x0d, 0xa9, 0x4c, 0xa6, 0xcb, 0x94, 0xaa, 0x88, 0x8b, 0xc5, 0x34, 0xa4, 0x4b, 0x36, 0xbd, 0x3c,
+	0x76, 0x09, 0xec, 0x9f, 0xa3, 0xba, 0x50, 0x54, 0xe5, 0xd2, 0xc3, 0xdf, 0x72, 0x94, 0xca, 0xfd,
+	0xb7, 0x0d, 0x37, 0x6a, 0xa0, 0x5c, 0xf2, 0x4c, 0x22, 0x39, 0x82, 0x41, 0xd1, 0xd2, 0xbf, 0x44,
+	0x21, 0x19, 0xcf, 0x6c, 0x6b, 0x6c, 0x4d, 0x86, 0x5e, 0xbf, 0xc0, 0x7e, 0x29, 0x21, 0xf2, 0x09,
+	0xec, 0x6b, 0xc2, 0x80, 0xa7, 0xab, 0xb4, 0xb6, 0x4e, 0x1b, 0x55, 0x78, 0x95, 0x7a, 0x08, 0x9d,
+	0x79, 0xca, 0x83, 0x27, 0xd2, 0xde, 0xd2, 0x09, 0x26, 0x22, 0x1f, 0x42, 0x5f, 0xb1, 0x05, 0xfa,
+	0x3c, 0x8a, 0x24, 0x2a, 0x7b, 0x5b, 0x1f, 0x42, 0x01, 0xfd, 0xac, 0x11, 0x32, 0x86, 0x7e, 0xc0,
+	0xb3, 0x0c, 0x03, 0xc5, 0x78, 0x26, 0xed, 0x9d, 0x4a, 0xc5, 0x0a, 0x22, 0x07, 0xb0, 0xb3, 0x14,
+	0xfc, 0xd9, 0x95, 0xdd, 0x19, 0x5b, 0x93, 0x9e, 0x57, 0x06, 0xe4, 0x03, 0x80, 0x90, 0x45, 0x11,
+	0x0b, 0xf2, 0x54, 0x5d, 0xd9, 0xbb, 0x63, 0x6b, 0x62, 0x79, 0x35, 0x84, 0xd8, 0xb0, 0xab, 0x50,
+	0xaa, 0x0c, 0x95, 0xdd, 0x1d, 0x5b, 0x93, 0xae, 0x57, 0x85, 0xe4, 0x3d, 0xe8, 0x09, 0x4c, 0xe9,
+	0x95, 0x1f, 0x21, 0xda, 0x3d, 0x5d, 0xd8, 0xd5, 0xc0, 0x19, 0x62, 0x71, 0x0f, 0x14, 0x82, 0x0b,
+	0x69, 0x83, 0x66, 0x33, 0x51, 0xd1, 0x2e, 0x43, 0xf5, 0x94, 0x8b, 0x27, 0x76, 0x5f, 0x1f, 0x54,
+	0xa1, 0xfb, 0x03, 0x8c, 0xce, 0x51, 0x9d, 0x16, 0xd7, 0x35, 0x03, 0x27, 0x36, 0x74, 0x12, 0x64,
+	0x71, 0xa2, 0xca, 0xa1, 0xce, 0x5a, 0x9e, 0x89, 0xc9, 0x01, 0x6c, 0x27, 0x54, 0x26, 0x7a, 0x8a,
+	0xbd, 0x59, 0xcb, 0xd3, 0xd1, 0xe9, 0x2e, 0xec, 0xe8, 0x71, 0xb9, 0x13, 0xd8, 0x5f, 0xf7, 0x32,
+	0x7b, 0x3a, 0x30, 0x87, 0xba, 0xd7, 0xc0, 0x33, 0x99, 0x7f, 0x58, 0x70, 0x78, 0x81, 0x59, 0xf8,
+	0x58, 0xd0, 0x4c, 0x52, 0x3d, 0xa9, 0x8a, 0x7d, 0x0c, 0x7d, 0xb5, 0x46, 0x4d, 0x59, 0x1d, 0x22,
+	0x1f, 0xc3, 0x88, 0xa6, 0x29, 0x7f, 0xea, 0x27, 0x2c, 0x4e, 0x8a, 0x31, 0x48, 0x2d, 0xa8, 0xeb,
+	0x0d, 0x35, 0x3c, 0x63, 0x71, 0x72, 0x86, 0x28, 0xc9, 0x47, 0x30, 0x9c, 0x5f, 0x2d, 0xa9, 0x94,
+	0x7e, 0xca, 0x16, 0x4c, 0x95, 0xbb, 0xed, 0x7a, 0x83, 0x12, 0x7c, 0xa4, 0x31, 0xf7, 0x5b, 0xb8,
+	0xf9, 0x92, 0x10, 0x23, 0xfd, 0x16, 0xec, 0xd5, 0x68, 0x7d, 0x16, 0x6a, 0x31, 0x3d, 0x6f, 0x58,
+	0x43, 0xbf, 0x0f, 0xdd, 0xdb, 0xf0, 0xce, 0x39, 0xaa, 0x6b, 0x6e, 0xb2, 0x07, 0xed, 0x55, 0x4d,
+	0x9b, 0x85, 0xee, 0x57, 0x70, 0xf8, 0x62, 0xa2, 0x61, 0x7a, 0xe5, 0x9d, 0xdd, 0xbf, 0x2d, 0x38,
+	0xd2, 0x83, 0x9d, 0x21, 0x0d, 0x51, 0xc8, 0x5f, 0x99, 0x4a, 0x1e, 0x26, 0x94, 0x65, 0x8f, 0x8a,
+	0x77, 0x5a, 0x31, 0x4e, 0x60, 0x14, 0x09, 0xbe, 0xf0, 0xf5, 0x90, 0x7d, 0xbd, 0x2a, 0xdd, 0x6b,
+	0xd6, 0xf2, 0x86, 0xc5, 0x41, 0xd9, 0x81, 0xca, 0x84, 0xdc, 0x85, 0x1b, 0xf5, 0xcc, 0x72, 0xdd,
+	0x6d, 0xb3, 0xee, 0xd1, 0x3a, 0xb7, 0xda, 0xfb, 0x4e, 0xc0, 0xf3, 0x4c, 0x99, 0xaf, 0xa3, 0x0c,
+	0x4e, 0x07, 0x00, 0xeb, 0x1e, 0xee, 0x7f, 0x16, 0xb8, 0x4d, 0x0a, 0xcd, 0x55, 0x7f, 0x82, 0x61,
+	0xc5, 0xa9, 0xd3, 0xb4, 0xc0, 0xfe, 0xc9, 0xed, 0xe9, 0x46, 0x53, 0x98, 0xd6, 0xbb, 0xce, 0x5a,
+	0xde, 0x60, 0x5e, 0x8b, 0xc9, 0xef, 0xf0, 0x7e, 0x50, 0xb0, 0xf8, 0xba, 0xa9, 0x64, 0x71, 0x46,
+	0x55, 0x2e, 0xd0, 0x5f, 0xa0, 0x94, 0x34, 0x36, 0x4f, 0xa3, 0x7f, 0xf2, 0x79, 0x43, 0xff, 0x95,
+	0xca, 0x8b, 0xaa, 0xfa, 0x47, 0x53, 0x3c, 0x6b, 0x79, 0x4e, 0xb0, 0xf1, 0xf4, 0xb4, 0x5f, 0x7c,
+	0x8a, 0xe5, 0xbd, 0xa4, 0x3b, 0x81, 0x41, 0x5d, 0x68, 0xf1, 0xc9, 0xad, 0xaf, 0xb8, 0x35, 0x19,
+	0x78, 0x55, 0xe8, 0x7e, 0x09, 0xce, 0x66, 0x4a, 0xe2, 0x40, 0x77, 0xa5, 0xbd, 0x2c, 0x5c, 0xc5,
+	0xee, 0xd7, 0x70, 0x74, 0x8e, 0xea, 0x3b, 0xa9, 0xd8, 0x82, 0x2a, 0xac, 0x3f, 0xda, 0x33, 0xc4,
+	0xea, 0x11, 0xac, 0xbd, 0xcc, 0xaa, 0x7b, 0x99, 0xfb, 0x05, 0xb8, 0x4d, 0xc5, 0x66, 0x3f, 0xfb,
+	0xb0, 0x55, 0x18, 0x8b, 0xa5, 0x8d, 0xa5, 0xf8, 0x79, 0xf2, 0x67, 0x07, 0xb6, 0x1f, 0x72, 0x81,
+	0x24, 0x82, 0x5e, 0x5c, 0xf9, 0x30, 0xb9, 0xd3, 0x30, 0xd0, 0x17, 0x2d, 0xdc, 0xb9, 0xfb, 0x7a,
+	0xc9, 0x46, 0x42, 0x00, 0xdd, 0xd8, 0xd8, 0x08, 0xf9, 0xb4, 0xb9, 0xb2, 0xee, 0x5b, 0xce, 0x9d,
+	0xd7, 0xca, 0x35, 0x24, 0xcf, 0x60, 0x24, 0x9f, 0xff, 0xee, 0xc9, 0xfd, 0x86, 0xfa, 0xeb, 0xcd,
+	0xca, 0x39, 0x79, 0x93, 0x12, 0xc3, 0x9c, 0xc3, 0x5e, 0xfc, 0x9c, 0x0d, 0x90, 0xe3, 0x66, 0xe1,
+	0xd7, 0xf0, 0xde, 0x7f, 0x83, 0x0a, 0x43, 0xfb, 0x97, 0x05, 0x4e, 0xbc, 0x71, 0xff, 0xe4, 0x9b,
+	0xe6, 0x8e, 0xcd, 0x6f, 0xce, 0x79, 0xf0, 0x96, 0xd5, 0x46, 0xdb, 0x3f, 0x16, 0xdc, 0x92, 0xf9,
+	0x5c, 0x06, 0x82, 0xcd, 0xf1, 0x31, 0xdf, 0x6c, 0x23, 0x8d, 0x32, 0x5f, 0xe9, 0x8f, 0xce, 0x83,
+	0xb7, 0xac, 0x2e, 0x65, 0x1e, 0x5b, 0xf3, 0x8e, 0xfe, 0xe3, 0xf0, 0xd9, 0xff, 0x03, 0x00, 0x34,
+	0x18, 0xa9, 0x27, 0xdc, 0x08, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// CoreClient is the client API for Core service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CoreClient interface {
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error)
+	SendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
+	GetEstimatedTransactionFee(ctx context.Context, in *GetEstimatedTransactionFeeRequest, opts ...grpc.CallOption) (*GetEstimatedTransactionFeeResponse, error)
+	SubscribeToBlockHeadersWithChainLocks(ctx context.Context, in *BlockHeadersWithChainLocksRequest, opts ...grpc.CallOption) (Core_SubscribeToBlockHeadersWithChainLocksClient, error)
+}
+
+type coreClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCoreClient(cc grpc.ClientConnInterface) CoreClient {
+	return &coreClient{cc}
+}
+
+func (c *coreClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, "/org.dash.platform.dapi.v0.Core/getStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error) {
+	out := new(GetBlockResponse)
+	err := c.cc.Invoke(ctx, "/org.dash.platform.dapi.v0.Core/getBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreClient) SendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
+	out := new(SendTransactionResponse)
+	err := c.cc.Invoke(ctx, "/org.dash.platform.dapi.v0.Core/sendTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error) {
+	out := new(GetTransactionResponse)
+	err := c.cc.Invoke(ctx, "/org.dash.platform.dapi.v0.Core/getTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreClient) GetEstimatedTransactionFee(ctx context.Context, in *GetEstimatedTransactionFeeRequest, opts ...grpc.CallOption) (*GetEstimatedTransactionFeeResponse, error) {
+	out := new(GetEstimatedTransactionFeeResponse)
+	err := c.cc.Invoke(ctx, "/org.dash.platform.dapi.v0.Core/getEstimatedTransactionFee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coreClient) SubscribeToBlockHeadersWithChainLocks(ctx context.Context, in *BlockHeadersWithChainLocksRequest, opts ...grpc.CallOption) (Core_SubscribeToBlockHeadersWithChainLocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Core_serviceDesc.Streams[0], "/org.dash.platform.dapi.v0.Core/subscribeToBlockHeadersWithChainLocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &coreSubscribeToBlockHeadersWithChainLocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Core_SubscribeToBlockHeadersWithChainLocksClient interface {
+	Recv() (*BlockHeadersWithChainLocksResponse, error)
+	grpc.ClientStream
+}
+
+type coreSubscribeToBlockHeadersWithChainLocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *coreSubscribeToBlockHeadersWithChainLocksClient) Recv() (*BlockHeadersWithChainLocksResponse, error) {
+	m := new(BlockHeadersWithChainLocksResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CoreServer is the server API for Core service.
+type CoreServer interface {
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
+	GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error)
+	SendTransaction(context.Context, *SendTransactionRequest) (*SendTransactionResponse, error)
+	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
+	GetEstimatedTransactionFee(context.Context, *GetEstimatedTransactionFeeRequest) (*GetEstimatedTransactionFeeResponse, error)
+	SubscribeToBlockHeadersWithChainLocks(*BlockHeadersWithChainLocksRequest, Core_SubscribeToBlockHeadersWithChainLocksServer) error
+}
+
+// UnimplementedCoreServer can be embedded to have forward compatible implementations.
+type UnimplementedCoreServer struct {
+}
+
+func (*UnimplementedCoreServer) GetStatus(ctx context.Context, req *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (*UnimplementedCoreServer) GetBlock(ctx context.Context, req *GetBlockRequest) (*GetBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (*UnimplementedCoreServer) SendTransaction(ctx context.Context, req *SendTransactionRequest) (*SendTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendTransaction not implemented")
+}
+func (*UnimplementedCoreServer) GetTransaction(ctx context.Context, req *GetTransactionRequest) (*GetTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (*UnimplementedCoreServer) GetEstimatedTransactionFee(ctx context.Context, req *GetEstimatedTransactionFeeRequest) (*GetEstimatedTransactionFeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEstimatedTransactionFee not implemented")
+}
+func (*UnimplementedCoreServer) SubscribeToBlockHeadersWithChainLocks(req *BlockHeadersWithChainLocksRequest, srv Core_SubscribeToBlockHeadersWithChainLocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeToBlockHeadersWithChainLocks not implemented")
+}
+
+func RegisterCoreServer(s *grpc.Server, srv CoreServer) {
+	s.RegisterService(&_Core_serviceDesc, srv)
+}
+
+func _Core_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.dash.platform.dapi.v0.Core/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Core_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.dash.platform.dapi.v0.Core/GetBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Core_SendTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreServer).SendTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.dash.platform.dapi.v0.Core/SendTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreServer).SendTransaction(ctx, req.(*SendTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Core_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.dash.platform.dapi.v0.Core/GetTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Core_GetEstimatedTransactionFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEstimatedTransactionFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoreServer).GetEstimatedTransactionFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.dash.platform.dapi.v0.Core/GetEstimatedTransactionFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoreServer).GetEstimatedTransactionFee(ctx, req.(*GetEstimatedTransactionFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Core_SubscribeToBlockHeadersWithChainLocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BlockHeadersWithChainLocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CoreServer).SubscribeToBlockHeadersWithChainLocks(m, &coreSubscribeToBlockHeadersWithChainLocksServer{stream})
+}
+
+type Core_SubscribeToBlockHeadersWithChainLocksServer interface {
+	Send(*BlockHeadersWithChainLocksResponse) error
+	grpc.ServerStream
+}
+
+type coreSubscribeToBlockHeadersWithChainLocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *coreSubscribeToBlockHeadersWithChainLocksServer) Send(m *BlockHeadersWithChainLocksResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Core_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "org.dash.platform.dapi.v0.Core",
+	HandlerType: (*CoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "getStatus",
+			Handler:    _Core_GetStatus_Handler,
+		},
+		{
+			MethodName: "getBlock",
+			Handler:    _Core_GetBlock_Handler,
+		},
+		{
+			MethodName: "sendTransaction",
+			Handler:    _Core_SendTransaction_Handler,
+		},
+		{
+			MethodName: "getTransaction",
+			Handler:    _Core_GetTransaction_Handler,
+		},
+		{
+			MethodName: "getEstimatedTransactionFee",
+			Handler:    _Core_GetEstimatedTransactionFee_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "subscribeToBlockHeadersWithChainLocks",
+			Handler:       _Core_SubscribeToBlockHeadersWithChainLocks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "evo/protobuf/core.proto",
+}
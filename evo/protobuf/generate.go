@@ -0,0 +1,4 @@
+package org_dash_platform_dapi_v0
+
+//Generated code is committed, regenerate it after .proto files change: go generate ./evo/protobuf
+//go:generate sh -c "cd ../.. && protoc --go_out=plugins=grpc:. evo/protobuf/*.proto"
@@ -0,0 +1,646 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: evo/protobuf/platform.proto
+
+package org_dash_platform_dapi_v0
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ApplyStateTransitionRequest struct {
+	StateTransition      []byte   `protobuf:"bytes,1,opt,name=state_transition,json=stateTransition,proto3" json:"state_transition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplyStateTransitionRequest) Reset()         { *m = ApplyStateTransitionRequest{} }
+func (m *ApplyStateTransitionRequest) String() string { return proto.CompactTextString(m) }
+func (*ApplyStateTransitionRequest) ProtoMessage()    {}
+func (*ApplyStateTransitionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ebd04832f1cf8ba, []int{0}
+}
+
+func (m *ApplyStateTransitionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyStateTransitionRequest.Unmarshal(m, b)
+}
+func (m *ApplyStateTransitionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyStateTransitionRequest.Marshal(b, m, deterministic)
+}
+func (m *ApplyStateTransitionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyStateTransitionRequest.Merge(m, src)
+}
+func (m *ApplyStateTransitionRequest) XXX_Size() int {
+	return xxx_messageInfo_ApplyStateTransitionRequest.Size(m)
+}
+func (m *ApplyStateTransitionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyStateTransitionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyStateTransitionRequest proto.InternalMessageInfo
+
+func (m *ApplyStateTransitionRequest) GetStateTransition() []byte {
+	if m != nil {
+		return m.StateTransition
+	}
+	return nil
+}
+
+type ApplyStateTransitionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplyStateTransitionResponse) Reset()         { *m = ApplyStateTransitionResponse{} }
+func (m *ApplyStateTransitionResponse) String() string { return proto.CompactTextString(m) }
+func (*ApplyStateTransitionResponse) ProtoMessage()    {}
+func (*ApplyStateTransitionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ebd04832f1cf8ba, []int{1}
+}
+
+func (m *ApplyStateTransitionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyStateTransitionResponse.Unmarshal(m, b)
+}
+func (m *ApplyStateTransitionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyStateTransitionResponse.Marshal(b, m, deterministic)
+}
+func (m *ApplyStateTransitionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyStateTransitionResponse.Merge(m, src)
+}
+func (m *ApplyStateTransitionResponse) XXX_Size() int {
+	return xxx_messageInfo_ApplyStateTransitionResponse.Size(m)
+}
+func (m *ApplyStateTransitionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyStateTransitionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyStateTransitionResponse proto.InternalMessageInfo
+
+type GetIdentityRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetIdentityRequest) Reset()         { *m = GetIdentityRequest{} }
+func (m *GetIdentityRequest) String() string { return proto.CompactTextString(m) }
+func (*GetIdentityRequest) ProtoMessage()    {}
+func (*GetIdentityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ebd04832f1cf8ba, []int{2}
+}
+
+func (m *GetIdentityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetIdentityRequest.Unmarshal(m, b)
+}
+func (m *GetIdentityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetIdentityRequest.Marshal(b, m, deterministic)
+}
+func (m *GetIdentityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetIdentityRequest.Merge(m, src)
+}
+func (m *GetIdentityRequest) XXX_Size() int {
+	return xxx_messageInfo_GetIdentityRequest.Size(m)
+}
+func (m *GetIdentityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetIdentityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetIdentityRequest proto.InternalMessageInfo
+
+func (m *GetIdentityRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetIdentityResponse struct {
+	Identity             []byte   `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetIdentityResponse) Reset()         { *m = GetIdentityResponse{} }
+func (m *GetIdentityResponse) String() string { return proto.CompactTextString(m) }
+func (*GetIdentityResponse) ProtoMessage()    {}
+func (*GetIdentityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ebd04832f1cf8ba, []int{3}
+}
+
+func (m *GetIdentityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetIdentityResponse.Unmarshal(m, b)
+}
+func (m *GetIdentityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetIdentityResponse.Marshal(b, m, deterministic)
+}
+func (m *GetIdentityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetIdentityResponse.Merge(m, src)
+}
+func (m *GetIdentityResponse) XXX_Size() int {
+	return xxx_messageInfo_GetIdentityResponse.Size(m)
+}
+func (m *GetIdentityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetIdentityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetIdentityResponse proto.InternalMessageInfo
+
+func (m *GetIdentityResponse) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+type GetDataContractRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDataContractRequest) Reset()         { *m = GetDataContractRequest{} }
+func (m *GetDataContractRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDataContractRequest) ProtoMessage()    {}
+func (*GetDataContractRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ebd04832f1cf8ba, []int{4}
+}
+
+func (m *GetDataContractRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataContractRequest.Unmarshal(m, b)
+}
+func (m *GetDataContractRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataContractRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDataContractRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataContractRequest.Merge(m, src)
+}
+func (m *GetDataContractRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDataContractRequest.Size(m)
+}
+func (m *GetDataContractRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataContractRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataContractRequest proto.InternalMessageInfo
+
+func (m *GetDataContractRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetDataContractResponse struct {
+	DataContract         []byte   `protobuf:"bytes,1,opt,name=data_contract,json=dataContract,proto3" json:"data_contract,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDataContractResponse) Reset()         { *m = GetDataContractResponse{} }
+func (m *GetDataContractResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDataContractResponse) ProtoMessage()    {}
+func (*GetDataContractResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ebd04832f1cf8ba, []int{5}
+}
+
+func (m *GetDataContractResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataContractResponse.Unmarshal(m, b)
+}
+func (m *GetDataContractResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataContractResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDataContractResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataContractResponse.Merge(m, src)
+}
+func (m *GetDataContractResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDataContractResponse.Size(m)
+}
+func (m *GetDataContractResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataContractResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataContractResponse proto.InternalMessageInfo
+
+func (m *GetDataContractResponse) GetDataContract() []byte {
+	if m != nil {
+		return m.DataContract
+	}
+	return nil
+}
+
+type GetDocumentsRequest struct {
+	DataContractId string `protobuf:"bytes,1,opt,name=data_contract_id,json=dataContractId,proto3" json:"data_contract_id,omitempty"`
+	DocumentType   string `protobuf:"bytes,2,opt,name=document_type,json=documentType,proto3" json:"document_type,omitempty"`
+	Where          []byte `protobuf:"bytes,3,opt,name=where,proto3" json:"where,omitempty"`
+	OrderBy        []byte `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	Limit          uint32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Types that are valid to be assigned to Start:
+	//	*GetDocumentsRequest_StartAfter
+	//	*GetDocumentsRequest_StartAt
+	Start                isGetDocumentsRequest_Start `protobuf_oneof:"start"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *GetDocumentsRequest) Reset()         { *m = GetDocumentsRequest{} }
+func (m *GetDocumentsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDocumentsRequest) ProtoMessage()    {}
+func (*GetDocumentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ebd04832f1cf8ba, []int{6}
+}
+
+func (m *GetDocumentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDocumentsRequest.Unmarshal(m, b)
+}
+func (m *GetDocumentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDocumentsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDocumentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDocumentsRequest.Merge(m, src)
+}
+func (m *GetDocumentsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDocumentsRequest.Size(m)
+}
+func (m *GetDocumentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDocumentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDocumentsRequest proto.InternalMessageInfo
+
+func (m *GetDocumentsRequest) GetDataContractId() string {
+	if m != nil {
+		return m.DataContractId
+	}
+	return ""
+}
+
+func (m *GetDocumentsRequest) GetDocumentType() string {
+	if m != nil {
+		return m.DocumentType
+	}
+	return ""
+}
+
+func (m *GetDocumentsRequest) GetWhere() []byte {
+	if m != nil {
+		return m.Where
+	}
+	return nil
+}
+
+func (m *GetDocumentsRequest) GetOrderBy() []byte {
+	if m != nil {
+		return m.OrderBy
+	}
+	return nil
+}
+
+func (m *GetDocumentsRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type isGetDocumentsRequest_Start interface {
+	isGetDocumentsRequest_Start()
+}
+
+type GetDocumentsRequest_StartAfter struct {
+	StartAfter uint32 `protobuf:"varint,6,opt,name=start_after,json=startAfter,proto3,oneof"`
+}
+
+type GetDocumentsRequest_StartAt struct {
+	StartAt uint32 `protobuf:"varint,7,opt,name=start_at,json=startAt,proto3,oneof"`
+}
+
+func (*GetDocumentsRequest_StartAfter) isGetDocumentsRequest_Start() {}
+
+func (*GetDocumentsRequest_StartAt) isGetDocumentsRequest_Start() {}
+
+func (m *GetDocumentsRequest) GetStart() isGetDocumentsRequest_Start {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *GetDocumentsRequest) GetStartAfter() uint32 {
+	if x, ok := m.GetStart().(*GetDocumentsRequest_StartAfter); ok {
+		return x.StartAfter
+	}
+	return 0
+}
+
+func (m *GetDocumentsRequest) GetStartAt() uint32 {
+	if x, ok := m.GetStart().(*GetDocumentsRequest_StartAt); ok {
+		return x.StartAt
+	}
+	return 0
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*GetDocumentsRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*GetDocumentsRequest_StartAfter)(nil),
+		(*GetDocumentsRequest_StartAt)(nil),
+	}
+}
+
+type GetDocumentsResponse struct {
+	Documents            [][]byte `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDocumentsResponse) Reset()         { *m = GetDocumentsResponse{} }
+func (m *GetDocumentsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDocumentsResponse) ProtoMessage()    {}
+func (*GetDocumentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4ebd04832f1cf8ba, []int{7}
+}
+
+func (m *GetDocumentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDocumentsResponse.Unmarshal(m, b)
+}
+func (m *GetDocumentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDocumentsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDocumentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDocumentsResponse.Merge(m, src)
+}
+func (m *GetDocumentsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDocumentsResponse.Size(m)
+}
+func (m *GetDocumentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDocumentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDocumentsResponse proto.InternalMessageInfo
+
+func (m *GetDocumentsResponse) GetDocuments() [][]byte {
+	if m != nil {
+		return m.Documents
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ApplyStateTransitionRequest)(nil), "org.dash.platform.dapi.v0.ApplyStateTransitionRequest")
+	proto.RegisterType((*ApplyStateTransitionResponse)(nil), "org.dash.platform.dapi.v0.ApplyStateTransitionResponse")
+	proto.RegisterType((*GetIdentityRequest)(nil), "org.dash.platform.dapi.v0.GetIdentityRequest")
+	proto.RegisterType((*GetIdentityResponse)(nil), "org.dash.platform.dapi.v0.GetIdentityResponse")
+	proto.RegisterType((*GetDataContractRequest)(nil), "org.dash.platform.dapi.v0.GetDataContractRequest")
+	proto.RegisterType((*GetDataContractResponse)(nil), "org.dash.platform.dapi.v0.GetDataContractResponse")
+	proto.RegisterType((*GetDocumentsRequest)(nil), "org.dash.platform.dapi.v0.GetDocumentsRequest")
+	proto.RegisterType((*GetDocumentsResponse)(nil), "org.dash.platform.dapi.v0.GetDocumentsResponse")
+}
+
+func init() {
+	proto.RegisterFile("evo/protobuf/platform.proto", fileDescriptor_4ebd04832f1cf8ba)
+}
+
+var fileDescriptor_4ebd04832f1cf8ba = []byte{
+	// 464 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0x09, 0x69, 0xd2, 0xa9, 0xfb, 0xa3, 0x25, 0x02, 0xd7, 0xa9, 0x50, 0x30, 0x1c, 0xcc,
+	0x01, 0x87, 0x16, 0x04, 0x37, 0xa4, 0x96, 0x4a, 0x6d, 0x6f, 0xc8, 0xf4, 0x6e, 0x6d, 0xb2, 0x9b,
+	0x74, 0xa5, 0xc4, 0xbb, 0xec, 0x4e, 0x0a, 0x7e, 0x02, 0x9e, 0x96, 0x1b, 0x0f, 0x80, 0xb2, 0xde,
+	0xa4, 0x31, 0x4d, 0xd3, 0xf6, 0x96, 0xf9, 0x7e, 0xe6, 0x9b, 0xd8, 0x9f, 0x0c, 0x1d, 0x7e, 0x2d,
+	0x7b, 0x4a, 0x4b, 0x94, 0xfd, 0xe9, 0xb0, 0xa7, 0xc6, 0x14, 0x87, 0x52, 0x4f, 0x12, 0x8b, 0x90,
+	0x7d, 0xa9, 0x47, 0x09, 0xa3, 0xe6, 0x2a, 0x59, 0x10, 0x8c, 0x2a, 0x91, 0x5c, 0xbf, 0x8f, 0xce,
+	0xa1, 0x73, 0xac, 0xd4, 0xb8, 0xf8, 0x8e, 0x14, 0xf9, 0xa5, 0xa6, 0xb9, 0x11, 0x28, 0x64, 0x9e,
+	0xf2, 0x1f, 0x53, 0x6e, 0x90, 0xbc, 0x85, 0x3d, 0x33, 0x63, 0x32, 0x5c, 0x50, 0x81, 0xd7, 0xf5,
+	0x62, 0x3f, 0xdd, 0x35, 0x55, 0x47, 0xf4, 0x12, 0x0e, 0x56, 0x6f, 0x32, 0x4a, 0xe6, 0x86, 0x47,
+	0x6f, 0x80, 0x9c, 0x71, 0xbc, 0x60, 0x3c, 0x47, 0x81, 0xc5, 0x3c, 0x60, 0x07, 0x6a, 0x82, 0xd9,
+	0x95, 0x9b, 0x69, 0x4d, 0xb0, 0xe8, 0x10, 0x9e, 0x55, 0x54, 0xa5, 0x99, 0x84, 0xd0, 0x12, 0x0e,
+	0x73, 0xf9, 0x8b, 0x39, 0x8a, 0xe1, 0xf9, 0x19, 0xc7, 0x53, 0x8a, 0xf4, 0xab, 0xcc, 0x51, 0xd3,
+	0x01, 0xde, 0xb5, 0xfc, 0x0b, 0xbc, 0xb8, 0xa5, 0x74, 0x01, 0xaf, 0x61, 0x9b, 0x51, 0xa4, 0xd9,
+	0xc0, 0x11, 0x2e, 0xc5, 0x67, 0x4b, 0xe2, 0xe8, 0xaf, 0x67, 0xaf, 0x3b, 0x95, 0x83, 0xe9, 0x84,
+	0xe7, 0x68, 0xe6, 0x39, 0x31, 0xec, 0x55, 0xcc, 0xd9, 0x22, 0x75, 0x67, 0xd9, 0x7f, 0xc1, 0x6c,
+	0x8c, 0x73, 0x67, 0x58, 0x28, 0x1e, 0xd4, 0xac, 0xcc, 0x9f, 0x83, 0x97, 0x85, 0xe2, 0xa4, 0x0d,
+	0x8d, 0x9f, 0x57, 0x5c, 0xf3, 0xa0, 0x6e, 0x6f, 0x28, 0x07, 0xb2, 0x0f, 0x2d, 0xa9, 0x19, 0xd7,
+	0x59, 0xbf, 0x08, 0x9e, 0x5a, 0xa2, 0x69, 0xe7, 0x93, 0x62, 0x66, 0x18, 0x8b, 0x89, 0xc0, 0xa0,
+	0xd1, 0xf5, 0xe2, 0xed, 0xb4, 0x1c, 0xc8, 0x2b, 0xd8, 0x32, 0x48, 0x35, 0x66, 0x74, 0x88, 0x5c,
+	0x07, 0x1b, 0x33, 0xee, 0xfc, 0x49, 0x0a, 0x16, 0x3c, 0x9e, 0x61, 0xa4, 0x03, 0x2d, 0x27, 0xc1,
+	0xa0, 0xe9, 0xf8, 0x66, 0xc9, 0xe3, 0x49, 0x13, 0x1a, 0xf6, 0x67, 0xf4, 0x11, 0xda, 0xd5, 0x7f,
+	0xed, 0x9e, 0xd9, 0x01, 0x6c, 0xce, 0xef, 0x36, 0x81, 0xd7, 0xad, 0xc7, 0x7e, 0x7a, 0x03, 0x1c,
+	0xfd, 0xa9, 0x43, 0xeb, 0x9b, 0xab, 0x1b, 0xf9, 0xed, 0x41, 0x9b, 0xae, 0x68, 0x07, 0xf9, 0x94,
+	0xdc, 0xd9, 0xcd, 0x64, 0x4d, 0x31, 0xc3, 0xcf, 0x8f, 0xf6, 0xb9, 0xa3, 0xc7, 0xb0, 0x35, 0xba,
+	0x29, 0x18, 0x79, 0xb7, 0x66, 0xcf, 0xed, 0xba, 0x86, 0xc9, 0x43, 0xe5, 0x2e, 0xed, 0x17, 0xec,
+	0x8e, 0xaa, 0x8d, 0x23, 0x87, 0xeb, 0x57, 0xac, 0xe8, 0x71, 0x78, 0xf4, 0x18, 0x8b, 0x4b, 0x96,
+	0xe0, 0x8f, 0x96, 0x5e, 0x1a, 0xb9, 0xe7, 0xf2, 0xff, 0x3b, 0x1d, 0xf6, 0x1e, 0xac, 0x2f, 0x03,
+	0xfb, 0x1b, 0xf6, 0x5b, 0xf3, 0xe1, 0xdf, 0x00, 0x23, 0xf3, 0xe4, 0x0c, 0x8a, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// PlatformClient is the client API for Platform service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PlatformClient interface {
+	ApplyStateTransition(ctx context.Context, in *ApplyStateTransitionRequest, opts ...grpc.CallOption) (*ApplyStateTransitionResponse, error)
+	GetIdentity(ctx context.Context, in *GetIdentityRequest, opts ...grpc.CallOption) (*GetIdentityResponse, error)
+	GetDataContract(ctx context.Context, in *GetDataContractRequest, opts ...grpc.CallOption) (*GetDataContractResponse, error)
+	GetDocuments(ctx context.Context, in *GetDocumentsRequest, opts ...grpc.CallOption) (*GetDocumentsResponse, error)
+}
+
+type platformClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPlatformClient(cc grpc.ClientConnInterface) PlatformClient {
+	return &platformClient{cc}
+}
+
+func (c *platformClient) ApplyStateTransition(ctx context.Context, in *ApplyStateTransitionRequest, opts ...grpc.CallOption) (*ApplyStateTransitionResponse, error) {
+	out := new(ApplyStateTransitionResponse)
+	err := c.cc.Invoke(ctx, "/org.dash.platform.dapi.v0.Platform/applyStateTransition", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetIdentity(ctx context.Context, in *GetIdentityRequest, opts ...grpc.CallOption) (*GetIdentityResponse, error) {
+	out := new(GetIdentityResponse)
+	err := c.cc.Invoke(ctx, "/org.dash.platform.dapi.v0.Platform/getIdentity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetDataContract(ctx context.Context, in *GetDataContractRequest, opts ...grpc.CallOption) (*GetDataContractResponse, error) {
+	out := new(GetDataContractResponse)
+	err := c.cc.Invoke(ctx, "/org.dash.platform.dapi.v0.Platform/getDataContract", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) GetDocuments(ctx context.Context, in *GetDocumentsRequest, opts ...grpc.CallOption) (*GetDocumentsResponse, error) {
+	out := new(GetDocumentsResponse)
+	err := c.cc.Invoke(ctx, "/org.dash.platform.dapi.v0.Platform/getDocuments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlatformServer is the server API for Platform service.
+type PlatformServer interface {
+	ApplyStateTransition(context.Context, *ApplyStateTransitionRequest) (*ApplyStateTransitionResponse, error)
+	GetIdentity(context.Context, *GetIdentityRequest) (*GetIdentityResponse, error)
+	GetDataContract(context.Context, *GetDataContractRequest) (*GetDataContractResponse, error)
+	GetDocuments(context.Context, *GetDocumentsRequest) (*GetDocumentsResponse, error)
+}
+
+// UnimplementedPlatformServer can be embedded to have forward compatible implementations.
+type UnimplementedPlatformServer struct {
+}
+
+func (*UnimplementedPlatformServer) ApplyStateTransition(ctx context.Context, req *ApplyStateTransitionRequest) (*ApplyStateTransitionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyStateTransition not implemented")
+}
+func (*UnimplementedPlatformServer) GetIdentity(ctx context.Context, req *GetIdentityRequest) (*GetIdentityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIdentity not implemented")
+}
+func (*UnimplementedPlatformServer) GetDataContract(ctx context.Context, req *GetDataContractRequest) (*GetDataContractResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDataContract not implemented")
+}
+func (*UnimplementedPlatformServer) GetDocuments(ctx context.Context, req *GetDocumentsRequest) (*GetDocumentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDocuments not implemented")
+}
+
+func RegisterPlatformServer(s *grpc.Server, srv PlatformServer) {
+	s.RegisterService(&_Platform_serviceDesc, srv)
+}
+
+func _Platform_ApplyStateTransition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyStateTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).ApplyStateTransition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.dash.platform.dapi.v0.Platform/ApplyStateTransition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).ApplyStateTransition(ctx, req.(*ApplyStateTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.dash.platform.dapi.v0.Platform/GetIdentity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetIdentity(ctx, req.(*GetIdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetDataContract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDataContractRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetDataContract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.dash.platform.dapi.v0.Platform/GetDataContract",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetDataContract(ctx, req.(*GetDataContractRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GetDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDocumentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GetDocuments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/org.dash.platform.dapi.v0.Platform/GetDocuments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GetDocuments(ctx, req.(*GetDocumentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Platform_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "org.dash.platform.dapi.v0.Platform",
+	HandlerType: (*PlatformServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "applyStateTransition",
+			Handler:    _Platform_ApplyStateTransition_Handler,
+		},
+		{
+			MethodName: "getIdentity",
+			Handler:    _Platform_GetIdentity_Handler,
+		},
+		{
+			MethodName: "getDataContract",
+			Handler:    _Platform_GetDataContract_Handler,
+		},
+		{
+			MethodName: "getDocuments",
+			Handler:    _Platform_GetDocuments_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "evo/protobuf/platform.proto",
+}
@@ -0,0 +1,522 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: evo/protobuf/transactions_filter_stream.proto
+
+package org_dash_platform_dapi_v0
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type TransactionsWithProofsRequest struct {
+	BloomFilter *BloomFilter `protobuf:"bytes,1,opt,name=bloom_filter,json=bloomFilter,proto3" json:"bloom_filter,omitempty"`
+	// Types that are valid to be assigned to FromBlock:
+	//	*TransactionsWithProofsRequest_FromBlockHash
+	//	*TransactionsWithProofsRequest_FromBlockHeight
+	FromBlock             isTransactionsWithProofsRequest_FromBlock `protobuf_oneof:"from_block"`
+	Count                 uint32                                    `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	SendTransactionHashes bool                                      `protobuf:"varint,5,opt,name=send_transaction_hashes,json=sendTransactionHashes,proto3" json:"send_transaction_hashes,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}                                  `json:"-"`
+	XXX_unrecognized      []byte                                    `json:"-"`
+	XXX_sizecache         int32                                     `json:"-"`
+}
+
+func (m *TransactionsWithProofsRequest) Reset()         { *m = TransactionsWithProofsRequest{} }
+func (m *TransactionsWithProofsRequest) String() string { return proto.CompactTextString(m) }
+func (*TransactionsWithProofsRequest) ProtoMessage()    {}
+func (*TransactionsWithProofsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_67c38745cf212062, []int{0}
+}
+
+func (m *TransactionsWithProofsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionsWithProofsRequest.Unmarshal(m, b)
+}
+func (m *TransactionsWithProofsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionsWithProofsRequest.Marshal(b, m, deterministic)
+}
+func (m *TransactionsWithProofsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionsWithProofsRequest.Merge(m, src)
+}
+func (m *TransactionsWithProofsRequest) XXX_Size() int {
+	return xxx_messageInfo_TransactionsWithProofsRequest.Size(m)
+}
+func (m *TransactionsWithProofsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionsWithProofsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionsWithProofsRequest proto.InternalMessageInfo
+
+func (m *TransactionsWithProofsRequest) GetBloomFilter() *BloomFilter {
+	if m != nil {
+		return m.BloomFilter
+	}
+	return nil
+}
+
+type isTransactionsWithProofsRequest_FromBlock interface {
+	isTransactionsWithProofsRequest_FromBlock()
+}
+
+type TransactionsWithProofsRequest_FromBlockHash struct {
+	FromBlockHash []byte `protobuf:"bytes,2,opt,name=from_block_hash,json=fromBlockHash,proto3,oneof"`
+}
+
+type TransactionsWithProofsRequest_FromBlockHeight struct {
+	FromBlockHeight uint32 `protobuf:"varint,3,opt,name=from_block_height,json=fromBlockHeight,proto3,oneof"`
+}
+
+func (*TransactionsWithProofsRequest_FromBlockHash) isTransactionsWithProofsRequest_FromBlock() {}
+
+func (*TransactionsWithProofsRequest_FromBlockHeight) isTransactionsWithProofsRequest_FromBlock() {}
+
+func (m *TransactionsWithProofsRequest) GetFromBlock() isTransactionsWithProofsRequest_FromBlock {
+	if m != nil {
+		return m.FromBlock
+	}
+	return nil
+}
+
+func (m *TransactionsWithProofsRequest) GetFromBlockHash() []byte {
+	if x, ok := m.GetFromBlock().(*TransactionsWithProofsRequest_FromBlockHash); ok {
+		return x.FromBlockHash
+	}
+	return nil
+}
+
+func (m *TransactionsWithProofsRequest) GetFromBlockHeight() uint32 {
+	if x, ok := m.GetFromBlock().(*TransactionsWithProofsRequest_FromBlockHeight); ok {
+		return x.FromBlockHeight
+	}
+	return 0
+}
+
+func (m *TransactionsWithProofsRequest) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *TransactionsWithProofsRequest) GetSendTransactionHashes() bool {
+	if m != nil {
+		return m.SendTransactionHashes
+	}
+	return false
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*TransactionsWithProofsRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*TransactionsWithProofsRequest_FromBlockHash)(nil),
+		(*TransactionsWithProofsRequest_FromBlockHeight)(nil),
+	}
+}
+
+type BloomFilter struct {
+	VData                []byte   `protobuf:"bytes,1,opt,name=v_data,json=vData,proto3" json:"v_data,omitempty"`
+	NHashFuncs           uint32   `protobuf:"varint,2,opt,name=n_hash_funcs,json=nHashFuncs,proto3" json:"n_hash_funcs,omitempty"`
+	NTweak               uint32   `protobuf:"varint,3,opt,name=n_tweak,json=nTweak,proto3" json:"n_tweak,omitempty"`
+	NFlags               uint32   `protobuf:"varint,4,opt,name=n_flags,json=nFlags,proto3" json:"n_flags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BloomFilter) Reset()         { *m = BloomFilter{} }
+func (m *BloomFilter) String() string { return proto.CompactTextString(m) }
+func (*BloomFilter) ProtoMessage()    {}
+func (*BloomFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_67c38745cf212062, []int{1}
+}
+
+func (m *BloomFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BloomFilter.Unmarshal(m, b)
+}
+func (m *BloomFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BloomFilter.Marshal(b, m, deterministic)
+}
+func (m *BloomFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BloomFilter.Merge(m, src)
+}
+func (m *BloomFilter) XXX_Size() int {
+	return xxx_messageInfo_BloomFilter.Size(m)
+}
+func (m *BloomFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_BloomFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BloomFilter proto.InternalMessageInfo
+
+func (m *BloomFilter) GetVData() []byte {
+	if m != nil {
+		return m.VData
+	}
+	return nil
+}
+
+func (m *BloomFilter) GetNHashFuncs() uint32 {
+	if m != nil {
+		return m.NHashFuncs
+	}
+	return 0
+}
+
+func (m *BloomFilter) GetNTweak() uint32 {
+	if m != nil {
+		return m.NTweak
+	}
+	return 0
+}
+
+func (m *BloomFilter) GetNFlags() uint32 {
+	if m != nil {
+		return m.NFlags
+	}
+	return 0
+}
+
+type TransactionsWithProofsResponse struct {
+	// Types that are valid to be assigned to Responses:
+	//	*TransactionsWithProofsResponse_RawTransactions
+	//	*TransactionsWithProofsResponse_InstantSendLockMessages
+	//	*TransactionsWithProofsResponse_RawMerkleBlock
+	Responses            isTransactionsWithProofsResponse_Responses `protobuf_oneof:"responses"`
+	XXX_NoUnkeyedLiteral struct{}                                   `json:"-"`
+	XXX_unrecognized     []byte                                     `json:"-"`
+	XXX_sizecache        int32                                      `json:"-"`
+}
+
+func (m *TransactionsWithProofsResponse) Reset()         { *m = TransactionsWithProofsResponse{} }
+func (m *TransactionsWithProofsResponse) String() string { return proto.CompactTextString(m) }
+func (*TransactionsWithProofsResponse) ProtoMessage()    {}
+func (*TransactionsWithProofsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_67c38745cf212062, []int{2}
+}
+
+func (m *TransactionsWithProofsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionsWithProofsResponse.Unmarshal(m, b)
+}
+func (m *TransactionsWithProofsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionsWithProofsResponse.Marshal(b, m, deterministic)
+}
+func (m *TransactionsWithProofsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionsWithProofsResponse.Merge(m, src)
+}
+func (m *TransactionsWithProofsResponse) XXX_Size() int {
+	return xxx_messageInfo_TransactionsWithProofsResponse.Size(m)
+}
+func (m *TransactionsWithProofsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionsWithProofsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionsWithProofsResponse proto.InternalMessageInfo
+
+type isTransactionsWithProofsResponse_Responses interface {
+	isTransactionsWithProofsResponse_Responses()
+}
+
+type TransactionsWithProofsResponse_RawTransactions struct {
+	RawTransactions *RawTransactions `protobuf:"bytes,1,opt,name=raw_transactions,json=rawTransactions,proto3,oneof"`
+}
+
+type TransactionsWithProofsResponse_InstantSendLockMessages struct {
+	InstantSendLockMessages *InstantSendLockMessages `protobuf:"bytes,2,opt,name=instant_send_lock_messages,json=instantSendLockMessages,proto3,oneof"`
+}
+
+type TransactionsWithProofsResponse_RawMerkleBlock struct {
+	RawMerkleBlock []byte `protobuf:"bytes,3,opt,name=raw_merkle_block,json=rawMerkleBlock,proto3,oneof"`
+}
+
+func (*TransactionsWithProofsResponse_RawTransactions) isTransactionsWithProofsResponse_Responses() {}
+
+func (*TransactionsWithProofsResponse_InstantSendLockMessages) isTransactionsWithProofsResponse_Responses() {
+}
+
+func (*TransactionsWithProofsResponse_RawMerkleBlock) isTransactionsWithProofsResponse_Responses() {}
+
+func (m *TransactionsWithProofsResponse) GetResponses() isTransactionsWithProofsResponse_Responses {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+func (m *TransactionsWithProofsResponse) GetRawTransactions() *RawTransactions {
+	if x, ok := m.GetResponses().(*TransactionsWithProofsResponse_RawTransactions); ok {
+		return x.RawTransactions
+	}
+	return nil
+}
+
+func (m *TransactionsWithProofsResponse) GetInstantSendLockMessages() *InstantSendLockMessages {
+	if x, ok := m.GetResponses().(*TransactionsWithProofsResponse_InstantSendLockMessages); ok {
+		return x.InstantSendLockMessages
+	}
+	return nil
+}
+
+func (m *TransactionsWithProofsResponse) GetRawMerkleBlock() []byte {
+	if x, ok := m.GetResponses().(*TransactionsWithProofsResponse_RawMerkleBlock); ok {
+		return x.RawMerkleBlock
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*TransactionsWithProofsResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*TransactionsWithProofsResponse_RawTransactions)(nil),
+		(*TransactionsWithProofsResponse_InstantSendLockMessages)(nil),
+		(*TransactionsWithProofsResponse_RawMerkleBlock)(nil),
+	}
+}
+
+type RawTransactions struct {
+	Transactions         [][]byte `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RawTransactions) Reset()         { *m = RawTransactions{} }
+func (m *RawTransactions) String() string { return proto.CompactTextString(m) }
+func (*RawTransactions) ProtoMessage()    {}
+func (*RawTransactions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_67c38745cf212062, []int{3}
+}
+
+func (m *RawTransactions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RawTransactions.Unmarshal(m, b)
+}
+func (m *RawTransactions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RawTransactions.Marshal(b, m, deterministic)
+}
+func (m *RawTransactions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RawTransactions.Merge(m, src)
+}
+func (m *RawTransactions) XXX_Size() int {
+	return xxx_messageInfo_RawTransactions.Size(m)
+}
+func (m *RawTransactions) XXX_DiscardUnknown() {
+	xxx_messageInfo_RawTransactions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RawTransactions proto.InternalMessageInfo
+
+func (m *RawTransactions) GetTransactions() [][]byte {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+type InstantSendLockMessages struct {
+	Messages             [][]byte `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InstantSendLockMessages) Reset()         { *m = InstantSendLockMessages{} }
+func (m *InstantSendLockMessages) String() string { return proto.CompactTextString(m) }
+func (*InstantSendLockMessages) ProtoMessage()    {}
+func (*InstantSendLockMessages) Descriptor() ([]byte, []int) {
+	return fileDescriptor_67c38745cf212062, []int{4}
+}
+
+func (m *InstantSendLockMessages) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstantSendLockMessages.Unmarshal(m, b)
+}
+func (m *InstantSendLockMessages) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstantSendLockMessages.Marshal(b, m, deterministic)
+}
+func (m *InstantSendLockMessages) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstantSendLockMessages.Merge(m, src)
+}
+func (m *InstantSendLockMessages) XXX_Size() int {
+	return xxx_messageInfo_InstantSendLockMessages.Size(m)
+}
+func (m *InstantSendLockMessages) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstantSendLockMessages.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstantSendLockMessages proto.InternalMessageInfo
+
+func (m *InstantSendLockMessages) GetMessages() [][]byte {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*TransactionsWithProofsRequest)(nil), "org.dash.platform.dapi.v0.TransactionsWithProofsRequest")
+	proto.RegisterType((*BloomFilter)(nil), "org.dash.platform.dapi.v0.BloomFilter")
+	proto.RegisterType((*TransactionsWithProofsResponse)(nil), "org.dash.platform.dapi.v0.TransactionsWithProofsResponse")
+	proto.RegisterType((*RawTransactions)(nil), "org.dash.platform.dapi.v0.RawTransactions")
+	proto.RegisterType((*InstantSendLockMessages)(nil), "org.dash.platform.dapi.v0.InstantSendLockMessages")
+}
+
+func init() {
+	proto.RegisterFile("evo/protobuf/transactions_filter_stream.proto", fileDescriptor_67c38745cf212062)
+}
+
+var fileDescriptor_67c38745cf212062 = []byte{
+	// 518 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x5d, 0x6f, 0xd3, 0x30,
+	0x14, 0x5d, 0x5a, 0x5a, 0xc6, 0x6d, 0x4a, 0xc1, 0x62, 0x6a, 0xa8, 0x04, 0x2a, 0x79, 0x40, 0xd1,
+	0x04, 0xd9, 0x54, 0x34, 0x04, 0xaf, 0x15, 0xaa, 0x3a, 0x89, 0x49, 0xc8, 0xab, 0xb4, 0xc7, 0xc8,
+	0x49, 0x9d, 0x26, 0x6a, 0x62, 0x77, 0xbe, 0x6e, 0xcb, 0x6f, 0xe1, 0x81, 0x37, 0x7e, 0x03, 0x7f,
+	0x0f, 0xc5, 0x89, 0xd6, 0x6c, 0x5a, 0xfb, 0xc0, 0xe3, 0xbd, 0xe7, 0x7e, 0xf8, 0x9c, 0x63, 0x1b,
+	0x3e, 0xf2, 0x8d, 0x3c, 0x5b, 0x29, 0xa9, 0x65, 0xb8, 0x8e, 0xcf, 0xb4, 0x62, 0x02, 0x59, 0xa4,
+	0x53, 0x29, 0x30, 0x88, 0xd3, 0x4c, 0x73, 0x15, 0xa0, 0x56, 0x9c, 0xe5, 0xbe, 0xa9, 0x21, 0xaf,
+	0xa5, 0x5a, 0xf8, 0x73, 0x86, 0x89, 0xbf, 0xca, 0x98, 0x8e, 0xa5, 0xca, 0xfd, 0x39, 0x5b, 0xa5,
+	0xfe, 0xe6, 0xdc, 0xfd, 0xdd, 0x80, 0x37, 0xb3, 0x5a, 0xff, 0x4d, 0xaa, 0x93, 0x1f, 0x4a, 0xca,
+	0x18, 0x29, 0xbf, 0x5d, 0x73, 0xd4, 0xe4, 0x12, 0xec, 0x30, 0x93, 0x32, 0xaf, 0x26, 0x3b, 0xd6,
+	0xd0, 0xf2, 0x3a, 0xa3, 0xf7, 0xfe, 0xde, 0x99, 0xfe, 0xb8, 0x28, 0x9f, 0x98, 0x6a, 0xda, 0x09,
+	0x77, 0x01, 0xf1, 0xa0, 0x17, 0x2b, 0x99, 0x07, 0x61, 0x26, 0xa3, 0x65, 0x90, 0x30, 0x4c, 0x9c,
+	0xc6, 0xd0, 0xf2, 0xec, 0xe9, 0x11, 0xed, 0x16, 0xc0, 0xb8, 0xc8, 0x4f, 0x19, 0x26, 0xe4, 0x03,
+	0xbc, 0xac, 0x57, 0xf2, 0x74, 0x91, 0x68, 0xa7, 0x39, 0xb4, 0xbc, 0xee, 0xf4, 0x88, 0xf6, 0x76,
+	0xb5, 0x06, 0x20, 0xaf, 0xa0, 0x15, 0xc9, 0xb5, 0xd0, 0xce, 0x93, 0xa2, 0x82, 0x96, 0x01, 0xf9,
+	0x0c, 0x7d, 0xe4, 0x62, 0x1e, 0xd4, 0xe4, 0x31, 0x3b, 0x39, 0x3a, 0xad, 0xa1, 0xe5, 0x1d, 0xd3,
+	0x93, 0x02, 0xae, 0x91, 0x9f, 0x1a, 0x70, 0x6c, 0x03, 0xec, 0x76, 0xbb, 0x3f, 0xa1, 0x53, 0xe3,
+	0x43, 0x4e, 0xa0, 0xbd, 0x09, 0xe6, 0x4c, 0x33, 0xa3, 0x83, 0x4d, 0x5b, 0x9b, 0x6f, 0x4c, 0x33,
+	0x32, 0x04, 0xbb, 0x1c, 0x1e, 0xc4, 0x6b, 0x11, 0xa1, 0xa1, 0xd5, 0xa5, 0x60, 0x46, 0x4e, 0x8a,
+	0x0c, 0xe9, 0xc3, 0x53, 0x11, 0xe8, 0x2d, 0x67, 0xcb, 0x92, 0x07, 0x6d, 0x8b, 0x59, 0x11, 0x95,
+	0x40, 0x9c, 0xb1, 0x05, 0x56, 0xc7, 0x6f, 0x8b, 0x49, 0x11, 0xb9, 0x7f, 0x1a, 0xf0, 0x76, 0x9f,
+	0x35, 0xb8, 0x92, 0x02, 0x39, 0xb9, 0x81, 0x17, 0x8a, 0x6d, 0xeb, 0x0c, 0xb1, 0xf2, 0xe7, 0xf4,
+	0x80, 0x3f, 0x94, 0x6d, 0xeb, 0x73, 0x0b, 0x45, 0xd5, 0xfd, 0x14, 0xb9, 0x85, 0x41, 0x2a, 0x50,
+	0x33, 0xa1, 0x03, 0xa3, 0xa1, 0xb1, 0x21, 0xe7, 0x88, 0x6c, 0xc1, 0x4b, 0x76, 0x9d, 0xd1, 0xe8,
+	0xc0, 0x8a, 0xcb, 0xb2, 0xf9, 0x9a, 0x8b, 0xf9, 0x77, 0x19, 0x2d, 0xaf, 0xaa, 0xce, 0xe9, 0x11,
+	0xed, 0xa7, 0x8f, 0x43, 0xe4, 0xb4, 0xe4, 0x92, 0x73, 0xb5, 0xcc, 0x78, 0x29, 0xbe, 0xd3, 0xac,
+	0x6e, 0xc7, 0x73, 0xc5, 0xb6, 0x57, 0x06, 0x30, 0xb6, 0x8f, 0x3b, 0xf0, 0x4c, 0x55, 0x1a, 0xa0,
+	0x7b, 0x01, 0xbd, 0x07, 0x8c, 0x88, 0x0b, 0xf6, 0x03, 0x4d, 0x9a, 0x9e, 0x4d, 0xef, 0xe5, 0xdc,
+	0x0b, 0xe8, 0xef, 0x39, 0x25, 0x19, 0xc0, 0xf1, 0x1d, 0xd7, 0xb2, 0xf5, 0x2e, 0x1e, 0xfd, 0xb5,
+	0xc0, 0xa9, 0xef, 0x2a, 0xef, 0xc5, 0xb5, 0x79, 0x6e, 0xe4, 0x97, 0x05, 0xef, 0x70, 0x1d, 0x62,
+	0xa4, 0xd2, 0x90, 0xcf, 0xe4, 0xe3, 0xee, 0x91, 0x2f, 0x07, 0x84, 0x3b, 0xf8, 0x16, 0x07, 0x5f,
+	0xff, 0xa3, 0xb3, 0x94, 0xe9, 0xdc, 0x0a, 0xdb, 0xe6, 0x33, 0xf8, 0xf4, 0x6f, 0x00, 0x07, 0x2c,
+	0xa6, 0xbd, 0x3d, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// TransactionsFilterStreamClient is the client API for TransactionsFilterStream service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TransactionsFilterStreamClient interface {
+	SubscribeToTransactionsWithProofs(ctx context.Context, in *TransactionsWithProofsRequest, opts ...grpc.CallOption) (TransactionsFilterStream_SubscribeToTransactionsWithProofsClient, error)
+}
+
+type transactionsFilterStreamClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTransactionsFilterStreamClient(cc grpc.ClientConnInterface) TransactionsFilterStreamClient {
+	return &transactionsFilterStreamClient{cc}
+}
+
+func (c *transactionsFilterStreamClient) SubscribeToTransactionsWithProofs(ctx context.Context, in *TransactionsWithProofsRequest, opts ...grpc.CallOption) (TransactionsFilterStream_SubscribeToTransactionsWithProofsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TransactionsFilterStream_serviceDesc.Streams[0], "/org.dash.platform.dapi.v0.TransactionsFilterStream/subscribeToTransactionsWithProofs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &transactionsFilterStreamSubscribeToTransactionsWithProofsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TransactionsFilterStream_SubscribeToTransactionsWithProofsClient interface {
+	Recv() (*TransactionsWithProofsResponse, error)
+	grpc.ClientStream
+}
+
+type transactionsFilterStreamSubscribeToTransactionsWithProofsClient struct {
+	grpc.ClientStream
+}
+
+func (x *transactionsFilterStreamSubscribeToTransactionsWithProofsClient) Recv() (*TransactionsWithProofsResponse, error) {
+	m := new(TransactionsWithProofsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TransactionsFilterStreamServer is the server API for TransactionsFilterStream service.
+type TransactionsFilterStreamServer interface {
+	SubscribeToTransactionsWithProofs(*TransactionsWithProofsRequest, TransactionsFilterStream_SubscribeToTransactionsWithProofsServer) error
+}
+
+// UnimplementedTransactionsFilterStreamServer can be embedded to have forward compatible implementations.
+type UnimplementedTransactionsFilterStreamServer struct {
+}
+
+func (*UnimplementedTransactionsFilterStreamServer) SubscribeToTransactionsWithProofs(req *TransactionsWithProofsRequest, srv TransactionsFilterStream_SubscribeToTransactionsWithProofsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeToTransactionsWithProofs not implemented")
+}
+
+func RegisterTransactionsFilterStreamServer(s *grpc.Server, srv TransactionsFilterStreamServer) {
+	s.RegisterService(&_TransactionsFilterStream_serviceDesc, srv)
+}
+
+func _TransactionsFilterStream_SubscribeToTransactionsWithProofs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TransactionsWithProofsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TransactionsFilterStreamServer).SubscribeToTransactionsWithProofs(m, &transactionsFilterStreamSubscribeToTransactionsWithProofsServer{stream})
+}
+
+type TransactionsFilterStream_SubscribeToTransactionsWithProofsServer interface {
+	Send(*TransactionsWithProofsResponse) error
+	grpc.ServerStream
+}
+
+type transactionsFilterStreamSubscribeToTransactionsWithProofsServer struct {
+	grpc.ServerStream
+}
+
+func (x *transactionsFilterStreamSubscribeToTransactionsWithProofsServer) Send(m *TransactionsWithProofsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _TransactionsFilterStream_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "org.dash.platform.dapi.v0.TransactionsFilterStream",
+	HandlerType: (*TransactionsFilterStreamServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "subscribeToTransactionsWithProofs",
+			Handler:       _TransactionsFilterStream_SubscribeToTransactionsWithProofs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "evo/protobuf/transactions_filter_stream.proto",
+}
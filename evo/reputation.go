@@ -0,0 +1,166 @@
+package evo
+
+import (
+	"github.com/co-in/dash-dapi/db"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultFraudHalfLife = 24 * time.Hour
+	DefaultBanThreshold  = 10
+	DefaultBanDuration   = time.Hour
+	//Latency samples kept per node
+	latencyWindow = 64
+)
+
+type ReputationPolicy struct {
+	//Fraud score halves during this period
+	FraudHalfLife time.Duration
+	//Node is banned when decayed fraud score reaches threshold
+	BanThreshold float64
+	BanDuration  time.Duration
+}
+
+//Reputation of node, shared by all copies of connection
+type nodeStats struct {
+	sync.Mutex
+	policy ReputationPolicy
+	record db.NodeRecord
+	//Network verification result, not persisted
+	verified  bool
+	verifyErr error
+}
+
+func DefaultReputationPolicy() ReputationPolicy {
+	return ReputationPolicy{
+		FraudHalfLife: DefaultFraudHalfLife,
+		BanThreshold:  DefaultBanThreshold,
+		BanDuration:   DefaultBanDuration,
+	}
+}
+
+func newNodeStats(policy ReputationPolicy, record db.NodeRecord) *nodeStats {
+	record.LatencySamples = append([]int64(nil), record.LatencySamples...)
+
+	return &nodeStats{
+		policy: policy,
+		record: record,
+	}
+}
+
+func (s *nodeStats) decay(now time.Time) {
+	if s.record.FraudUpdatedAt.IsZero() || s.policy.FraudHalfLife <= 0 {
+		s.record.FraudUpdatedAt = now
+		return
+	}
+
+	elapsed := now.Sub(s.record.FraudUpdatedAt)
+
+	if elapsed <= 0 {
+		return
+	}
+
+	s.record.Fraud *= math.Pow(0.5, float64(elapsed)/float64(s.policy.FraudHalfLife))
+	s.record.FraudUpdatedAt = now
+}
+
+func (s *nodeStats) addFraud(score float64) float64 {
+	s.Lock()
+	defer s.Unlock()
+
+	now := time.Now()
+	s.decay(now)
+	s.record.Fraud += score
+
+	if score > 0 && s.record.Fraud >= s.policy.BanThreshold {
+		s.record.BanUntil = now.Add(s.policy.BanDuration)
+	}
+
+	return s.record.Fraud
+}
+
+func (s *nodeStats) success(latency time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.record.LastSuccess = time.Now()
+	s.record.LatencySamples = append(s.record.LatencySamples, latency.Milliseconds())
+
+	if len(s.record.LatencySamples) > latencyWindow {
+		s.record.LatencySamples = s.record.LatencySamples[len(s.record.LatencySamples)-latencyWindow:]
+	}
+}
+
+//Failed request increases fraud score, returns decayed score
+func (s *nodeStats) failure() float64 {
+	fraud := s.addFraud(1)
+
+	s.Lock()
+	s.record.LastFailure = time.Now()
+	s.Unlock()
+
+	return fraud
+}
+
+func (s *nodeStats) fraud() float64 {
+	s.Lock()
+	defer s.Unlock()
+
+	s.decay(time.Now())
+
+	return s.record.Fraud
+}
+
+func (s *nodeStats) banned(now time.Time) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	return now.Before(s.record.BanUntil)
+}
+
+//Node belongs to another network
+func (s *nodeStats) mismatched() bool {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.verified && s.verifyErr != nil
+}
+
+//Record with decayed fraud score and fresh percentiles
+func (s *nodeStats) snapshot() db.NodeRecord {
+	s.Lock()
+	defer s.Unlock()
+
+	s.decay(time.Now())
+
+	r := s.record
+	r.LatencySamples = append([]int64(nil), s.record.LatencySamples...)
+	sorted := append([]int64(nil), r.LatencySamples...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	r.LatencyP50 = percentile(sorted, 50)
+	r.LatencyP90 = percentile(sorted, 90)
+	r.LatencyP99 = percentile(sorted, 99)
+
+	return r
+}
+
+//Nearest-rank percentile of sorted samples
+func percentile(sorted []int64, p int) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := (p*len(sorted) + 99) / 100
+
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
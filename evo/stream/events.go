@@ -0,0 +1,257 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"github.com/co-in/dash-dapi/evo/block"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"sync"
+)
+
+//What to do when consumer buffer is full
+type BackpressurePolicy int
+
+const (
+	//Wait for slow consumer, stalls all consumers
+	PolicyBlock BackpressurePolicy = iota
+	//Discard oldest buffered event
+	PolicyDropOldest
+	//Send ErrorEvent with ErrSlowConsumer and close consumer channel
+	PolicyError
+)
+
+var ErrSlowConsumer = errors.New("consumer buffer is full")
+
+type Event interface {
+	isEvent()
+}
+
+type TransactionEvent struct {
+	Raw         []byte
+	TxID        string
+	Transaction *transaction.Transaction
+}
+
+type MerkleBlockEvent struct {
+	Raw         []byte
+	Height      int
+	MerkleBlock *block.MerkleBlock
+	//Hashes of matched transactions (internal byte order)
+	Matches [][32]byte
+}
+
+type InstantLockEvent struct {
+	Raw  []byte
+	TxID string
+	Lock *block.InstantSendLock
+}
+
+//Payload decoding failure or stream termination
+type ErrorEvent struct {
+	Err error
+}
+
+func (TransactionEvent) isEvent() {}
+func (MerkleBlockEvent) isEvent() {}
+func (InstantLockEvent) isEvent() {}
+func (ErrorEvent) isEvent()       {}
+
+type consumer struct {
+	events chan Event
+	policy BackpressurePolicy
+	closed bool
+}
+
+//Decodes managed subscription responses and fans them out to consumers
+type Subscriber struct {
+	sync.Mutex
+	ctx          context.Context
+	subscription *Subscription
+	consumers    []*consumer
+	started      bool
+}
+
+func NewSubscriber(ctx context.Context, client interfaces.IClient, params structures.SubscribeToTransactionsWithProofsRequest, options Options) (*Subscriber, error) {
+	subscription, err := Subscribe(ctx, client, params, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Subscriber{
+		ctx:          ctx,
+		subscription: subscription,
+	}, nil
+}
+
+//Register consumer, must be called before Start.
+//Channel is closed when ctx is done or stream finished
+func (s *Subscriber) Events(buffer int, policy BackpressurePolicy) <-chan Event {
+	s.Lock()
+	defer s.Unlock()
+
+	if buffer < 1 {
+		buffer = 1
+	}
+
+	c := &consumer{
+		events: make(chan Event, buffer),
+		policy: policy,
+	}
+
+	if s.started {
+		close(c.events)
+		c.closed = true
+	}
+
+	s.consumers = append(s.consumers, c)
+
+	return c.events
+}
+
+//Start delivering events to registered consumers
+func (s *Subscriber) Start() {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.started {
+		return
+	}
+
+	s.started = true
+
+	go s.run()
+}
+
+func (s *Subscriber) run() {
+	defer func() {
+		s.Lock()
+		defer s.Unlock()
+
+		for _, c := range s.consumers {
+			if !c.closed {
+				c.closed = true
+				close(c.events)
+			}
+		}
+	}()
+
+	for {
+		r, err := s.subscription.Recv()
+
+		if err != nil {
+			if s.ctx.Err() == nil {
+				s.publish(ErrorEvent{Err: err})
+			}
+
+			return
+		}
+
+		for _, e := range s.decode(r) {
+			s.publish(e)
+		}
+	}
+}
+
+func (s *Subscriber) decode(r *proto.TransactionsWithProofsResponse) []Event {
+	var events []Event
+
+	for _, raw := range r.GetRawTransactions().GetTransactions() {
+		tx, err := transaction.Parse(raw)
+
+		if err != nil {
+			events = append(events, ErrorEvent{Err: err})
+
+			continue
+		}
+
+		events = append(events, TransactionEvent{Raw: raw, TxID: tx.TxID(), Transaction: tx})
+	}
+
+	for _, raw := range r.GetInstantSendLockMessages().GetMessages() {
+		lock, err := block.ParseInstantSendLock(raw)
+
+		if err != nil {
+			events = append(events, ErrorEvent{Err: err})
+
+			continue
+		}
+
+		events = append(events, InstantLockEvent{Raw: raw, TxID: lock.TxID(), Lock: lock})
+	}
+
+	if raw := r.GetRawMerkleBlock(); raw != nil {
+		merkleBlock, err := block.ParseMerkleBlock(raw)
+
+		if err != nil {
+			return append(events, ErrorEvent{Err: err})
+		}
+
+		matches, err := merkleBlock.ExtractMatches()
+
+		if err != nil {
+			return append(events, ErrorEvent{Err: err})
+		}
+
+		events = append(events, MerkleBlockEvent{
+			Raw:         raw,
+			Height:      s.subscription.LastProcessedHeight(),
+			MerkleBlock: merkleBlock,
+			Matches:     matches,
+		})
+	}
+
+	return events
+}
+
+func (s *Subscriber) publish(e Event) {
+	s.Lock()
+	consumers := append([]*consumer(nil), s.consumers...)
+	s.Unlock()
+
+	for _, c := range consumers {
+		if c.closed {
+			continue
+		}
+
+		switch c.policy {
+		case PolicyBlock:
+			select {
+			case c.events <- e:
+			case <-s.ctx.Done():
+			}
+		case PolicyDropOldest:
+			for sent := false; !sent; {
+				select {
+				case c.events <- e:
+					sent = true
+				default:
+					select {
+					case <-c.events:
+					default:
+					}
+				}
+			}
+		case PolicyError:
+			select {
+			case c.events <- e:
+			default:
+				//Make room for error and disconnect consumer
+				select {
+				case <-c.events:
+				default:
+				}
+
+				c.events <- ErrorEvent{Err: ErrSlowConsumer}
+
+				s.Lock()
+				c.closed = true
+				close(c.events)
+				s.Unlock()
+			}
+		}
+	}
+}
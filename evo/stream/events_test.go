@@ -0,0 +1,203 @@
+package stream_test
+
+import (
+	"context"
+	"github.com/co-in/dash-dapi/evo"
+	"github.com/co-in/dash-dapi/evo/dapitest"
+	"github.com/co-in/dash-dapi/evo/stream"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"testing"
+	"time"
+)
+
+//Transactions sent by fake node to every subscription, stream is held open after them
+func newEventServer(t *testing.T, count int) (*dapitest.Server, []string) {
+	t.Helper()
+	s, err := dapitest.NewServer()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(s.Close)
+
+	var events []dapitest.StreamEvent
+	var txIDs []string
+
+	for i := 0; i < count; i++ {
+		tx := &transaction.Transaction{
+			Version: transaction.DefaultVersion,
+			Inputs:  []transaction.Input{{PreviousOutput: transaction.OutPoint{Hash: [32]byte{1}}, Script: []byte{1, 1}, Sequence: transaction.DefaultSequence}},
+			Outputs: []transaction.Output{{Value: int64(1000 + i), Script: []byte{0x6a}}},
+		}
+
+		events = append(events, dapitest.Transactions(tx.Bytes()))
+		txIDs = append(txIDs, tx.TxID())
+	}
+
+	s.SetStream(dapitest.MethodSubscribeToTransactionsWithProofs, append(events, dapitest.Hold())...)
+
+	return s, txIDs
+}
+
+func newSubscriber(t *testing.T, ctx context.Context, s *dapitest.Server) *stream.Subscriber {
+	t.Helper()
+	c, err := evo.NewClient(nil, s.Host, s.JSONRPCPort, s.GRPCPort, evo.WithTimeout(5*time.Second))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := 100
+	subscriber, err := stream.NewSubscriber(ctx, c, structures.SubscribeToTransactionsWithProofsRequest{
+		BloomFilter:     structures.BloomFilterRequest{Data: []byte{0xff}, HashFunc: 3},
+		FromBlockHeight: &from,
+	}, stream.DefaultOptions())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return subscriber
+}
+
+func receive(t *testing.T, events <-chan stream.Event) stream.Event {
+	t.Helper()
+
+	select {
+	case e, ok := <-events:
+		if !ok {
+			t.Fatal("events are closed")
+		}
+
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event")
+	}
+
+	return nil
+}
+
+func receiveTransaction(t *testing.T, events <-chan stream.Event, txID string) {
+	t.Helper()
+	e, ok := receive(t, events).(stream.TransactionEvent)
+
+	if !ok || e.TxID != txID {
+		t.Fatalf("event %+v, expected transaction %s", e, txID)
+	}
+}
+
+func expectClosed(t *testing.T, events <-chan stream.Event) {
+	t.Helper()
+
+	select {
+	case e, ok := <-events:
+		if ok {
+			t.Fatalf("event %+v, expected closed channel", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("events are not closed")
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	s, txIDs := newEventServer(t, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscriber := newSubscriber(t, ctx, s)
+	first := subscriber.Events(1, stream.PolicyBlock)
+	second := subscriber.Events(1, stream.PolicyBlock)
+	subscriber.Start()
+
+	//Consumer registered after start gets closed channel
+	expectClosed(t, subscriber.Events(1, stream.PolicyBlock))
+
+	for _, txID := range txIDs {
+		receiveTransaction(t, first, txID)
+		receiveTransaction(t, second, txID)
+	}
+
+	cancel()
+	expectClosed(t, first)
+	expectClosed(t, second)
+
+	if requests := s.Requests(dapitest.MethodSubscribeToTransactionsWithProofs); len(requests) != 1 {
+		t.Fatalf("consumers opened %d streams", len(requests))
+	}
+}
+
+func TestPolicyBlock(t *testing.T) {
+	s, txIDs := newEventServer(t, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscriber := newSubscriber(t, ctx, s)
+	slow := subscriber.Events(1, stream.PolicyBlock)
+	fast := subscriber.Events(3, stream.PolicyBlock)
+	subscriber.Start()
+
+	receiveTransaction(t, fast, txIDs[0])
+
+	//Full buffer of slow consumer stalls delivery to every consumer
+	select {
+	case e := <-fast:
+		t.Fatalf("event %+v delivered while slow consumer is full", e)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	for _, txID := range txIDs {
+		receiveTransaction(t, slow, txID)
+	}
+
+	receiveTransaction(t, fast, txIDs[1])
+	receiveTransaction(t, fast, txIDs[2])
+}
+
+func TestPolicyDropOldest(t *testing.T) {
+	s, txIDs := newEventServer(t, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscriber := newSubscriber(t, ctx, s)
+	reader := subscriber.Events(1, stream.PolicyBlock)
+	dropping := subscriber.Events(1, stream.PolicyDropOldest)
+	subscriber.Start()
+
+	for _, txID := range txIDs {
+		receiveTransaction(t, reader, txID)
+	}
+
+	//Channels are closed after last event was published to every consumer
+	cancel()
+	expectClosed(t, reader)
+
+	receiveTransaction(t, dropping, txIDs[2])
+	expectClosed(t, dropping)
+}
+
+func TestPolicyError(t *testing.T) {
+	s, txIDs := newEventServer(t, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscriber := newSubscriber(t, ctx, s)
+	reader := subscriber.Events(1, stream.PolicyBlock)
+	failing := subscriber.Events(1, stream.PolicyError)
+	subscriber.Start()
+
+	for _, txID := range txIDs {
+		receiveTransaction(t, reader, txID)
+	}
+
+	cancel()
+	expectClosed(t, reader)
+
+	//Buffered event is replaced by error, consumer is disconnected
+	if e, ok := receive(t, failing).(stream.ErrorEvent); !ok || e.Err != stream.ErrSlowConsumer {
+		t.Fatalf("event %+v, expected %s", e, stream.ErrSlowConsumer)
+	}
+
+	expectClosed(t, failing)
+}
@@ -0,0 +1,394 @@
+package stream
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/block"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"github.com/co-in/dash-dapi/metrics"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+//Attempts to find node different from the failed one
+const selectNodeTries = 10
+
+//Reported after subscription moved to another node
+type ReconnectEvent struct {
+	From string
+	To   string
+	//Stream resumed from this block height
+	FromBlockHeight int
+	//Reason of reconnection
+	Err error
+}
+
+type Options struct {
+	//Zero means unlimited reconnection attempts
+	MaxRetries int
+	RetryDelay time.Duration
+	//Called from Recv goroutine
+	OnReconnect func(ReconnectEvent)
+	//Reconnections and received events are counted when set
+	Metrics *metrics.Registry
+}
+
+//Managed SubscribeToTransactionsWithProofs stream, resumes on another node after failures
+type Subscription struct {
+	sync.Mutex
+	ctx     context.Context
+	client  interfaces.IClient
+	params  structures.SubscribeToTransactionsWithProofsRequest
+	options Options
+	node    interfaces.IConnection
+	stream  proto.TransactionsFilterStream_SubscribeToTransactionsWithProofsClient
+	//Height following last received merkle block, stream is resumed from it
+	nextHeight int
+	//Height stream started at, used to shorten Count on resume
+	startHeight int
+	//Transactions and locks delivered since last fully processed block
+	pending map[[32]byte]bool
+	//Filter duplicates after reconnect until first merkle block
+	replay map[[32]byte]bool
+	//Nil unless Options.Metrics is set
+	reconnects *metrics.CounterVec
+	events     *metrics.CounterVec
+}
+
+func DefaultOptions() Options {
+	return Options{
+		RetryDelay: 5 * time.Second,
+	}
+}
+
+//Start managed subscription. When FromBlockHeight is missing, stream starts at current tip.
+//FromBlockHash is not supported, because stream must be resumable by height
+func Subscribe(ctx context.Context, client interfaces.IClient, params structures.SubscribeToTransactionsWithProofsRequest, options Options) (*Subscription, error) {
+	if params.FromBlockHash != nil {
+		return nil, errors.New("FromBlockHash is not supported, use FromBlockHeight")
+	}
+
+	s := &Subscription{
+		ctx:     ctx,
+		client:  client,
+		params:  params,
+		options: options,
+		pending: make(map[[32]byte]bool),
+	}
+
+	if options.Metrics != nil {
+		s.reconnects = options.Metrics.Counter("dapi_stream_reconnects_total", "Subscriptions moved to another node")
+		s.events = options.Metrics.Counter("dapi_stream_events_total", "Deduplicated stream events by type", "type")
+	}
+
+	node, err := client.SelectRandomNode()
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.node = node.WithContext(ctx)
+
+	if params.FromBlockHeight != nil {
+		s.nextHeight = *params.FromBlockHeight
+	} else {
+		status, err := s.node.GetStatus()
+
+		if err != nil {
+			return nil, err
+		}
+
+		s.nextHeight = int(status.Blocks)
+	}
+
+	s.startHeight = s.nextHeight
+
+	err = s.open()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+//Height of last block, which was received completely (transactions and merkle block)
+func (s *Subscription) LastProcessedHeight() int {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.nextHeight - 1
+}
+
+func (s *Subscription) NodeName() string {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.node.GetNodeName()
+}
+
+func (s *Subscription) open() error {
+	params := s.params
+	height := s.nextHeight
+	params.FromBlockHeight = &height
+
+	//Zero count is unlimited stream for DAPI, so used up count ends subscription instead of reopening it
+	if s.params.Count != nil && *s.params.Count > 0 {
+		count := *s.params.Count - (s.nextHeight - s.startHeight)
+
+		if count <= 0 {
+			return io.EOF
+		}
+
+		params.Count = &count
+	}
+
+	stream, err := s.node.SubscribeToTransactionsWithProofs(params)
+
+	if err != nil {
+		return err
+	}
+
+	s.stream = stream
+
+	return nil
+}
+
+//Next deduplicated response. Returns error only when ctx is done, stream finished or retries exhausted
+func (s *Subscription) Recv() (*proto.TransactionsWithProofsResponse, error) {
+	for {
+		r, err := s.stream.Recv()
+
+		if err == nil {
+			r, err = s.process(r)
+
+			if err == nil && r == nil {
+				continue
+			}
+
+			if err == nil {
+				return r, nil
+			}
+		}
+
+		if s.ctx.Err() != nil {
+			return nil, s.ctx.Err()
+		}
+
+		//Requested blocks were sent, merkle blocks are sent only for blocks with matches
+		if err == io.EOF && s.params.Count != nil {
+			return nil, io.EOF
+		}
+
+		err = s.reconnect(err)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (s *Subscription) reconnect(reason error) error {
+	from := s.node.GetNodeName()
+
+	for attempt := 1; s.options.MaxRetries == 0 || attempt <= s.options.MaxRetries; attempt++ {
+		select {
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		case <-time.After(s.options.RetryDelay):
+		}
+
+		node, err := s.selectOtherNode(from)
+
+		if err != nil {
+			reason = err
+
+			continue
+		}
+
+		s.Lock()
+		s.node = node.WithContext(s.ctx)
+		s.Unlock()
+
+		err = s.open()
+
+		if err == io.EOF {
+			return err
+		}
+
+		if err != nil {
+			reason = err
+
+			continue
+		}
+
+		s.replay = s.pending
+		s.pending = make(map[[32]byte]bool)
+
+		if s.reconnects != nil {
+			s.reconnects.Inc()
+		}
+
+		if s.options.OnReconnect != nil {
+			s.options.OnReconnect(ReconnectEvent{
+				From:            from,
+				To:              node.GetNodeName(),
+				FromBlockHeight: s.nextHeight,
+				Err:             reason,
+			})
+		}
+
+		return nil
+	}
+
+	return reason
+}
+
+//Node different from failed one, failed node is returned only when pool has no other available node
+func (s *Subscription) selectOtherNode(failed string) (interfaces.IConnection, error) {
+	for i := 0; i < selectNodeTries; i++ {
+		node, err := s.client.SelectRandomNode()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if node.GetNodeName() != failed {
+			return node, nil
+		}
+	}
+
+	//Random selection prefers failed node (e.g. other nodes are banned), try every other node of pool
+	for name := range s.client.NodeRecords() {
+		if name == failed {
+			continue
+		}
+
+		node, err := s.client.SelectNode(name)
+
+		if err == nil && node.CheckAvailability() {
+			return node, nil
+		}
+	}
+
+	return s.client.SelectNode(failed)
+}
+
+//Drop duplicates and track block height, returns nil when nothing left.
+//Error means height of merkle block is unknown, stream is resumed from last known height
+func (s *Subscription) process(r *proto.TransactionsWithProofsResponse) (*proto.TransactionsWithProofsResponse, error) {
+	if transactions := r.GetRawTransactions(); transactions != nil {
+		var fresh [][]byte
+
+		for _, raw := range transactions.GetTransactions() {
+			if s.deliver(transaction.DoubleHash(raw)) {
+				fresh = append(fresh, raw)
+			}
+		}
+
+		if len(fresh) == 0 {
+			return nil, nil
+		}
+
+		transactions.Transactions = fresh
+		s.count("transaction", len(fresh))
+	}
+
+	if locks := r.GetInstantSendLockMessages(); locks != nil {
+		var fresh [][]byte
+
+		for _, message := range locks.GetMessages() {
+			if s.deliver(transaction.DoubleHash(message)) {
+				fresh = append(fresh, message)
+			}
+		}
+
+		if len(fresh) == 0 {
+			return nil, nil
+		}
+
+		locks.Messages = fresh
+		s.count("instant_lock", len(fresh))
+	}
+
+	if raw := r.GetRawMerkleBlock(); raw != nil {
+		height, err := s.merkleBlockHeight(raw)
+
+		if err != nil {
+			return nil, fmt.Errorf("height of merkle block: %w", err)
+		}
+
+		//Merkle block follows its transactions, so block is complete
+		s.Lock()
+		s.nextHeight = height + 1
+		s.Unlock()
+
+		s.pending = make(map[[32]byte]bool)
+		s.replay = nil
+		s.count("merkle_block", 1)
+	}
+
+	return r, nil
+}
+
+//Stream skips blocks without matches, so height is derived from previous block named by header:
+//block following last received one, otherwise height of previous block is read from its coinbase
+func (s *Subscription) merkleBlockHeight(raw []byte) (int, error) {
+	header, err := block.ParseHeader(raw)
+
+	if err != nil {
+		return 0, err
+	}
+
+	prevHash := hex.EncodeToString(transaction.ReverseBytes(header.PrevBlock[:]))
+
+	s.Lock()
+	node := s.node
+	last := s.nextHeight - 1
+	s.Unlock()
+
+	if last >= 0 {
+		hash, err := node.GetBlockHash(last)
+
+		if err == nil && strings.EqualFold(string(*hash), prevHash) {
+			return last + 1, nil
+		}
+	}
+
+	prev, err := node.GetBlock(structures.BlockRequest{Hash: &prevHash})
+
+	if err != nil {
+		return 0, err
+	}
+
+	height, err := block.Height(prev.GetBlock())
+
+	if err != nil {
+		return 0, err
+	}
+
+	return height + 1, nil
+}
+
+func (s *Subscription) count(eventType string, n int) {
+	if s.events != nil {
+		s.events.Add(float64(n), eventType)
+	}
+}
+
+func (s *Subscription) deliver(hash [32]byte) bool {
+	if s.replay[hash] {
+		return false
+	}
+
+	s.pending[hash] = true
+
+	return true
+}
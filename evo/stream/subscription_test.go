@@ -0,0 +1,234 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo"
+	"github.com/co-in/dash-dapi/evo/dapitest"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	"github.com/co-in/dash-dapi/evo/network"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/evo/stream"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"testing"
+	"time"
+)
+
+//Fake hash of block at height, RPC byte order
+func blockHash(height int) string {
+	return fmt.Sprintf("%064x", height)
+}
+
+//Merkle block with one matched transaction, header names block at prevHeight
+func merkleBlock(prevHeight int) []byte {
+	prev, _ := hex.DecodeString(blockHash(prevHeight))
+	data := make([]byte, 80, 80+4+1+32+2)
+	copy(data[4:36], transaction.ReverseBytes(prev))
+	data = append(data, 1, 0, 0, 0, 1)
+	data = append(data, make([]byte, 32)...)
+
+	return append(data, 1, 1)
+}
+
+//Raw block with DIP4 coinbase of height
+func rawBlock(height int) []byte {
+	payload := make([]byte, 2+4+32)
+	binary.LittleEndian.PutUint16(payload, 2)
+	binary.LittleEndian.PutUint32(payload[2:], uint32(height))
+
+	coinbase := &transaction.Transaction{
+		Version:      3,
+		Type:         5,
+		Inputs:       []transaction.Input{{PreviousOutput: transaction.OutPoint{Index: 0xffffffff}, Script: []byte{1, 1}, Sequence: transaction.DefaultSequence}},
+		Outputs:      []transaction.Output{{Script: []byte{0x6a}}},
+		ExtraPayload: payload,
+	}
+
+	return append(append(make([]byte, 80), 1), coinbase.Bytes()...)
+}
+
+//Fake node of regtest knowing hashes of blocks up to 1000
+func newChainServer(t *testing.T, host string, jsonRPCPort uint16, gRPCPort uint16) *dapitest.Server {
+	t.Helper()
+	s, err := dapitest.NewServerAt(host, jsonRPCPort, gRPCPort)
+
+	if err != nil {
+		t.Skipf("fake node on %s: %s", host, err)
+	}
+
+	t.Cleanup(s.Close)
+
+	s.SetResponse(dapitest.MethodGetStatus, &proto.GetStatusResponse{Network: network.RegTestName, Blocks: 1000, Connections: 8})
+	s.Handle(dapitest.MethodGetBlockHash, func(ctx context.Context, request interface{}) (interface{}, error) {
+		var params struct {
+			Height *int `json:"height"`
+		}
+
+		err := json.Unmarshal(request.(json.RawMessage), &params)
+
+		if err != nil || params.Height == nil {
+			return nil, fmt.Errorf("params %s", request)
+		}
+
+		return blockHash(*params.Height), nil
+	})
+	s.Handle(dapitest.MethodGetBlock, func(ctx context.Context, request interface{}) (interface{}, error) {
+		hash := request.(*proto.GetBlockRequest).GetHash()
+
+		for height := 0; height <= 1000; height++ {
+			if blockHash(height) == hash {
+				return &proto.GetBlockResponse{Block: rawBlock(height)}, nil
+			}
+		}
+
+		return nil, status.Error(codes.NotFound, "unknown block")
+	})
+
+	//Block 100 follows requested height, block 150 is next block with matches
+	s.SetStream(dapitest.MethodSubscribeToTransactionsWithProofs,
+		dapitest.MerkleBlock(merkleBlock(99)),
+		dapitest.MerkleBlock(merkleBlock(149)),
+		dapitest.Fail(status.Error(codes.Unavailable, "node is down")),
+	)
+
+	return s
+}
+
+//Client of two fake nodes
+func newChainClient(t *testing.T) (interfaces.IClient, *dapitest.Server, *dapitest.Server) {
+	t.Helper()
+	first := newChainServer(t, "127.0.0.1", 0, 0)
+	second := newChainServer(t, "127.0.0.2", first.JSONRPCPort, first.GRPCPort)
+
+	params := &network.Params{Name: network.RegTestName, StatusNetworks: []string{network.RegTestName}}
+	c, err := evo.NewClient(nil, first.Host, first.JSONRPCPort, first.GRPCPort, evo.WithNetwork(params), evo.WithTimeout(5*time.Second))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.AddNode(second.Host, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c, first, second
+}
+
+func TestSubscriptionHeight(t *testing.T) {
+	c, first, second := newChainClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	from, count := 100, 200
+	var reconnect stream.ReconnectEvent
+	options := stream.Options{RetryDelay: time.Millisecond, OnReconnect: func(e stream.ReconnectEvent) {
+		reconnect = e
+	}}
+
+	s, err := stream.Subscribe(ctx, c, structures.SubscribeToTransactionsWithProofsRequest{
+		BloomFilter:     structures.BloomFilterRequest{Data: []byte{0xff}, HashFunc: 3},
+		FromBlockHeight: &from,
+		Count:           &count,
+	}, options)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failing := s.NodeName()
+
+	//Previous block of first merkle block is requested height - 1, block hash alone proves its height
+	//Previous block of second one is not last processed, its height is read from coinbase
+	for i, expected := range []int{100, 150} {
+		_, err = s.Recv()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if height := s.LastProcessedHeight(); height != expected {
+			t.Fatalf("height %d, expected %d", height, expected)
+		}
+
+		if blocks := len(first.Requests(dapitest.MethodGetBlock)) + len(second.Requests(dapitest.MethodGetBlock)); blocks != i {
+			t.Fatalf("%d blocks requested for height of merkle block %d", blocks, expected)
+		}
+	}
+
+	//Failed stream is resumed on other node after last merkle block
+	_, err = s.Recv()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reconnect.From != failing || reconnect.To == failing || reconnect.FromBlockHeight != 151 {
+		t.Fatalf("reconnect %+v from %s", reconnect, failing)
+	}
+
+	resumed := first
+
+	if failing == first.Host {
+		resumed = second
+	}
+
+	requests := resumed.Requests(dapitest.MethodSubscribeToTransactionsWithProofs)
+
+	if len(requests) != 1 {
+		t.Fatalf("requests of resumed node %v", requests)
+	}
+
+	request := requests[0].(*proto.TransactionsWithProofsRequest)
+
+	if request.GetFromBlockHeight() != 151 || request.GetCount() != 149 {
+		t.Fatalf("resumed from %d count %d, expected from 151 count 149", request.GetFromBlockHeight(), request.GetCount())
+	}
+}
+
+func TestSubscriptionCountUsedUp(t *testing.T) {
+	c, first, second := newChainClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	//Blocks 100-150 are requested, stream fails after merkle block 150
+	from, count := 100, 51
+	s, err := stream.Subscribe(ctx, c, structures.SubscribeToTransactionsWithProofsRequest{
+		BloomFilter:     structures.BloomFilterRequest{Data: []byte{0xff}, HashFunc: 3},
+		FromBlockHeight: &from,
+		Count:           &count,
+	}, stream.Options{RetryDelay: time.Millisecond})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err = s.Recv()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err = s.Recv()
+
+	if err != io.EOF {
+		t.Fatalf("error %v, expected io.EOF", err)
+	}
+
+	//Zero count would open endless stream
+	if requests := len(first.Requests(dapitest.MethodSubscribeToTransactionsWithProofs)) + len(second.Requests(dapitest.MethodSubscribeToTransactionsWithProofs)); requests != 1 {
+		t.Fatalf("stream opened %d times", requests)
+	}
+}
@@ -97,3 +97,9 @@ type SubscribeToTransactionsWithProofsRequest struct {
 	FromBlockHeight       *int               `json:"from_block_height,omitempty"`
 	SendTransactionHashes *bool              `json:"send_transaction_hashes,omitempty"`
 }
+
+type BlockHeadersWithChainLocksRequest struct {
+	Count           *int    `json:"count,omitempty"`
+	FromBlockHash   *[]byte `json:"from_block_hash,omitempty"`
+	FromBlockHeight *int    `json:"from_block_height,omitempty"`
+}
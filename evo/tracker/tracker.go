@@ -0,0 +1,633 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"github.com/co-in/dash-dapi/evo/block"
+	"github.com/co-in/dash-dapi/evo/bloom"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"time"
+)
+
+type State int
+
+const (
+	//Transaction accepted by node (or returned to mempool after reorg)
+	StateBroadcast State = iota
+	//Transaction sent again to another node
+	StateRebroadcast
+	StateInstantLocked
+	StateMined
+	StateChainLocked
+	//Confirmations count changed
+	StateConfirmed
+	//Transaction was not seen by network after all rebroadcasts
+	StateDropped
+	//Tracking stopped by error
+	StateFailed
+)
+
+const (
+	//How deep to search block of merkle proof below the tip
+	maxLookBack = 100
+	//Attempts to find node which did not receive transaction yet
+	selectNodeTries = 10
+)
+
+var stateNames = map[State]string{
+	StateBroadcast:     "broadcast",
+	StateRebroadcast:   "rebroadcast",
+	StateInstantLocked: "instant-locked",
+	StateMined:         "mined",
+	StateChainLocked:   "chain-locked",
+	StateConfirmed:     "confirmed",
+	StateDropped:       "dropped",
+	StateFailed:        "failed",
+}
+
+func (s State) String() string {
+	return stateNames[s]
+}
+
+type Event struct {
+	State State
+	TxID  string
+	Node  string
+	//Set from StateMined
+	BlockHeight   int
+	Confirmations int
+	Error         error
+}
+
+type Options struct {
+	//Tracking stops after transaction gets Confirmations blocks
+	Confirmations int
+	//Transaction is sent to another node when it is not seen in stream during timeout
+	RebroadcastTimeout time.Duration
+	MaxRebroadcasts    int
+	//Interval of chain tip polling for confirmations
+	PollInterval  time.Duration
+	AllowHighFees bool
+	BypassLimits  bool
+}
+
+type Tracker struct {
+	client  interfaces.IClient
+	options Options
+}
+
+type tracking struct {
+	*Tracker
+	ctx           context.Context
+	cancel        context.CancelFunc
+	raw           []byte
+	hash          [32]byte
+	txID          string
+	events        chan Event
+	node          interfaces.IConnection
+	nodes         map[string]bool
+	startHeight   int
+	rebroadcasts  int
+	seen          bool
+	instantLocked bool
+	minedHeight   int
+	merkleRoot    [32]byte
+	transactions  chan transactionsMessage
+	//Stops transaction stream before it is subscribed again
+	cancelTransactions context.CancelFunc
+	//Stops chainlock stream of previous mined block
+	cancelChainLocks context.CancelFunc
+	chainLocked      bool
+	confirmations    int
+}
+
+type transactionsMessage struct {
+	response *proto.TransactionsWithProofsResponse
+	err      error
+}
+
+type chainLocksMessage struct {
+	response *proto.BlockHeadersWithChainLocksResponse
+	err      error
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Confirmations:      6,
+		RebroadcastTimeout: time.Minute,
+		MaxRebroadcasts:    3,
+		PollInterval:       30 * time.Second,
+	}
+}
+
+//Non-positive durations and confirmations of options are replaced by defaults
+func NewTracker(client interfaces.IClient, options Options) *Tracker {
+	defaults := DefaultOptions()
+
+	if options.Confirmations <= 0 {
+		options.Confirmations = defaults.Confirmations
+	}
+
+	if options.RebroadcastTimeout <= 0 {
+		options.RebroadcastTimeout = defaults.RebroadcastTimeout
+	}
+
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaults.PollInterval
+	}
+
+	if options.MaxRebroadcasts < 0 {
+		options.MaxRebroadcasts = 0
+	}
+
+	return &Tracker{
+		client:  client,
+		options: options,
+	}
+}
+
+//Broadcast raw transaction and report its state transitions.
+//Channel is closed when transaction gets required confirmations, is dropped, fails or ctx is done
+func (t *Tracker) Send(ctx context.Context, raw []byte) (<-chan Event, error) {
+	tx, err := transaction.Parse(raw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := t.client.SelectRandomNode()
+
+	if err != nil {
+		return nil, err
+	}
+
+	//Streams and requests of tracking end with it, even when caller never cancels ctx
+	ctx, cancel := context.WithCancel(ctx)
+	node = node.WithContext(ctx)
+
+	_, err = node.SendTransaction(raw, t.options.AllowHighFees, t.options.BypassLimits)
+
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	s := &tracking{
+		Tracker: t,
+		ctx:     ctx,
+		cancel:  cancel,
+		raw:     raw,
+		hash:    tx.Hash(),
+		txID:    tx.TxID(),
+		events:  make(chan Event, 16),
+		node:    node,
+		nodes:   map[string]bool{node.GetNodeName(): true},
+	}
+
+	go s.run()
+
+	return s.events, nil
+}
+
+//Same as Send, callback is called sequentially from tracking goroutine
+func (t *Tracker) SendWithCallback(ctx context.Context, raw []byte, callback func(Event)) error {
+	events, err := t.Send(ctx, raw)
+
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for e := range events {
+			callback(e)
+		}
+	}()
+
+	return nil
+}
+
+func (s *tracking) emit(e Event) {
+	e.TxID = s.txID
+
+	if e.Node == "" {
+		e.Node = s.node.GetNodeName()
+	}
+
+	select {
+	case s.events <- e:
+	case <-s.ctx.Done():
+	}
+}
+
+func (s *tracking) fail(err error) {
+	s.emit(Event{State: StateFailed, Error: err})
+}
+
+func (s *tracking) run() {
+	defer close(s.events)
+	defer s.cancel()
+
+	s.emit(Event{State: StateBroadcast})
+
+	status, err := s.node.GetStatus()
+
+	if err != nil {
+		s.fail(err)
+
+		return
+	}
+
+	s.startHeight = int(status.Blocks)
+	err = s.subscribeTransactions()
+
+	if err != nil {
+		s.fail(err)
+
+		return
+	}
+
+	var chainLocks chan chainLocksMessage
+	rebroadcast := time.NewTimer(s.options.RebroadcastTimeout)
+	poll := time.NewTicker(s.options.PollInterval)
+
+	defer rebroadcast.Stop()
+	defer poll.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case m := <-s.transactions:
+			if m.err != nil {
+				//Stream broken, continue on another node
+				err = s.resubscribe()
+
+				if err != nil {
+					s.fail(err)
+
+					return
+				}
+
+				continue
+			}
+
+			mined := s.minedHeight
+
+			err = s.handleTransactions(m.response)
+
+			if err != nil {
+				s.fail(err)
+
+				return
+			}
+
+			if mined == 0 && s.minedHeight != 0 {
+				chainLocks, err = s.subscribeChainLocks()
+
+				if err != nil {
+					s.fail(err)
+
+					return
+				}
+
+				if s.updateConfirmations() {
+					return
+				}
+			}
+		case m := <-chainLocks:
+			if m.err != nil {
+				chainLocks = nil
+
+				continue
+			}
+
+			s.handleChainLocks(m.response)
+		case <-rebroadcast.C:
+			if s.seen {
+				continue
+			}
+
+			if s.rebroadcasts >= s.options.MaxRebroadcasts {
+				s.emit(Event{State: StateDropped})
+
+				return
+			}
+
+			s.rebroadcasts++
+			s.rebroadcast()
+			rebroadcast.Reset(s.options.RebroadcastTimeout)
+		case <-poll.C:
+			if s.minedHeight != 0 && s.updateConfirmations() {
+				return
+			}
+		}
+	}
+}
+
+func (s *tracking) filter() structures.BloomFilterRequest {
+	f := bloom.New(1, 0.0001, 0, bloom.UpdateNone)
+	f.Add(s.hash[:])
+
+	return f.Request()
+}
+
+//Subscribe from broadcast height, previous stream is closed
+func (s *tracking) subscribeTransactions() error {
+	s.stopTransactions()
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	height := s.startHeight
+	stream, err := s.node.WithContext(ctx).SubscribeToTransactionsWithProofs(structures.SubscribeToTransactionsWithProofsRequest{
+		BloomFilter:     s.filter(),
+		FromBlockHeight: &height,
+	})
+
+	if err != nil {
+		cancel()
+
+		return err
+	}
+
+	s.cancelTransactions = cancel
+
+	messages := make(chan transactionsMessage)
+	s.transactions = messages
+
+	go func() {
+		for {
+			r, err := stream.Recv()
+
+			select {
+			case messages <- transactionsMessage{response: r, err: err}:
+			case <-ctx.Done():
+				return
+			}
+
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *tracking) stopTransactions() {
+	if s.cancelTransactions != nil {
+		s.cancelTransactions()
+		s.cancelTransactions = nil
+	}
+}
+
+//Subscribe from mined block, stream of block mined before reorg is closed
+func (s *tracking) subscribeChainLocks() (chan chainLocksMessage, error) {
+	s.stopChainLocks()
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	height := s.minedHeight
+	stream, err := s.node.WithContext(ctx).SubscribeToBlockHeadersWithChainLocks(structures.BlockHeadersWithChainLocksRequest{
+		FromBlockHeight: &height,
+	})
+
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	s.cancelChainLocks = cancel
+
+	messages := make(chan chainLocksMessage)
+
+	go func() {
+		for {
+			r, err := stream.Recv()
+
+			select {
+			case messages <- chainLocksMessage{response: r, err: err}:
+			case <-ctx.Done():
+				return
+			}
+
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
+func (s *tracking) stopChainLocks() {
+	if s.cancelChainLocks != nil {
+		s.cancelChainLocks()
+		s.cancelChainLocks = nil
+	}
+}
+
+//Select node, which did not receive transaction yet
+func (s *tracking) selectOtherNode() (interfaces.IConnection, error) {
+	for i := 0; i < selectNodeTries; i++ {
+		node, err := s.client.SelectRandomNode()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !s.nodes[node.GetNodeName()] {
+			s.nodes[node.GetNodeName()] = true
+
+			return node.WithContext(s.ctx), nil
+		}
+	}
+
+	return nil, errors.New("no other nodes available")
+}
+
+func (s *tracking) resubscribe() error {
+	node, err := s.selectOtherNode()
+
+	if err != nil {
+		return err
+	}
+
+	s.node = node
+
+	return s.subscribeTransactions()
+}
+
+func (s *tracking) rebroadcast() {
+	node, err := s.selectOtherNode()
+
+	if err != nil {
+		s.emit(Event{State: StateRebroadcast, Error: err})
+
+		return
+	}
+
+	_, err = node.SendTransaction(s.raw, s.options.AllowHighFees, s.options.BypassLimits)
+	s.emit(Event{State: StateRebroadcast, Node: node.GetNodeName(), Error: err})
+}
+
+func (s *tracking) handleTransactions(r *proto.TransactionsWithProofsResponse) error {
+	for _, raw := range r.GetRawTransactions().GetTransactions() {
+		if transaction.DoubleHash(raw) == s.hash {
+			s.seen = true
+		}
+	}
+
+	for _, message := range r.GetInstantSendLockMessages().GetMessages() {
+		lock, err := block.ParseInstantSendLock(message)
+
+		if err != nil || lock.TxHash != s.hash {
+			continue
+		}
+
+		s.seen = true
+
+		if !s.instantLocked {
+			s.instantLocked = true
+			s.emit(Event{State: StateInstantLocked})
+		}
+	}
+
+	if data := r.GetRawMerkleBlock(); data != nil && s.minedHeight == 0 {
+		merkleBlock, err := block.ParseMerkleBlock(data)
+
+		if err != nil {
+			return err
+		}
+
+		matches, err := merkleBlock.ExtractMatches()
+
+		if err != nil {
+			return err
+		}
+
+		for _, hash := range matches {
+			if hash != s.hash {
+				continue
+			}
+
+			height, err := s.locateBlock(merkleBlock.Header.MerkleRoot)
+
+			if err != nil {
+				return err
+			}
+
+			s.seen = true
+			s.minedHeight = height
+			s.merkleRoot = merkleBlock.Header.MerkleRoot
+			s.emit(Event{State: StateMined, BlockHeight: height})
+		}
+	}
+
+	return nil
+}
+
+func (s *tracking) handleChainLocks(r *proto.BlockHeadersWithChainLocksResponse) {
+	for _, message := range r.GetChainLockSignatureMessages().GetMessages() {
+		lock, err := block.ParseChainLock(message)
+
+		//ChainLock of block finalizes all its ancestors
+		if err != nil || s.chainLocked || s.minedHeight == 0 || int(lock.Height) < s.minedHeight {
+			continue
+		}
+
+		s.chainLocked = true
+		s.emit(Event{State: StateChainLocked, BlockHeight: s.minedHeight})
+	}
+}
+
+//Height of best chain block with given merkle root, transaction is not mined below height of its broadcast
+func (s *tracking) locateBlock(merkleRoot [32]byte) (int, error) {
+	status, err := s.node.GetStatus()
+
+	if err != nil {
+		return 0, err
+	}
+
+	tip := int(status.Blocks)
+
+	for height := tip; height >= s.startHeight && height > tip-maxLookBack; height-- {
+		root, err := s.merkleRootAt(height)
+
+		if err != nil {
+			return 0, err
+		}
+
+		if root == merkleRoot {
+			return height, nil
+		}
+	}
+
+	return 0, errors.New("block of merkle proof is not found in best chain")
+}
+
+func (s *tracking) merkleRootAt(height int) ([32]byte, error) {
+	response, err := s.node.GetBlock(structures.BlockRequest{Height: &height})
+
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	header, err := block.ParseHeader(response.GetBlock())
+
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return header.MerkleRoot, nil
+}
+
+//Refresh confirmations, returns true when tracking is finished
+func (s *tracking) updateConfirmations() bool {
+	status, err := s.node.GetStatus()
+
+	if err != nil {
+		s.emit(Event{State: StateConfirmed, Error: err})
+
+		return false
+	}
+
+	root, err := s.merkleRootAt(s.minedHeight)
+
+	if err != nil {
+		s.emit(Event{State: StateConfirmed, Error: err})
+
+		return false
+	}
+
+	//Block was reorganized, transaction returned to mempool. It may be mined again in any block after fork,
+	//which is searched from broadcast height like block of first merkle proof
+	if root != s.merkleRoot {
+		s.stopChainLocks()
+		s.minedHeight = 0
+		s.chainLocked = false
+		s.confirmations = 0
+		s.emit(Event{State: StateBroadcast})
+
+		err = s.subscribeTransactions()
+
+		if err != nil {
+			s.fail(err)
+
+			return true
+		}
+
+		return false
+	}
+
+	confirmations := int(status.Blocks) - s.minedHeight + 1
+
+	if confirmations > s.confirmations {
+		s.confirmations = confirmations
+		s.emit(Event{State: StateConfirmed, BlockHeight: s.minedHeight, Confirmations: confirmations})
+	}
+
+	return s.confirmations >= s.options.Confirmations
+}
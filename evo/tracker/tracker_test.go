@@ -0,0 +1,261 @@
+package tracker_test
+
+import (
+	"context"
+	"encoding/binary"
+	"github.com/co-in/dash-dapi/evo"
+	"github.com/co-in/dash-dapi/evo/dapitest"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/evo/tracker"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"sync"
+	"testing"
+	"time"
+)
+
+//Fake chain of node: tip height and height of block containing tracked transaction
+type chain struct {
+	sync.Mutex
+	tip   int
+	mined int
+}
+
+func (c *chain) set(tip int, mined int) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.tip, c.mined = tip, mined
+}
+
+func testTransaction() *transaction.Transaction {
+	return &transaction.Transaction{
+		Version: transaction.DefaultVersion,
+		Inputs:  []transaction.Input{{PreviousOutput: transaction.OutPoint{Hash: [32]byte{1}}, Script: []byte{1, 1}, Sequence: transaction.DefaultSequence}},
+		Outputs: []transaction.Output{{Value: 1000, Script: []byte{0x6a}}},
+	}
+}
+
+//Header of block with merkle root
+func header(merkleRoot [32]byte) []byte {
+	data := make([]byte, 80)
+	copy(data[36:68], merkleRoot[:])
+
+	return data
+}
+
+//Merkle block of single matched transaction, its hash is merkle root
+func merkleBlock(hash [32]byte) []byte {
+	data := append(header(hash), 1, 0, 0, 0, 1)
+	data = append(data, hash[:]...)
+
+	return append(data, 1, 1)
+}
+
+func instantLock(hash [32]byte) []byte {
+	data := append([]byte{0}, hash[:]...)
+
+	return append(data, make([]byte, 96)...)
+}
+
+func chainLock(height int) []byte {
+	data := make([]byte, 4+32+96)
+	binary.LittleEndian.PutUint32(data, uint32(height))
+
+	return data
+}
+
+//Fake node where tracked transaction is in block c.mined, blocks are served by height
+func newServer(t *testing.T, c *chain, hash [32]byte) *dapitest.Server {
+	t.Helper()
+	s, err := dapitest.NewServer()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(s.Close)
+
+	s.SetResponse(dapitest.MethodSendTransaction, &proto.SendTransactionResponse{})
+	s.Handle(dapitest.MethodGetStatus, func(ctx context.Context, request interface{}) (interface{}, error) {
+		c.Lock()
+		defer c.Unlock()
+
+		return &proto.GetStatusResponse{Blocks: uint32(c.tip), Connections: 8}, nil
+	})
+	s.Handle(dapitest.MethodGetBlock, func(ctx context.Context, request interface{}) (interface{}, error) {
+		c.Lock()
+		defer c.Unlock()
+
+		if int(request.(*proto.GetBlockRequest).GetHeight()) == c.mined {
+			return &proto.GetBlockResponse{Block: header(hash)}, nil
+		}
+
+		return &proto.GetBlockResponse{Block: header([32]byte{0xff})}, nil
+	})
+
+	return s
+}
+
+func newTracker(t *testing.T, s *dapitest.Server, options tracker.Options) *tracker.Tracker {
+	t.Helper()
+	c, err := evo.NewClient(nil, s.Host, s.JSONRPCPort, s.GRPCPort, evo.WithTimeout(5*time.Second))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tracker.NewTracker(c, options)
+}
+
+func next(t *testing.T, events <-chan tracker.Event) tracker.Event {
+	t.Helper()
+
+	select {
+	case e, ok := <-events:
+		if !ok {
+			t.Fatal("events are closed")
+		}
+
+		if e.Error != nil && e.State != tracker.StateRebroadcast {
+			t.Fatalf("%s: %s", e.State, e.Error)
+		}
+
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event")
+	}
+
+	return tracker.Event{}
+}
+
+func expect(t *testing.T, events <-chan tracker.Event, state tracker.State, height int, confirmations int) tracker.Event {
+	t.Helper()
+	e := next(t, events)
+
+	if e.State != state || e.BlockHeight != height || e.Confirmations != confirmations {
+		t.Fatalf("event %+v, expected %s at %d with %d confirmations", e, state, height, confirmations)
+	}
+
+	return e
+}
+
+func closed(t *testing.T, events <-chan tracker.Event) {
+	t.Helper()
+
+	select {
+	case e, ok := <-events:
+		if ok {
+			t.Fatalf("event %+v after end of tracking", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("events are not closed")
+	}
+}
+
+func TestFinality(t *testing.T) {
+	tx := testTransaction()
+	c := &chain{tip: 101, mined: 101}
+	s := newServer(t, c, tx.Hash())
+	s.SetStream(dapitest.MethodSubscribeToTransactionsWithProofs,
+		dapitest.Transactions(tx.Bytes()),
+		dapitest.InstantLocks(instantLock(tx.Hash())),
+		dapitest.MerkleBlock(merkleBlock(tx.Hash())),
+		dapitest.Hold(),
+	)
+	s.SetStream(dapitest.MethodSubscribeToBlockHeadersWithChainLocks, dapitest.ChainLocks(chainLock(102)), dapitest.Hold())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	//Zero durations are defaulted instead of panicking in ticker
+	options := tracker.Options{Confirmations: 3}
+	events, err := newTracker(t, s, options).Send(ctx, tx.Bytes())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect(t, events, tracker.StateBroadcast, 0, 0)
+	expect(t, events, tracker.StateInstantLocked, 0, 0)
+	expect(t, events, tracker.StateMined, 101, 0)
+	expect(t, events, tracker.StateConfirmed, 101, 1)
+
+	//ChainLock of descendant finalizes block of transaction
+	e := expect(t, events, tracker.StateChainLocked, 101, 0)
+
+	if e.TxID != tx.TxID() || e.Node != s.Host {
+		t.Fatalf("event %+v", e)
+	}
+
+	//Tracking ends with caller
+	cancel()
+	closed(t, events)
+}
+
+func TestConfirmationsAndReorg(t *testing.T) {
+	tx := testTransaction()
+	c := &chain{tip: 101, mined: 101}
+	s := newServer(t, c, tx.Hash())
+	s.SetStream(dapitest.MethodSubscribeToTransactionsWithProofs, dapitest.MerkleBlock(merkleBlock(tx.Hash())), dapitest.Hold())
+	s.SetStream(dapitest.MethodSubscribeToBlockHeadersWithChainLocks, dapitest.Hold())
+
+	options := tracker.DefaultOptions()
+	options.Confirmations = 2
+	options.PollInterval = 10 * time.Millisecond
+	events, err := newTracker(t, s, options).Send(context.Background(), tx.Bytes())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect(t, events, tracker.StateBroadcast, 0, 0)
+	expect(t, events, tracker.StateMined, 101, 0)
+	expect(t, events, tracker.StateConfirmed, 101, 1)
+
+	//Block 101 is replaced, transaction is mined again in block 102 of new chain
+	c.set(102, 102)
+
+	expect(t, events, tracker.StateBroadcast, 0, 0)
+	expect(t, events, tracker.StateMined, 102, 0)
+	expect(t, events, tracker.StateConfirmed, 102, 1)
+
+	requests := s.Requests(dapitest.MethodSubscribeToTransactionsWithProofs)
+
+	if len(requests) != 2 || requests[1].(*proto.TransactionsWithProofsRequest).GetFromBlockHeight() != 101 {
+		t.Fatalf("transaction stream is not subscribed again from broadcast height: %v", requests)
+	}
+
+	c.set(103, 102)
+
+	expect(t, events, tracker.StateConfirmed, 102, 2)
+	closed(t, events)
+}
+
+func TestDropped(t *testing.T) {
+	tx := testTransaction()
+	s := newServer(t, &chain{tip: 101}, tx.Hash())
+	s.SetStream(dapitest.MethodSubscribeToTransactionsWithProofs, dapitest.Hold())
+
+	options := tracker.DefaultOptions()
+	options.RebroadcastTimeout = 10 * time.Millisecond
+	options.MaxRebroadcasts = 1
+	events, err := newTracker(t, s, options).Send(context.Background(), tx.Bytes())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect(t, events, tracker.StateBroadcast, 0, 0)
+
+	//Single node received transaction already
+	if e := next(t, events); e.State != tracker.StateRebroadcast || e.Error == nil {
+		t.Fatalf("event %+v", e)
+	}
+
+	expect(t, events, tracker.StateDropped, 0, 0)
+	closed(t, events)
+
+	if requests := s.Requests(dapitest.MethodSendTransaction); len(requests) != 1 {
+		t.Fatalf("sent %d times", len(requests))
+	}
+}
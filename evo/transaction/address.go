@@ -0,0 +1,154 @@
+package transaction
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+const (
+	opDup         = 0x76
+	opHash160     = 0xa9
+	opEqual       = 0x87
+	opEqualVerify = 0x88
+	opCheckSig    = 0xac
+	opPushData1   = 0x4c
+	opPushData2   = 0x4d
+	hash160Size   = 20
+)
+
+//Version bytes of Base58Check encoded addresses and keys
+type AddressParams struct {
+	PubKeyHashAddrID byte
+	ScriptHashAddrID byte
+	PrivateKeyID     byte
+}
+
+var (
+	MainNetParams = AddressParams{
+		PubKeyHashAddrID: 0x4c,
+		ScriptHashAddrID: 0x10,
+		PrivateKeyID:     0xcc,
+	}
+	TestNetParams = AddressParams{
+		PubKeyHashAddrID: 0x8c,
+		ScriptHashAddrID: 0x13,
+		PrivateKeyID:     0xef,
+	}
+)
+
+func PayToPubKeyHashScript(pubKeyHash []byte) []byte {
+	script := make([]byte, 0, 25)
+	script = append(script, opDup, opHash160, hash160Size)
+	script = append(script, pubKeyHash...)
+
+	return append(script, opEqualVerify, opCheckSig)
+}
+
+func PayToScriptHashScript(scriptHash []byte) []byte {
+	script := make([]byte, 0, 23)
+	script = append(script, opHash160, hash160Size)
+	script = append(script, scriptHash...)
+
+	return append(script, opEqual)
+}
+
+func IsPayToPubKeyHash(script []byte) bool {
+	return len(script) == 25 &&
+		script[0] == opDup &&
+		script[1] == opHash160 &&
+		script[2] == hash160Size &&
+		script[23] == opEqualVerify &&
+		script[24] == opCheckSig
+}
+
+func IsPayToScriptHash(script []byte) bool {
+	return len(script) == 23 &&
+		script[0] == opHash160 &&
+		script[1] == hash160Size &&
+		script[22] == opEqual
+}
+
+//Output script for P2PKH or P2SH address
+func AddressToScript(address string, params AddressParams) ([]byte, error) {
+	hash, version, err := base58.CheckDecode(address)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %s", address, err)
+	}
+
+	if len(hash) != hash160Size {
+		return nil, fmt.Errorf("invalid address %s: wrong hash length", address)
+	}
+
+	switch version {
+	case params.PubKeyHashAddrID:
+		return PayToPubKeyHashScript(hash), nil
+	case params.ScriptHashAddrID:
+		return PayToScriptHashScript(hash), nil
+	default:
+		return nil, fmt.Errorf("invalid address %s: unknown version byte 0x%02x", address, version)
+	}
+}
+
+//Address of P2PKH or P2SH output script
+func ScriptToAddress(script []byte, params AddressParams) (string, error) {
+	switch {
+	case IsPayToPubKeyHash(script):
+		return base58.CheckEncode(script[3:23], params.PubKeyHashAddrID), nil
+	case IsPayToScriptHash(script):
+		return base58.CheckEncode(script[2:22], params.ScriptHashAddrID), nil
+	default:
+		return "", errors.New("non-standard output script")
+	}
+}
+
+func PubKeyHashAddress(pubKey []byte, params AddressParams) string {
+	return base58.CheckEncode(btcutil.Hash160(pubKey), params.PubKeyHashAddrID)
+}
+
+//Decode private key in Wallet Import Format
+func DecodeWIF(wif string, params AddressParams) (*btcutil.WIF, error) {
+	key, err := btcutil.DecodeWIF(wif)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !key.IsForNet(&chaincfg.Params{PrivateKeyID: params.PrivateKeyID}) {
+		return nil, errors.New("private key belongs to another network")
+	}
+
+	return key, nil
+}
+
+func EncodeWIF(key *btcec.PrivateKey, compressed bool, params AddressParams) (string, error) {
+	wif, err := btcutil.NewWIF(key, &chaincfg.Params{PrivateKeyID: params.PrivateKeyID}, compressed)
+
+	if err != nil {
+		return "", err
+	}
+
+	return wif.String(), nil
+}
+
+//Script push of arbitrary data
+func pushData(w *bytes.Buffer, data []byte) {
+	switch {
+	case len(data) < opPushData1:
+		w.WriteByte(byte(len(data)))
+	case len(data) <= 0xff:
+		w.WriteByte(opPushData1)
+		w.WriteByte(byte(len(data)))
+	default:
+		w.WriteByte(opPushData2)
+		w.WriteByte(byte(len(data)))
+		w.WriteByte(byte(len(data) >> 8))
+	}
+
+	w.Write(data)
+}
@@ -0,0 +1,188 @@
+package transaction
+
+import (
+	"errors"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"math"
+)
+
+const (
+	//Minimal relay fee of Dash Core, duffs per 1000 bytes
+	MinRelayFeeRate int64 = 1000
+	//Outputs below threshold are not relayed by Dash Core
+	DefaultDustThreshold int64 = 546
+	duffsPerDash               = 1e8
+)
+
+type Builder struct {
+	params        AddressParams
+	utxos         []UTXO
+	outputs       []Output
+	changeScript  []byte
+	feeRate       int64
+	dustThreshold int64
+	selector      CoinSelector
+	lockTime      uint32
+}
+
+func NewBuilder(params AddressParams) *Builder {
+	return &Builder{
+		params:        params,
+		feeRate:       MinRelayFeeRate,
+		dustThreshold: DefaultDustThreshold,
+		selector:      LargestFirst{},
+	}
+}
+
+//Convert GetEstimatedTransactionFee result (Dash per kB) to duffs per kB
+func FeeRateFromEstimate(fee float64) int64 {
+	rate := int64(math.Ceil(fee * duffsPerDash))
+
+	//Node returns non-positive value when it has no estimation
+	if rate < MinRelayFeeRate {
+		return MinRelayFeeRate
+	}
+
+	return rate
+}
+
+func (b *Builder) AddUTXO(items ...structures.UTXOItemResponse) error {
+	for _, item := range items {
+		u, err := UTXOFromResponse(item)
+
+		if err != nil {
+			return err
+		}
+
+		b.utxos = append(b.utxos, u)
+	}
+
+	return nil
+}
+
+func (b *Builder) AddOutput(address string, amount int64) error {
+	if amount <= 0 {
+		return errors.New("output amount must be positive")
+	}
+
+	if amount < b.dustThreshold {
+		return errors.New("output amount is below dust threshold")
+	}
+
+	script, err := AddressToScript(address, b.params)
+
+	if err != nil {
+		return err
+	}
+
+	b.outputs = append(b.outputs, Output{Value: amount, Script: script})
+
+	return nil
+}
+
+func (b *Builder) SetChangeAddress(address string) error {
+	script, err := AddressToScript(address, b.params)
+
+	if err != nil {
+		return err
+	}
+
+	b.changeScript = script
+
+	return nil
+}
+
+//Fee rate in duffs per 1000 bytes
+func (b *Builder) SetFeeRate(duffsPerKB int64) {
+	if duffsPerKB < MinRelayFeeRate {
+		duffsPerKB = MinRelayFeeRate
+	}
+
+	b.feeRate = duffsPerKB
+}
+
+func (b *Builder) SetEstimatedFee(response *proto.GetEstimatedTransactionFeeResponse) {
+	b.feeRate = FeeRateFromEstimate(response.GetFee())
+}
+
+//Outputs and change below threshold are rejected or added to fee, zero allows any positive amount
+func (b *Builder) SetDustThreshold(threshold int64) error {
+	if threshold < 0 {
+		return errors.New("negative dust threshold")
+	}
+
+	b.dustThreshold = threshold
+
+	return nil
+}
+
+func (b *Builder) SetCoinSelector(selector CoinSelector) {
+	b.selector = selector
+}
+
+func (b *Builder) SetLockTime(lockTime uint32) {
+	b.lockTime = lockTime
+}
+
+//Select coins, add change output and sign all inputs
+func (b *Builder) Build(signer Signer) (*Transaction, error) {
+	if len(b.outputs) == 0 {
+		return nil, errors.New("transaction has no outputs")
+	}
+
+	params := SelectionParams{
+		FeeRate:       b.feeRate,
+		BaseSize:      BaseTransactionSize,
+		ChangeSize:    P2PKHOutputSize,
+		DustThreshold: b.dustThreshold,
+	}
+
+	for _, out := range b.outputs {
+		params.Target += out.Value
+		params.BaseSize += 8 + VarIntSize(uint64(len(out.Script))) + len(out.Script)
+	}
+
+	selection, err := b.selector.Select(b.utxos, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{
+		Version:  DefaultVersion,
+		LockTime: b.lockTime,
+		Outputs:  append([]Output(nil), b.outputs...),
+	}
+
+	for _, u := range selection.Inputs {
+		tx.Inputs = append(tx.Inputs, Input{
+			PreviousOutput: u.OutPoint,
+			Sequence:       DefaultSequence,
+		})
+	}
+
+	if selection.Change > 0 {
+		if b.changeScript == nil {
+			return nil, errors.New("missing change address")
+		}
+
+		tx.Outputs = append(tx.Outputs, Output{Value: selection.Change, Script: b.changeScript})
+	}
+
+	for i, u := range selection.Inputs {
+		key, compressed, err := signer.PrivateKey(u.Script)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = SignP2PKH(tx, i, u.Script, key, compressed)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tx, nil
+}
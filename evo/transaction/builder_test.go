@@ -0,0 +1,103 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"testing"
+)
+
+func testBuilder(t *testing.T) *Builder {
+	t.Helper()
+	b := NewBuilder(TestNetParams)
+	script := hex.EncodeToString(mustScript(t, testAddress))
+
+	err := b.AddUTXO(
+		structures.UTXOItemResponse{Address: testAddress, Txid: testTxid2, OutputIndex: 1, Script: script, Satoshis: 80000000},
+		structures.UTXOItemResponse{Address: testAddress, Txid: testTxid1, OutputIndex: 0, Script: script, Satoshis: 100000000},
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func TestBuild(t *testing.T) {
+	b := testBuilder(t)
+	keys := NewKeyRing(TestNetParams)
+	_, err := keys.AddWIF(testWIF)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = b.AddOutput(testPayee, 150000000)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = b.SetChangeAddress(testAddress)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := b.Build(keys)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Both inputs, fee of 374 bytes and change of 29999626 duffs
+	expected := "0200000002169e1e83e930853391bc6f35f605c6754cfead57cf8387639d3b4096c54f18f4000000006a473044022071096230d2bf12394fd3bc1f1b357c684edc5140ba4c15ef018805a75087528902202b22728aee1f731d191f8228e723c39fbe39f162dbc52c8d4715d054a871a7270121027d38e8b73ff84638c10119f9a3a560ccdec6f9129915d2bd4f55d17c6f9c7755fffffffff2c245c38672a5d8fba5a5caa44dcef277a52e916a0603272f91286f2b052706010000006b483045022100ffc5b23f380d371887b05b0cf8a66cc391686f10695dcf34683bd1cc6310a46202204da01f625c2c61821a930fd692093853bef98a5cde537b31ed7ae5f64b1d9fcb0121027d38e8b73ff84638c10119f9a3a560ccdec6f9129915d2bd4f55d17c6f9c7755ffffffff0280d1f008000000001976a914b681d1634509dff1b2a0ca302bde6f77f87b798888ac0ac2c901000000001976a91419652abb4f2d56c54edea288af841916f12c2b3588ac00000000"
+
+	if raw := hex.EncodeToString(tx.Bytes()); raw != expected {
+		t.Fatalf("built transaction\n%s\nexpected\n%s", raw, expected)
+	}
+}
+
+func TestBuildMissingChangeAddress(t *testing.T) {
+	b := testBuilder(t)
+	err := b.AddOutput(testPayee, 150000000)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = b.Build(NewKeyRing(TestNetParams))
+
+	if err == nil {
+		t.Fatal("built transaction without change address")
+	}
+}
+
+func TestDustThreshold(t *testing.T) {
+	b := NewBuilder(TestNetParams)
+
+	if b.AddOutput(testPayee, DefaultDustThreshold-1) == nil {
+		t.Fatal("added output below dust threshold")
+	}
+
+	if b.SetDustThreshold(-1) == nil {
+		t.Fatal("accepted negative dust threshold")
+	}
+
+	err := b.SetDustThreshold(0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = b.AddOutput(testPayee, 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Zero threshold still requires positive amount
+	if b.AddOutput(testPayee, 0) == nil || b.AddOutput(testPayee, -1) == nil {
+		t.Fatal("added output of non-positive amount")
+	}
+}
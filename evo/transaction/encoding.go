@@ -0,0 +1,117 @@
+package transaction
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+var errVarIntOverflow = errors.New("varint value is too large")
+
+//Double SHA256 used for transaction ids, block hashes and signature hashes
+func DoubleHash(data []byte) [32]byte {
+	first := sha256.Sum256(data)
+
+	return sha256.Sum256(first[:])
+}
+
+//Reverse byte order (internal hash order <-> RPC hex order)
+func ReverseBytes(data []byte) []byte {
+	result := make([]byte, len(data))
+
+	for i, v := range data {
+		result[len(data)-1-i] = v
+	}
+
+	return result
+}
+
+func VarIntSize(value uint64) int {
+	switch {
+	case value < 0xfd:
+		return 1
+	case value <= 0xffff:
+		return 3
+	case value <= 0xffffffff:
+		return 5
+	default:
+		return 9
+	}
+}
+
+func WriteVarInt(w *bytes.Buffer, value uint64) {
+	var buf [8]byte
+
+	switch {
+	case value < 0xfd:
+		w.WriteByte(byte(value))
+	case value <= 0xffff:
+		w.WriteByte(0xfd)
+		binary.LittleEndian.PutUint16(buf[:2], uint16(value))
+		w.Write(buf[:2])
+	case value <= 0xffffffff:
+		w.WriteByte(0xfe)
+		binary.LittleEndian.PutUint32(buf[:4], uint32(value))
+		w.Write(buf[:4])
+	default:
+		w.WriteByte(0xff)
+		binary.LittleEndian.PutUint64(buf[:], value)
+		w.Write(buf[:])
+	}
+}
+
+func WriteVarBytes(w *bytes.Buffer, data []byte) {
+	WriteVarInt(w, uint64(len(data)))
+	w.Write(data)
+}
+
+func ReadVarInt(r io.Reader) (uint64, error) {
+	var buf [8]byte
+
+	_, err := io.ReadFull(r, buf[:1])
+
+	if err != nil {
+		return 0, err
+	}
+
+	switch buf[0] {
+	case 0xfd:
+		_, err = io.ReadFull(r, buf[:2])
+
+		return uint64(binary.LittleEndian.Uint16(buf[:2])), err
+	case 0xfe:
+		_, err = io.ReadFull(r, buf[:4])
+
+		return uint64(binary.LittleEndian.Uint32(buf[:4])), err
+	case 0xff:
+		_, err = io.ReadFull(r, buf[:])
+
+		return binary.LittleEndian.Uint64(buf[:]), err
+	default:
+		return uint64(buf[0]), nil
+	}
+}
+
+//Read length-prefixed bytes, maxSize protects from malformed length values
+func ReadVarBytes(r io.Reader, maxSize uint64) ([]byte, error) {
+	size, err := ReadVarInt(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if size > maxSize {
+		return nil, errVarIntOverflow
+	}
+
+	data := make([]byte, size)
+	_, err = io.ReadFull(r, data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,305 @@
+package transaction
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"math/big"
+	"sort"
+)
+
+const (
+	//Size of P2PKH input signed by compressed key (low-S DER signature)
+	P2PKHInputSize = 148
+	//Size of P2PKH output
+	P2PKHOutputSize = 34
+	//Version, input and output counters, lock time
+	BaseTransactionSize = 10
+	//Upper bound of Branch and Bound search iterations
+	bnbMaxTries = 100000
+)
+
+var (
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrNoExactMatch      = errors.New("branch and bound found no exact match")
+)
+
+type UTXO struct {
+	OutPoint OutPoint
+	Address  string
+	Script   []byte
+	Value    int64
+	Height   int
+}
+
+//Parameters passed to CoinSelector
+type SelectionParams struct {
+	//Sum of payment outputs
+	Target int64
+	//Duffs per 1000 bytes
+	FeeRate int64
+	//Size of transaction without inputs and change output
+	BaseSize int
+	//Size of change output
+	ChangeSize int
+	//Change below threshold is added to fee
+	DustThreshold int64
+}
+
+type Selection struct {
+	Inputs []UTXO
+	Fee    int64
+	//Zero when transaction has no change output
+	Change int64
+}
+
+type CoinSelector interface {
+	Select(utxos []UTXO, params SelectionParams) (*Selection, error)
+}
+
+//Spend biggest outputs first, minimises number of inputs
+type LargestFirst struct{}
+
+//Search for input set without change output (Bitcoin Core algorithm),
+//falls back to Fallback selector when exact match does not exist
+type BranchAndBound struct {
+	Fallback CoinSelector
+}
+
+//Spend all outputs of address together and link as few addresses as possible
+type PrivacyPreserving struct{}
+
+func UTXOFromResponse(item structures.UTXOItemResponse) (UTXO, error) {
+	u := UTXO{
+		Address: item.Address,
+		Value:   item.Satoshis,
+		Height:  item.Height,
+	}
+
+	if item.OutputIndex < 0 {
+		return u, errors.New("negative output index")
+	}
+
+	outPoint, err := NewOutPoint(item.Txid, uint32(item.OutputIndex))
+
+	if err != nil {
+		return u, err
+	}
+
+	u.OutPoint = outPoint
+	u.Script, err = hex.DecodeString(item.Script)
+
+	if err != nil {
+		return u, err
+	}
+
+	return u, nil
+}
+
+//Fee in duffs for transaction of given size
+func (p SelectionParams) Fee(size int) int64 {
+	return (int64(size)*p.FeeRate + 999) / 1000
+}
+
+func (p SelectionParams) size(inputs int, withChange bool) int {
+	size := p.BaseSize + inputs*P2PKHInputSize
+
+	if withChange {
+		size += p.ChangeSize
+	}
+
+	return size
+}
+
+//Build selection from inputs, nil when inputs do not cover target and fee
+func (p SelectionParams) finish(inputs []UTXO) *Selection {
+	var total int64
+
+	for _, u := range inputs {
+		total += u.Value
+	}
+
+	fee := p.Fee(p.size(len(inputs), true))
+	change := total - p.Target - fee
+
+	if change >= p.DustThreshold && change > 0 {
+		return &Selection{Inputs: inputs, Fee: fee, Change: change}
+	}
+
+	fee = p.Fee(p.size(len(inputs), false))
+
+	if total-p.Target < fee {
+		return nil
+	}
+
+	return &Selection{Inputs: inputs, Fee: total - p.Target}
+}
+
+func (LargestFirst) Select(utxos []UTXO, params SelectionParams) (*Selection, error) {
+	sorted := make([]UTXO, len(utxos))
+	copy(sorted, utxos)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Value > sorted[j].Value
+	})
+
+	for i := range sorted {
+		if s := params.finish(sorted[:i+1]); s != nil {
+			return s, nil
+		}
+	}
+
+	return nil, ErrInsufficientFunds
+}
+
+func (b BranchAndBound) Select(utxos []UTXO, params SelectionParams) (*Selection, error) {
+	inputFee := params.Fee(P2PKHInputSize)
+	target := params.Target + params.Fee(params.BaseSize)
+	//Change output now and spending it later
+	costOfChange := params.Fee(params.ChangeSize) + inputFee
+
+	candidates := make([]UTXO, 0, len(utxos))
+	var available int64
+
+	for _, u := range utxos {
+		if u.Value-inputFee > 0 {
+			candidates = append(candidates, u)
+			available += u.Value - inputFee
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Value > candidates[j].Value
+	})
+
+	//Stack of include/omit decisions for candidates
+	var selection []bool
+	var best []bool
+	bestWaste := int64(-1)
+	var current int64
+
+	for tries := 0; tries < bnbMaxTries; tries++ {
+		backtrack := false
+
+		switch {
+		case current+available < target, current > target+costOfChange:
+			backtrack = true
+		case current >= target:
+			if waste := current - target; bestWaste < 0 || waste < bestWaste {
+				bestWaste = waste
+				best = append(best[:0], selection...)
+			}
+
+			backtrack = true
+		}
+
+		if !backtrack {
+			//Include next candidate
+			value := candidates[len(selection)].Value - inputFee
+			available -= value
+			current += value
+			selection = append(selection, true)
+
+			continue
+		}
+
+		//Walk back to last included candidate and try to omit it
+		for len(selection) > 0 && !selection[len(selection)-1] {
+			selection = selection[:len(selection)-1]
+			available += candidates[len(selection)].Value - inputFee
+		}
+
+		if len(selection) == 0 {
+			break
+		}
+
+		selection[len(selection)-1] = false
+		current -= candidates[len(selection)-1].Value - inputFee
+	}
+
+	if best == nil {
+		if b.Fallback != nil {
+			return b.Fallback.Select(utxos, params)
+		}
+
+		return nil, ErrNoExactMatch
+	}
+
+	var inputs []UTXO
+	var total int64
+
+	for i, ok := range best {
+		if ok {
+			inputs = append(inputs, candidates[i])
+			total += candidates[i].Value
+		}
+	}
+
+	return &Selection{Inputs: inputs, Fee: total - params.Target}, nil
+}
+
+func (PrivacyPreserving) Select(utxos []UTXO, params SelectionParams) (*Selection, error) {
+	groups := make(map[string][]UTXO)
+	var order []string
+
+	for _, u := range utxos {
+		if _, ok := groups[u.Address]; !ok {
+			order = append(order, u.Address)
+		}
+
+		groups[u.Address] = append(groups[u.Address], u)
+	}
+
+	//Random order hides wallet ordering from chain observers
+	for i := len(order) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+
+		if err != nil {
+			return nil, err
+		}
+
+		order[i], order[j.Int64()] = order[j.Int64()], order[i]
+	}
+
+	groupValue := func(address string) int64 {
+		var total int64
+
+		for _, u := range groups[address] {
+			total += u.Value
+		}
+
+		return total
+	}
+
+	//Smallest single address covering payment
+	var best *Selection
+
+	for _, address := range order {
+		if s := params.finish(groups[address]); s != nil {
+			if best == nil || s.Fee+s.Change < best.Fee+best.Change {
+				best = s
+			}
+		}
+	}
+
+	if best != nil {
+		return best, nil
+	}
+
+	//Otherwise link as few addresses as possible
+	sort.SliceStable(order, func(i, j int) bool {
+		return groupValue(order[i]) > groupValue(order[j])
+	})
+
+	var inputs []UTXO
+
+	for _, address := range order {
+		inputs = append(inputs, groups[address]...)
+
+		if s := params.finish(inputs); s != nil {
+			return s, nil
+		}
+	}
+
+	return nil, ErrInsufficientFunds
+}
@@ -0,0 +1,168 @@
+package transaction
+
+import (
+	"errors"
+	"testing"
+)
+
+// Fee rate of 1000 duffs/kB makes fee equal to size: base 44 (one P2PKH output), input 148, change 34
+var testParams = SelectionParams{
+	Target:        100000,
+	FeeRate:       MinRelayFeeRate,
+	BaseSize:      44,
+	ChangeSize:    P2PKHOutputSize,
+	DustThreshold: DefaultDustThreshold,
+}
+
+func testUTXO(address string, index uint32, value int64) UTXO {
+	return UTXO{OutPoint: OutPoint{Index: index}, Address: address, Value: value}
+}
+
+func inputValues(s *Selection) []int64 {
+	var values []int64
+
+	for _, u := range s.Inputs {
+		values = append(values, u.Value)
+	}
+
+	return values
+}
+
+func sameValues(a []int64, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestCoinSelection(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector CoinSelector
+		utxos    []UTXO
+		zeroDust bool
+		inputs   []int64
+		fee      int64
+		change   int64
+		err      error
+	}{
+		{
+			name:     "largest first spends biggest outputs",
+			selector: LargestFirst{},
+			utxos:    []UTXO{testUTXO("a", 0, 30000), testUTXO("a", 1, 90000), testUTXO("a", 2, 60000)},
+			inputs:   []int64{90000, 60000},
+			fee:      374,
+			change:   49626,
+		},
+		{
+			name:     "largest first insufficient funds",
+			selector: LargestFirst{},
+			utxos:    []UTXO{testUTXO("a", 0, 60000), testUTXO("a", 1, 40000)},
+			err:      ErrInsufficientFunds,
+		},
+		{
+			name:     "change below dust threshold goes to fee",
+			selector: LargestFirst{},
+			utxos:    []UTXO{testUTXO("a", 0, 100500)},
+			inputs:   []int64{100500},
+			fee:      500,
+		},
+		{
+			name:     "change equal to dust threshold is kept",
+			selector: LargestFirst{},
+			utxos:    []UTXO{testUTXO("a", 0, 100772)},
+			inputs:   []int64{100772},
+			fee:      226,
+			change:   546,
+		},
+		{
+			name:     "zero change is never an output",
+			selector: LargestFirst{},
+			utxos:    []UTXO{testUTXO("a", 0, 100226)},
+			zeroDust: true,
+			inputs:   []int64{100226},
+			fee:      226,
+		},
+		{
+			name:     "funds without fee of inputs",
+			selector: LargestFirst{},
+			utxos:    []UTXO{testUTXO("a", 0, 100100)},
+			err:      ErrInsufficientFunds,
+		},
+		{
+			name:     "branch and bound exact match without change",
+			selector: BranchAndBound{},
+			utxos:    []UTXO{testUTXO("a", 0, 500000), testUTXO("a", 1, 60148), testUTXO("a", 2, 40192)},
+			inputs:   []int64{60148, 40192},
+			fee:      340,
+		},
+		{
+			name:     "branch and bound without exact match",
+			selector: BranchAndBound{},
+			utxos:    []UTXO{testUTXO("a", 0, 500000)},
+			err:      ErrNoExactMatch,
+		},
+		{
+			name:     "branch and bound fallback",
+			selector: BranchAndBound{Fallback: LargestFirst{}},
+			utxos:    []UTXO{testUTXO("a", 0, 500000)},
+			inputs:   []int64{500000},
+			fee:      226,
+			change:   399774,
+		},
+		{
+			name:     "privacy preserving spends whole cheapest address",
+			selector: PrivacyPreserving{},
+			utxos:    []UTXO{testUTXO("a", 0, 70000), testUTXO("b", 0, 300000), testUTXO("a", 1, 70000), testUTXO("c", 0, 50000)},
+			inputs:   []int64{70000, 70000},
+			fee:      374,
+			change:   39626,
+		},
+		{
+			name:     "privacy preserving links fewest addresses",
+			selector: PrivacyPreserving{},
+			utxos:    []UTXO{testUTXO("c", 0, 30000), testUTXO("a", 0, 60000), testUTXO("b", 0, 50000)},
+			inputs:   []int64{60000, 50000},
+			fee:      374,
+			change:   9626,
+		},
+		{
+			name:     "privacy preserving insufficient funds",
+			selector: PrivacyPreserving{},
+			utxos:    []UTXO{testUTXO("a", 0, 60000), testUTXO("b", 0, 40000)},
+			err:      ErrInsufficientFunds,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := testParams
+
+			if test.zeroDust {
+				params.DustThreshold = 0
+			}
+
+			s, err := test.selector.Select(test.utxos, params)
+
+			if !errors.Is(err, test.err) {
+				t.Fatalf("error %v, expected %v", err, test.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !sameValues(inputValues(s), test.inputs) || s.Fee != test.fee || s.Change != test.change {
+				t.Fatalf("inputs %v fee %d change %d, expected inputs %v fee %d change %d",
+					inputValues(s), s.Fee, s.Change, test.inputs, test.fee, test.change)
+			}
+		})
+	}
+}
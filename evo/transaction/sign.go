@@ -0,0 +1,174 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil"
+)
+
+const SigHashAll uint32 = 0x01
+
+//Provides private key able to spend output with given script
+type Signer interface {
+	PrivateKey(script []byte) (key *btcec.PrivateKey, compressed bool, err error)
+}
+
+//In-memory Signer for P2PKH outputs
+type KeyRing struct {
+	params AddressParams
+	keys   map[string]*btcutil.WIF
+}
+
+func NewKeyRing(params AddressParams) *KeyRing {
+	return &KeyRing{
+		params: params,
+		keys:   make(map[string]*btcutil.WIF),
+	}
+}
+
+//Add private key in Wallet Import Format, returns P2PKH address of key
+func (k *KeyRing) AddWIF(wif string) (string, error) {
+	key, err := DecodeWIF(wif, k.params)
+
+	if err != nil {
+		return "", err
+	}
+
+	pubKeyHash := btcutil.Hash160(key.SerializePubKey())
+	k.keys[string(PayToPubKeyHashScript(pubKeyHash))] = key
+
+	return PubKeyHashAddress(key.SerializePubKey(), k.params), nil
+}
+
+func (k *KeyRing) PrivateKey(script []byte) (*btcec.PrivateKey, bool, error) {
+	key, ok := k.keys[string(script)]
+
+	if !ok {
+		return nil, false, errors.New("missing private key for output script")
+	}
+
+	return key.PrivKey, key.CompressPubKey, nil
+}
+
+//Legacy signature hash of input
+func SignatureHash(tx *Transaction, index int, subScript []byte, hashType uint32) ([]byte, error) {
+	if index < 0 || index >= len(tx.Inputs) {
+		return nil, fmt.Errorf("input index %d out of range", index)
+	}
+
+	if hashType != SigHashAll {
+		return nil, errors.New("only SIGHASH_ALL is supported")
+	}
+
+	c := tx.Copy()
+
+	for i := range c.Inputs {
+		if i == index {
+			c.Inputs[i].Script = subScript
+		} else {
+			c.Inputs[i].Script = nil
+		}
+	}
+
+	w := new(bytes.Buffer)
+	c.serialize(w)
+
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], hashType)
+	w.Write(buf[:])
+
+	hash := DoubleHash(w.Bytes())
+
+	return hash[:], nil
+}
+
+//Sign P2PKH input with SIGHASH_ALL
+func SignP2PKH(tx *Transaction, index int, prevScript []byte, key *btcec.PrivateKey, compressed bool) error {
+	if !IsPayToPubKeyHash(prevScript) {
+		return errors.New("previous output is not P2PKH")
+	}
+
+	pubKey := key.PubKey().SerializeUncompressed()
+
+	if compressed {
+		pubKey = key.PubKey().SerializeCompressed()
+	}
+
+	if !bytes.Equal(btcutil.Hash160(pubKey), prevScript[3:23]) {
+		return errors.New("private key does not match previous output")
+	}
+
+	hash, err := SignatureHash(tx, index, prevScript, SigHashAll)
+
+	if err != nil {
+		return err
+	}
+
+	signature, err := key.Sign(hash)
+
+	if err != nil {
+		return err
+	}
+
+	script := new(bytes.Buffer)
+	pushData(script, append(signature.Serialize(), byte(SigHashAll)))
+	pushData(script, pubKey)
+	tx.Inputs[index].Script = script.Bytes()
+
+	return nil
+}
+
+//Check P2PKH input signature, used as sanity check after signing
+func VerifyP2PKH(tx *Transaction, index int, prevScript []byte) error {
+	if index < 0 || index >= len(tx.Inputs) {
+		return fmt.Errorf("input index %d out of range", index)
+	}
+
+	script := tx.Inputs[index].Script
+
+	if len(script) < 1 || int(script[0])+2 > len(script) {
+		return errors.New("malformed signature script")
+	}
+
+	signatureData := script[1 : 1+int(script[0])]
+	pubKeyData := script[2+int(script[0]):]
+
+	if len(pubKeyData) != int(script[1+int(script[0])]) {
+		return errors.New("malformed signature script")
+	}
+
+	if len(signatureData) < 1 || uint32(signatureData[len(signatureData)-1]) != SigHashAll {
+		return errors.New("unsupported signature hash type")
+	}
+
+	if !IsPayToPubKeyHash(prevScript) || !bytes.Equal(btcutil.Hash160(pubKeyData), prevScript[3:23]) {
+		return errors.New("public key does not match previous output")
+	}
+
+	signature, err := btcec.ParseDERSignature(signatureData[:len(signatureData)-1], btcec.S256())
+
+	if err != nil {
+		return err
+	}
+
+	pubKey, err := btcec.ParsePubKey(pubKeyData, btcec.S256())
+
+	if err != nil {
+		return err
+	}
+
+	hash, err := SignatureHash(tx, index, prevScript, SigHashAll)
+
+	if err != nil {
+		return err
+	}
+
+	if !signature.Verify(hash, pubKey) {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+package transaction
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil"
+	"testing"
+)
+
+// Testnet keys of vectors, signatures are deterministic (RFC 6979) with low S. Vectors spend outputs of real transactions
+// f4184fc5… (block 170) and 0627052b…, expected transactions were cross-checked with independent implementation
+const (
+	testWIF             = "cSzNsT8WebmZD9eBQ6aCP5bCSWCg9au4sG5Ur9eem3PQUKNqV1y7"
+	testWIFUncompressed = "92oz4jArmKJLz8PvZRNZwPnoztUZtLTkUFHsiVhFSqaArKdnXwD"
+	testAddress         = "yNdiwCY1pUBijwayLXh5Qz3ZFVnMkQXHgb"
+	testAddressUncomp   = "yZqay8BkHgAtgZ47S6Xufjra3h9miWwGne"
+	testPayee           = "ycxTM2KDQqjD1msypK7gm9RktChok6NG2h"
+	testTxid1           = "f4184fc596403b9d638783cf57adfe4c75c605f6356fbc91338530e9831e9e16"
+	testTxid2           = "0627052b6f28912f2703066a912ea577f2ce4da4caa5a5fbd8a57286c345c2f2"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	data, err := hex.DecodeString(s)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func mustScript(t *testing.T, address string) []byte {
+	t.Helper()
+	script, err := AddressToScript(address, TestNetParams)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return script
+}
+
+func mustOutPoint(t *testing.T, txid string, index uint32) OutPoint {
+	t.Helper()
+	o, err := NewOutPoint(txid, index)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return o
+}
+
+func TestSignP2PKH(t *testing.T) {
+	tests := []struct {
+		name    string
+		wif     string
+		address string
+		txid    string
+		index   uint32
+		//First value pays to payee, second returns change to testAddress
+		values   []int64
+		lockTime uint32
+		expected string
+	}{
+		{
+			name:     "compressed key with change",
+			wif:      testWIF,
+			address:  testAddress,
+			txid:     testTxid1,
+			index:    1,
+			values:   []int64{50000000, 49990000},
+			expected: "0200000001169e1e83e930853391bc6f35f605c6754cfead57cf8387639d3b4096c54f18f4010000006a47304402203df092bbc7fe394b7c3a2295a92be46b95f43734e15240a328fe765a6cf8f5370220705ff2f963cce23dc208136a24bf40f8e2ab8ebf9ff63c8f2c30f02dafab7d2a0121027d38e8b73ff84638c10119f9a3a560ccdec6f9129915d2bd4f55d17c6f9c7755ffffffff0280f0fa02000000001976a914b681d1634509dff1b2a0ca302bde6f77f87b798888ac70c9fa02000000001976a91419652abb4f2d56c54edea288af841916f12c2b3588ac00000000",
+		},
+		{
+			name:     "uncompressed key with lock time",
+			wif:      testWIFUncompressed,
+			address:  testAddressUncomp,
+			txid:     testTxid2,
+			index:    0,
+			values:   []int64{12345678},
+			lockTime: 1200000,
+			expected: "0200000001f2c245c38672a5d8fba5a5caa44dcef277a52e916a0603272f91286f2b052706000000008a473044022015c8add0d2d1e4a598d0411654d686fb41550df02a090382527b98178e40665d02207534fa2ace9a653c87e838f72f096ec686095ffd16987aa053f19207f1dcfb480141047d38e8b73ff84638c10119f9a3a560ccdec6f9129915d2bd4f55d17c6f9c7755ede3d7c1a20487ee751a7f0dc3e3e5c0ade6aa36aa9ec6b29ab84c4b7f902facffffffff014e61bc00000000001976a914b681d1634509dff1b2a0ca302bde6f77f87b798888ac804f1200",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keys := NewKeyRing(TestNetParams)
+			address, err := keys.AddWIF(test.wif)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if address != test.address {
+				t.Fatalf("address %s, expected %s", address, test.address)
+			}
+
+			outputs := []Output{{Value: test.values[0], Script: mustScript(t, testPayee)}}
+
+			if len(test.values) > 1 {
+				outputs = append(outputs, Output{Value: test.values[1], Script: mustScript(t, testAddress)})
+			}
+
+			tx := &Transaction{
+				Version:  DefaultVersion,
+				LockTime: test.lockTime,
+				Inputs:   []Input{{PreviousOutput: mustOutPoint(t, test.txid, test.index), Sequence: DefaultSequence}},
+				Outputs:  outputs,
+			}
+
+			prevScript := mustScript(t, test.address)
+			key, compressed, err := keys.PrivateKey(prevScript)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = SignP2PKH(tx, 0, prevScript, key, compressed)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if raw := hex.EncodeToString(tx.Bytes()); raw != test.expected {
+				t.Fatalf("signed transaction\n%s\nexpected\n%s", raw, test.expected)
+			}
+
+			err = VerifyP2PKH(tx, 0, prevScript)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestSignP2PKHWrongKey(t *testing.T) {
+	keys := NewKeyRing(TestNetParams)
+	_, err := keys.AddWIF(testWIF)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, compressed, err := keys.PrivateKey(mustScript(t, testAddress))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := &Transaction{
+		Version: DefaultVersion,
+		Inputs:  []Input{{PreviousOutput: mustOutPoint(t, testTxid1, 0), Sequence: DefaultSequence}},
+		Outputs: []Output{{Value: 1000, Script: mustScript(t, testPayee)}},
+	}
+
+	if SignP2PKH(tx, 0, mustScript(t, testPayee), key, compressed) == nil {
+		t.Fatal("signed output of another key")
+	}
+}
+
+//Published RFC 6979 vectors of secp256k1, key.Sign used by SignP2PKH must reproduce them
+func TestDeterministicSignature(t *testing.T) {
+	tests := []struct {
+		key       string
+		message   string
+		signature string
+	}{
+		{
+			key:       "0000000000000000000000000000000000000000000000000000000000000001",
+			message:   "Everything should be made as simple as possible, but not simpler.",
+			signature: "33a69cd2065432a30f3d1ce4eb0d59b8ab58c74f27c41a7fdb5696ad4e6108c96f807982866f785d3f6418d24163ddae117b7db4d5fdf0071de069fa54342262",
+		},
+		{
+			key:       "0000000000000000000000000000000000000000000000000000000000000001",
+			message:   "Satoshi Nakamoto",
+			signature: "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d82442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.message, func(t *testing.T) {
+			key, _ := btcec.PrivKeyFromBytes(btcec.S256(), mustDecodeHex(t, test.key))
+			hash := sha256.Sum256([]byte(test.message))
+			signature, err := key.Sign(hash[:])
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if rs := fmt.Sprintf("%064x%064x", signature.R, signature.S); rs != test.signature {
+				t.Fatalf("signature %s, expected %s", rs, test.signature)
+			}
+		})
+	}
+}
+
+//Real transactions with their published txids. Dash kept legacy format and signature hash of Bitcoin,
+//so signatures of Bitcoin mainnet transactions verify byte-for-byte
+func TestVerifyKnownTransactions(t *testing.T) {
+	tests := []struct {
+		name string
+		txid string
+		raw  string
+		//Previous output script of first input, empty for P2PKH where it is derived from public key of input
+		prevScript string
+	}{
+		{
+			name: "compressed key",
+			txid: "5df1375ffe61ac35ca178ebb0cab9ea26dedbd0e96005dfcee7e379fa513232f",
+			raw:  "0100000002f9cbafc519425637ba4227f8d0a0b7160b4e65168193d5af39747891de98b5b5000000006b4830450221008dd619c563e527c47d9bd53534a770b102e40faa87f61433580e04e271ef2f960220029886434e18122b53d5decd25f1f4acb2480659fea20aabd856987ba3c3907e0121022b78b756e2258af13779c1a1f37ea6800259716ca4b7f0b87610e0bf3ab52a01ffffffff42e7988254800876b69f24676b3e0205b77be476512ca4d970707dd5c60598ab00000000fd260100483045022015bd0139bcccf990a6af6ec5c1c52ed8222e03a0d51c334df139968525d2fcd20221009f9efe325476eb64c3958e4713e9eefe49bf1d820ed58d2112721b134e2a1a53034930460221008431bdfa72bc67f9d41fe72e94c88fb8f359ffa30b33c72c121c5a877d922e1002210089ef5fc22dd8bfc6bf9ffdb01a9862d27687d424d1fefbab9e9c7176844a187a014c9052483045022015bd0139bcccf990a6af6ec5c1c52ed8222e03a0d51c334df139968525d2fcd20221009f9efe325476eb64c3958e4713e9eefe49bf1d820ed58d2112721b134e2a1a5303210378d430274f8c5ec1321338151e9f27f4c676a008bdf8638d07c0b6be9ab35c71210378d430274f8c5ec1321338151e9f27f4c676a008bdf8638d07c0b6be9ab35c7153aeffffffff01a08601000000000017a914d8dacdadb7462ae15cd906f1878706d0da8660e68700000000",
+		},
+		{
+			name: "uncompressed key",
+			txid: "0627052b6f28912f2703066a912ea577f2ce4da4caa5a5fbd8a57286c345c2f2",
+			raw:  "0100000001186f9f998a5aa6f048e51dd8419a14d8a0f1a8a2836dd734d2804fe65fa35779000000008b483045022100884d142d86652a3f47ba4746ec719bbfbd040a570b1deccbb6498c75c4ae24cb02204b9f039ff08df09cbe9f6addac960298cad530a863ea8f53982c09db8f6e381301410484ecc0d46f1918b30928fa0e4ed99f16a0fb4fde0735e7ade8416ab9fe423cc5412336376789d172787ec3457eee41c04f4938de5cc17b4a10fa336a8d752adfffffffff0260e31600000000001976a914ab68025513c3dbd2f7b92a94e0581f5d50f654e788acd0ef8000000000001976a9147f9b1a7fb68d60c536c2fd8aeaa53a8f3cc025a888ac00000000",
+		},
+		{
+			name:       "pay to public key of block 170",
+			txid:       "f4184fc596403b9d638783cf57adfe4c75c605f6356fbc91338530e9831e9e16",
+			raw:        "0100000001c997a5e56e104102fa209c6a852dd90660a20b2d9c352423edce25857fcd3704000000004847304402204e45e16932b8af514961a1d3a1a25fdf3f4f7732e9d624c6c61548ab5fb8cd410220181522ec8eca07de4860a4acdd12909d831cc56cbbac4622082221a8768d1d0901ffffffff0200ca9a3b00000000434104ae1a62fe09c5f51b13905f07f06b99a2f7159b2225f374cd378d71302fa28414e7aab37397f554a7df5f142c21c1b7303b8a0626f1baded5c72a704f7e6cd84cac00286bee0000000043410411db93e1dcdb8a016b49840f8c53bc1eb68a382e97b1482ecad7b148a6909a5cb2e0eaddfb84ccf9744464f82e160bfa9b8b64f9d4c03f999b8643f656b412a3ac00000000",
+			prevScript: "410411db93e1dcdb8a016b49840f8c53bc1eb68a382e97b1482ecad7b148a6909a5cb2e0eaddfb84ccf9744464f82e160bfa9b8b64f9d4c03f999b8643f656b412a3ac",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx, err := Parse(mustDecodeHex(t, test.raw))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tx.TxID() != test.txid {
+				t.Fatalf("txid %s, expected %s", tx.TxID(), test.txid)
+			}
+
+			if hex.EncodeToString(tx.Bytes()) != test.raw {
+				t.Fatal("serialized transaction differs from parsed one")
+			}
+
+			verify := func() error {
+				if test.prevScript == "" {
+					return VerifyP2PKH(tx, 0, prevPubKeyHashScript(t, tx.Inputs[0].Script))
+				}
+
+				return verifyPayToPubKey(t, tx, mustDecodeHex(t, test.prevScript))
+			}
+
+			err = verify()
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tx.Outputs[0].Value--
+
+			if verify() == nil {
+				t.Fatal("signature verified after output was changed")
+			}
+		})
+	}
+}
+
+//P2PKH script of public key pushed after signature
+func prevPubKeyHashScript(t *testing.T, script []byte) []byte {
+	t.Helper()
+
+	if len(script) < 1 || int(script[0])+2 > len(script) {
+		t.Fatal("malformed signature script")
+	}
+
+	return PayToPubKeyHashScript(btcutil.Hash160(script[2+int(script[0]):]))
+}
+
+//Signature of P2PK input checked against signature hash of first input
+func verifyPayToPubKey(t *testing.T, tx *Transaction, prevScript []byte) error {
+	t.Helper()
+	script := tx.Inputs[0].Script
+	signature, err := btcec.ParseDERSignature(script[1:script[0]], btcec.S256())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubKey, err := btcec.ParsePubKey(prevScript[1:len(prevScript)-1], btcec.S256())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := SignatureHash(tx, 0, prevScript, SigHashAll)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !signature.Verify(hash, pubKey) {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+)
+
+const (
+	//Sequence number of final inputs
+	DefaultSequence uint32 = 0xffffffff
+	//Default transaction version for regular payments
+	DefaultVersion uint16 = 2
+	//Special transactions (DIP2) carry extra payload starting from version 3
+	specialTxVersion uint16 = 3
+	//Limit for single field of raw transaction, protects from malformed data
+	maxFieldSize = 1 << 20
+)
+
+type OutPoint struct {
+	//Hash in internal byte order (reversed Txid)
+	Hash  [32]byte
+	Index uint32
+}
+
+type Input struct {
+	PreviousOutput OutPoint
+	Script         []byte
+	Sequence       uint32
+}
+
+type Output struct {
+	Value  int64
+	Script []byte
+}
+
+type Transaction struct {
+	Version      uint16
+	Type         uint16
+	Inputs       []Input
+	Outputs      []Output
+	LockTime     uint32
+	ExtraPayload []byte
+}
+
+func NewOutPoint(txid string, index uint32) (OutPoint, error) {
+	o := OutPoint{Index: index}
+	hash, err := hex.DecodeString(txid)
+
+	if err != nil {
+		return o, err
+	}
+
+	if len(hash) != len(o.Hash) {
+		return o, errors.New("invalid txid length")
+	}
+
+	copy(o.Hash[:], ReverseBytes(hash))
+
+	return o, nil
+}
+
+//Txid in RPC (reversed) hex order
+func (o OutPoint) TxID() string {
+	return hex.EncodeToString(ReverseBytes(o.Hash[:]))
+}
+
+func (t *Transaction) isSpecial() bool {
+	return t.Version >= specialTxVersion && t.Type != 0
+}
+
+func (t *Transaction) serialize(w *bytes.Buffer) {
+	var buf [8]byte
+
+	binary.LittleEndian.PutUint32(buf[:4], uint32(t.Version)|uint32(t.Type)<<16)
+	w.Write(buf[:4])
+
+	WriteVarInt(w, uint64(len(t.Inputs)))
+
+	for _, in := range t.Inputs {
+		w.Write(in.PreviousOutput.Hash[:])
+		binary.LittleEndian.PutUint32(buf[:4], in.PreviousOutput.Index)
+		w.Write(buf[:4])
+		WriteVarBytes(w, in.Script)
+		binary.LittleEndian.PutUint32(buf[:4], in.Sequence)
+		w.Write(buf[:4])
+	}
+
+	WriteVarInt(w, uint64(len(t.Outputs)))
+
+	for _, out := range t.Outputs {
+		binary.LittleEndian.PutUint64(buf[:], uint64(out.Value))
+		w.Write(buf[:])
+		WriteVarBytes(w, out.Script)
+	}
+
+	binary.LittleEndian.PutUint32(buf[:4], t.LockTime)
+	w.Write(buf[:4])
+
+	if t.isSpecial() {
+		WriteVarBytes(w, t.ExtraPayload)
+	}
+}
+
+//Raw transaction, ready for SendTransaction
+func (t *Transaction) Bytes() []byte {
+	w := new(bytes.Buffer)
+	t.serialize(w)
+
+	return w.Bytes()
+}
+
+//Hash in internal byte order
+func (t *Transaction) Hash() [32]byte {
+	return DoubleHash(t.Bytes())
+}
+
+//Txid in RPC (reversed) hex order
+func (t *Transaction) TxID() string {
+	hash := t.Hash()
+
+	return hex.EncodeToString(ReverseBytes(hash[:]))
+}
+
+func (t *Transaction) Copy() *Transaction {
+	c := *t
+	c.Inputs = make([]Input, len(t.Inputs))
+	c.Outputs = make([]Output, len(t.Outputs))
+	copy(c.Inputs, t.Inputs)
+	copy(c.Outputs, t.Outputs)
+
+	return &c
+}
+
+//Parse raw transaction
+func Parse(data []byte) (*Transaction, error) {
+	r := bytes.NewReader(data)
+	t, err := Read(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Len() != 0 {
+		return nil, errors.New("unexpected trailing data in transaction")
+	}
+
+	return t, nil
+}
+
+//Read single transaction from stream (e.g. from raw block)
+func Read(r io.Reader) (*Transaction, error) {
+	var buf [8]byte
+	t := new(Transaction)
+
+	_, err := io.ReadFull(r, buf[:4])
+
+	if err != nil {
+		return nil, err
+	}
+
+	version := binary.LittleEndian.Uint32(buf[:4])
+	t.Version = uint16(version)
+	t.Type = uint16(version >> 16)
+
+	count, err := ReadVarInt(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if count > maxFieldSize {
+		return nil, errVarIntOverflow
+	}
+
+	t.Inputs = make([]Input, count)
+
+	for i := range t.Inputs {
+		in := &t.Inputs[i]
+
+		_, err = io.ReadFull(r, in.PreviousOutput.Hash[:])
+
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = io.ReadFull(r, buf[:4])
+
+		if err != nil {
+			return nil, err
+		}
+
+		in.PreviousOutput.Index = binary.LittleEndian.Uint32(buf[:4])
+		in.Script, err = ReadVarBytes(r, maxFieldSize)
+
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = io.ReadFull(r, buf[:4])
+
+		if err != nil {
+			return nil, err
+		}
+
+		in.Sequence = binary.LittleEndian.Uint32(buf[:4])
+	}
+
+	count, err = ReadVarInt(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if count > maxFieldSize {
+		return nil, errVarIntOverflow
+	}
+
+	t.Outputs = make([]Output, count)
+
+	for i := range t.Outputs {
+		out := &t.Outputs[i]
+
+		_, err = io.ReadFull(r, buf[:])
+
+		if err != nil {
+			return nil, err
+		}
+
+		out.Value = int64(binary.LittleEndian.Uint64(buf[:]))
+		out.Script, err = ReadVarBytes(r, maxFieldSize)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = io.ReadFull(r, buf[:4])
+
+	if err != nil {
+		return nil, err
+	}
+
+	t.LockTime = binary.LittleEndian.Uint32(buf[:4])
+
+	if t.isSpecial() {
+		t.ExtraPayload, err = ReadVarBytes(r, maxFieldSize)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,189 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"github.com/co-in/dash-dapi/evo/block"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	"github.com/co-in/dash-dapi/evo/stream"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"io"
+)
+
+type Progress struct {
+	Height       int
+	TargetHeight int
+	//Wallet transactions found so far
+	Transactions int
+	//Subscription restarts caused by newly derived addresses
+	FilterRebuilds int
+}
+
+type RescanOptions struct {
+	//Defaults to wallet birthday
+	FromBlockHeight *int
+	//Called after each processed block
+	OnProgress    func(Progress)
+	StreamOptions stream.Options
+}
+
+//Rebuild UTXO set and history by replaying blocks from FromBlockHeight to the current tip
+func Rescan(ctx context.Context, client interfaces.IClient, w *Wallet, options RescanOptions) error {
+	node, err := client.SelectRandomNode()
+
+	if err != nil {
+		return err
+	}
+
+	status, err := node.WithContext(ctx).GetStatus()
+
+	if err != nil {
+		return err
+	}
+
+	progress := Progress{
+		Height:       w.Birthday(),
+		TargetHeight: int(status.Blocks),
+	}
+
+	if options.FromBlockHeight != nil {
+		progress.Height = *options.FromBlockHeight
+	}
+
+	if options.StreamOptions.RetryDelay == 0 {
+		options.StreamOptions.RetryDelay = stream.DefaultOptions().RetryDelay
+	}
+
+	w.Reset()
+
+	for progress.Height <= progress.TargetHeight {
+		//Current block is replayed after filter rebuild, ApplyTransaction is idempotent
+		extended, err := rescanRange(ctx, client, w, options, &progress)
+
+		if err != nil {
+			return err
+		}
+
+		if !extended {
+			break
+		}
+
+		progress.FilterRebuilds++
+	}
+
+	return nil
+}
+
+//Returns true when stream must be restarted with extended filter from progress.Height
+func rescanRange(ctx context.Context, client interfaces.IClient, w *Wallet, options RescanOptions, progress *Progress) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	filter, err := w.Filter()
+
+	if err != nil {
+		return false, err
+	}
+
+	height := progress.Height
+	count := progress.TargetHeight - progress.Height + 1
+	subscription, err := stream.Subscribe(ctx, client, structures.SubscribeToTransactionsWithProofsRequest{
+		BloomFilter:     filter.Request(),
+		FromBlockHeight: &height,
+		Count:           &count,
+	}, options.StreamOptions)
+
+	if err != nil {
+		return false, err
+	}
+
+	//Transactions of block in progress, applied after merkle proof verification
+	pending := make(map[[32]byte]*transaction.Transaction)
+
+	for {
+		r, err := subscription.Recv()
+
+		if err == io.EOF {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		for _, raw := range r.GetRawTransactions().GetTransactions() {
+			tx, err := transaction.Parse(raw)
+
+			if err != nil {
+				return false, err
+			}
+
+			pending[tx.Hash()] = tx
+		}
+
+		data := r.GetRawMerkleBlock()
+
+		if data == nil {
+			continue
+		}
+
+		merkleBlock, err := block.ParseMerkleBlock(data)
+
+		if err != nil {
+			return false, err
+		}
+
+		matches, err := merkleBlock.ExtractMatches()
+
+		if err != nil {
+			return false, err
+		}
+
+		blockHeight := subscription.LastProcessedHeight()
+		txs := make([]*transaction.Transaction, 0, len(matches))
+
+		for _, hash := range matches {
+			tx, ok := pending[hash]
+
+			if !ok {
+				return false, errors.New("merkle block matches transaction which was not sent")
+			}
+
+			txs = append(txs, tx)
+		}
+
+		extended, err := w.Discover(txs)
+
+		if err != nil {
+			return false, err
+		}
+
+		for _, tx := range txs {
+			_, _, err = w.ApplyTransaction(tx, blockHeight)
+
+			if err != nil {
+				return false, err
+			}
+		}
+
+		progress.Transactions = w.TransactionCount()
+		pending = make(map[[32]byte]*transaction.Transaction)
+
+		if extended {
+			progress.Height = blockHeight
+
+			return true, nil
+		}
+
+		progress.Height = blockHeight + 1
+
+		if options.OnProgress != nil {
+			options.OnProgress(*progress)
+		}
+
+		if blockHeight >= progress.TargetHeight {
+			return false, nil
+		}
+	}
+}
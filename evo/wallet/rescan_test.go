@@ -0,0 +1,263 @@
+package wallet_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/co-in/dash-dapi/evo"
+	"github.com/co-in/dash-dapi/evo/dapitest"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/evo/stream"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"github.com/co-in/dash-dapi/evo/wallet"
+	"testing"
+	"time"
+)
+
+const tipHeight = 1000
+
+//Fake hash of block at height, RPC byte order
+func blockHash(height int) string {
+	return fmt.Sprintf("%064x", height)
+}
+
+//Merkle block of single matched transaction, header names block at prevHeight
+func merkleBlock(prevHeight int, tx *transaction.Transaction) []byte {
+	prev, _ := hex.DecodeString(blockHash(prevHeight))
+	hash := tx.Hash()
+	data := make([]byte, 80)
+	copy(data[4:36], transaction.ReverseBytes(prev))
+	copy(data[36:68], hash[:])
+	data = append(data, 1, 0, 0, 0, 1)
+	data = append(data, hash[:]...)
+
+	return append(data, 1, 1)
+}
+
+//Raw block with DIP4 coinbase of height
+func rawBlock(height int) []byte {
+	payload := make([]byte, 2+4+32)
+	binary.LittleEndian.PutUint16(payload, 2)
+	binary.LittleEndian.PutUint32(payload[2:], uint32(height))
+
+	coinbase := &transaction.Transaction{
+		Version:      3,
+		Type:         5,
+		Inputs:       []transaction.Input{{PreviousOutput: transaction.OutPoint{Index: 0xffffffff}, Script: []byte{1, 1}, Sequence: transaction.DefaultSequence}},
+		Outputs:      []transaction.Output{{Script: []byte{0x6a}}},
+		ExtraPayload: payload,
+	}
+
+	return append(append(make([]byte, 80), 1), coinbase.Bytes()...)
+}
+
+//Fake node of chain up to tipHeight, streams given events to every subscription
+func newChainServer(t *testing.T, events ...dapitest.StreamEvent) *dapitest.Server {
+	t.Helper()
+	s, err := dapitest.NewServer()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(s.Close)
+
+	s.SetResponse(dapitest.MethodGetStatus, &proto.GetStatusResponse{Blocks: tipHeight, Connections: 8})
+	s.Handle(dapitest.MethodGetBlockHash, func(ctx context.Context, request interface{}) (interface{}, error) {
+		var params struct {
+			Height int `json:"height"`
+		}
+
+		err := json.Unmarshal(request.(json.RawMessage), &params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return blockHash(params.Height), nil
+	})
+	s.Handle(dapitest.MethodGetBlock, func(ctx context.Context, request interface{}) (interface{}, error) {
+		hash := request.(*proto.GetBlockRequest).GetHash()
+
+		for height := 0; height <= tipHeight; height++ {
+			if blockHash(height) == hash {
+				return &proto.GetBlockResponse{Block: rawBlock(height)}, nil
+			}
+		}
+
+		return nil, fmt.Errorf("unknown block %s", hash)
+	})
+	s.SetStream(dapitest.MethodSubscribeToTransactionsWithProofs, events...)
+
+	return s
+}
+
+//Account key of test wallet
+func testAccount(t *testing.T) *hdkeychain.ExtendedKey {
+	t.Helper()
+	master, err := hdkeychain.NewMaster(make([]byte, 32), &chaincfg.MainNetParams)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := master.Child(hdkeychain.HardenedKeyStart)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return account
+}
+
+//P2PKH script of account address
+func script(t *testing.T, account *hdkeychain.ExtendedKey, chain uint32, index uint32) []byte {
+	t.Helper()
+	branch, err := account.Child(chain)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := branch.Child(index)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubKey, err := key.ECPubKey()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return transaction.PayToPubKeyHashScript(btcutil.Hash160(pubKey.SerializeCompressed()))
+}
+
+func TestRescan(t *testing.T) {
+	account := testAccount(t)
+
+	//Block 100 pays last address of initial gap and address beyond it, which is derived only after filter rebuild
+	received := &transaction.Transaction{
+		Version: transaction.DefaultVersion,
+		Inputs:  []transaction.Input{{PreviousOutput: transaction.OutPoint{Hash: [32]byte{1}}, Script: []byte{1, 1}, Sequence: transaction.DefaultSequence}},
+		Outputs: []transaction.Output{
+			{Value: 50000, Script: script(t, account, wallet.ExternalChain, wallet.DefaultGapLimit-1)},
+			{Value: 70000, Script: script(t, account, wallet.ExternalChain, wallet.DefaultGapLimit+5)},
+		},
+	}
+
+	//Block 150 spends first output and returns rest to address below last used one, so gap is not extended again
+	sent := &transaction.Transaction{
+		Version: transaction.DefaultVersion,
+		Inputs:  []transaction.Input{{PreviousOutput: transaction.OutPoint{Hash: received.Hash()}, Script: []byte{1, 1}, Sequence: transaction.DefaultSequence}},
+		Outputs: []transaction.Output{
+			{Value: 30000, Script: transaction.PayToPubKeyHashScript(make([]byte, 20))},
+			{Value: 19000, Script: script(t, account, wallet.ExternalChain, 3)},
+		},
+	}
+
+	s := newChainServer(t,
+		dapitest.Transactions(received.Bytes()),
+		dapitest.MerkleBlock(merkleBlock(99, received)),
+		dapitest.Transactions(sent.Bytes()),
+		dapitest.MerkleBlock(merkleBlock(149, sent)),
+	)
+
+	c, err := evo.NewClient(nil, s.Host, s.JSONRPCPort, s.GRPCPort, evo.WithTimeout(5*time.Second))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	xpub, err := account.Neuter()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := wallet.New(xpub.String(), transaction.TestNetParams, 100)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//UTXO set and history of previous scan are replaced
+	_, _, err = w.ApplyTransaction(&transaction.Transaction{
+		Version: transaction.DefaultVersion,
+		Outputs: []transaction.Output{{Value: 1, Script: script(t, account, wallet.ExternalChain, 0)}},
+	}, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var progress wallet.Progress
+	err = wallet.Rescan(context.Background(), c, w, wallet.RescanOptions{
+		OnProgress: func(p wallet.Progress) {
+			progress = p
+		},
+		StreamOptions: stream.Options{RetryDelay: time.Millisecond},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if progress.Height != 151 || progress.TargetHeight != tipHeight || progress.Transactions != 2 || progress.FilterRebuilds != 1 {
+		t.Fatalf("progress %+v", progress)
+	}
+
+	utxos := w.UTXOs()
+
+	if len(utxos) != 2 || utxos[0].Value != 70000 || utxos[0].Height != 100 || utxos[1].Value != 19000 || utxos[1].Height != 150 {
+		t.Fatalf("UTXOs %+v", utxos)
+	}
+
+	if w.Balance() != 89000 {
+		t.Fatalf("balance %d", w.Balance())
+	}
+
+	history := w.History()
+
+	if len(history) != 2 || history[0].TxID != received.TxID() || history[0].Received != 120000 ||
+		history[1].TxID != sent.TxID() || history[1].Sent != 50000 || history[1].Received != 19000 {
+		t.Fatalf("history %+v", history)
+	}
+
+	//Scan is restarted from block which used new addresses, filter covers addresses derived for gap after them
+	requests := s.Requests(dapitest.MethodSubscribeToTransactionsWithProofs)
+
+	if len(requests) != 2 {
+		t.Fatalf("%d subscriptions", len(requests))
+	}
+
+	first := requests[0].(*proto.TransactionsWithProofsRequest)
+	second := requests[1].(*proto.TransactionsWithProofsRequest)
+
+	if first.GetFromBlockHeight() != 100 || second.GetFromBlockHeight() != 100 || second.GetCount() != tipHeight-100+1 {
+		t.Fatalf("subscriptions from %d and %d", first.GetFromBlockHeight(), second.GetFromBlockHeight())
+	}
+
+	if len(second.GetBloomFilter().GetVData()) <= len(first.GetBloomFilter().GetVData()) {
+		t.Fatal("filter is not extended by derived addresses")
+	}
+
+	//Next receive address follows address beyond initial gap
+	address, err := w.ReceiveAddress()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, _ := transaction.ScriptToAddress(script(t, account, wallet.ExternalChain, wallet.DefaultGapLimit+6), transaction.TestNetParams)
+
+	if address != expected {
+		t.Fatalf("receive address %s, expected %s", address, expected)
+	}
+}
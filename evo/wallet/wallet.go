@@ -0,0 +1,435 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/co-in/dash-dapi/evo/bloom"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"sort"
+	"sync"
+)
+
+const (
+	ExternalChain uint32 = 0
+	//Change addresses
+	InternalChain uint32 = 1
+	//Unused addresses derived ahead of last used one
+	DefaultGapLimit = 20
+	//False positive rate of wallet bloom filter
+	filterFalsePositiveRate = 0.0001
+)
+
+type HistoryEntry struct {
+	TxID string
+	//Zero for unconfirmed transaction
+	Height   int
+	Received int64
+	Sent     int64
+}
+
+type keyInfo struct {
+	chain  uint32
+	index  uint32
+	pubKey []byte
+}
+
+type chain struct {
+	//Number of derived addresses
+	derived uint32
+	//Index of last used address + 1
+	used uint32
+}
+
+//HD (BIP32) account wallet, keeps UTXO set and history of P2PKH addresses
+type Wallet struct {
+	sync.Mutex
+	params   transaction.AddressParams
+	account  *hdkeychain.ExtendedKey
+	gapLimit uint32
+	birthday int
+	chains   [2]chain
+	scripts  map[string]keyInfo
+	utxos    map[transaction.OutPoint]transaction.UTXO
+	history  map[[32]byte]*HistoryEntry
+}
+
+//Wallet from account extended key (xpub for watch-only or xprv), birthday is height of first wallet transaction
+func New(accountKey string, params transaction.AddressParams, birthday int) (*Wallet, error) {
+	account, err := hdkeychain.NewKeyFromString(accountKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Wallet{
+		params:   params,
+		account:  account,
+		gapLimit: DefaultGapLimit,
+		birthday: birthday,
+		scripts:  make(map[string]keyInfo),
+	}
+
+	w.reset()
+
+	for _, c := range []uint32{ExternalChain, InternalChain} {
+		err = w.deriveUpTo(c, w.gapLimit)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+func (w *Wallet) Birthday() int {
+	return w.birthday
+}
+
+func (w *Wallet) reset() {
+	w.utxos = make(map[transaction.OutPoint]transaction.UTXO)
+	w.history = make(map[[32]byte]*HistoryEntry)
+}
+
+//Forget UTXO set and history before rescan
+func (w *Wallet) Reset() {
+	w.Lock()
+	defer w.Unlock()
+
+	w.reset()
+}
+
+func (w *Wallet) deriveUpTo(chainIndex uint32, count uint32) error {
+	c := &w.chains[chainIndex]
+
+	if c.derived >= count {
+		return nil
+	}
+
+	branch, err := w.account.Child(chainIndex)
+
+	if err != nil {
+		return err
+	}
+
+	for ; c.derived < count; c.derived++ {
+		key, err := branch.Child(c.derived)
+
+		if err != nil {
+			return err
+		}
+
+		pubKey, err := key.ECPubKey()
+
+		if err != nil {
+			return err
+		}
+
+		compressed := pubKey.SerializeCompressed()
+		script := transaction.PayToPubKeyHashScript(btcutil.Hash160(compressed))
+		w.scripts[string(script)] = keyInfo{chain: chainIndex, index: c.derived, pubKey: compressed}
+	}
+
+	return nil
+}
+
+//Mark address as used and keep gap of unused addresses, returns true when new addresses were derived
+func (w *Wallet) markUsed(info keyInfo) (bool, error) {
+	c := &w.chains[info.chain]
+
+	if info.index < c.used {
+		return false, nil
+	}
+
+	c.used = info.index + 1
+	derived := c.derived
+	err := w.deriveUpTo(info.chain, c.used+w.gapLimit)
+
+	return c.derived != derived, err
+}
+
+func (w *Wallet) address(chainIndex uint32, index uint32) (string, error) {
+	branch, err := w.account.Child(chainIndex)
+
+	if err != nil {
+		return "", err
+	}
+
+	key, err := branch.Child(index)
+
+	if err != nil {
+		return "", err
+	}
+
+	pubKey, err := key.ECPubKey()
+
+	if err != nil {
+		return "", err
+	}
+
+	return transaction.PubKeyHashAddress(pubKey.SerializeCompressed(), w.params), nil
+}
+
+//First unused receive address
+func (w *Wallet) ReceiveAddress() (string, error) {
+	w.Lock()
+	defer w.Unlock()
+
+	return w.address(ExternalChain, w.chains[ExternalChain].used)
+}
+
+//First unused change address
+func (w *Wallet) ChangeAddress() (string, error) {
+	w.Lock()
+	defer w.Unlock()
+
+	return w.address(InternalChain, w.chains[InternalChain].used)
+}
+
+//All derived addresses, including gap
+func (w *Wallet) Addresses() []string {
+	w.Lock()
+	defer w.Unlock()
+
+	var addresses []string
+
+	for script := range w.scripts {
+		address, err := transaction.ScriptToAddress([]byte(script), w.params)
+
+		if err == nil {
+			addresses = append(addresses, address)
+		}
+	}
+
+	sort.Strings(addresses)
+
+	return addresses
+}
+
+//Bloom filter matching all derived keys and unspent outputs
+func (w *Wallet) Filter() (*bloom.Filter, error) {
+	w.Lock()
+	defer w.Unlock()
+
+	var tweak [4]byte
+
+	_, err := rand.Read(tweak[:])
+
+	if err != nil {
+		return nil, err
+	}
+
+	f := bloom.New(len(w.scripts)*2+len(w.utxos), filterFalsePositiveRate, binary.LittleEndian.Uint32(tweak[:]), bloom.UpdateAll)
+
+	for script, info := range w.scripts {
+		//Pubkey hash matches outputs, pubkey matches spending inputs
+		f.Add([]byte(script)[3:23])
+		f.Add(info.pubKey)
+	}
+
+	for outPoint := range w.utxos {
+		f.AddOutPoint(outPoint.Hash, outPoint.Index)
+	}
+
+	return f, nil
+}
+
+//Mark addresses paid by transactions as used until no new addresses are derived,
+//so outputs to addresses beyond the gap are known before transactions are applied
+func (w *Wallet) Discover(txs []*transaction.Transaction) (bool, error) {
+	w.Lock()
+	defer w.Unlock()
+
+	extended := false
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, tx := range txs {
+			for _, out := range tx.Outputs {
+				info, ok := w.scripts[string(out.Script)]
+
+				if !ok {
+					continue
+				}
+
+				derived, err := w.markUsed(info)
+
+				if err != nil {
+					return false, err
+				}
+
+				changed = changed || derived
+			}
+		}
+
+		extended = extended || changed
+	}
+
+	return extended, nil
+}
+
+//Apply confirmed (height > 0) or unconfirmed transaction.
+//Returns whether transaction belongs to wallet and whether new addresses were derived (filter must be rebuilt)
+func (w *Wallet) ApplyTransaction(tx *transaction.Transaction, height int) (bool, bool, error) {
+	w.Lock()
+	defer w.Unlock()
+
+	hash := tx.Hash()
+
+	if entry, ok := w.history[hash]; ok {
+		if height > 0 {
+			entry.Height = height
+		}
+
+		return true, false, nil
+	}
+
+	entry := &HistoryEntry{
+		TxID:   tx.TxID(),
+		Height: height,
+	}
+
+	for _, in := range tx.Inputs {
+		if u, ok := w.utxos[in.PreviousOutput]; ok {
+			entry.Sent += u.Value
+			delete(w.utxos, in.PreviousOutput)
+		}
+	}
+
+	extended := false
+
+	for i, out := range tx.Outputs {
+		info, ok := w.scripts[string(out.Script)]
+
+		if !ok {
+			continue
+		}
+
+		outPoint := transaction.OutPoint{Hash: hash, Index: uint32(i)}
+		address, _ := transaction.ScriptToAddress(out.Script, w.params)
+		w.utxos[outPoint] = transaction.UTXO{
+			OutPoint: outPoint,
+			Address:  address,
+			Script:   out.Script,
+			Value:    out.Value,
+			Height:   height,
+		}
+		entry.Received += out.Value
+
+		derived, err := w.markUsed(info)
+
+		if err != nil {
+			return false, false, err
+		}
+
+		extended = extended || derived
+	}
+
+	if entry.Sent == 0 && entry.Received == 0 {
+		return false, false, nil
+	}
+
+	w.history[hash] = entry
+
+	return true, extended, nil
+}
+
+func (w *Wallet) TransactionCount() int {
+	w.Lock()
+	defer w.Unlock()
+
+	return len(w.history)
+}
+
+func (w *Wallet) UTXOs() []transaction.UTXO {
+	w.Lock()
+	defer w.Unlock()
+
+	utxos := make([]transaction.UTXO, 0, len(w.utxos))
+
+	for _, u := range w.utxos {
+		utxos = append(utxos, u)
+	}
+
+	sort.Slice(utxos, func(i, j int) bool {
+		return utxos[i].Height < utxos[j].Height
+	})
+
+	return utxos
+}
+
+//History ordered by height, unconfirmed transactions last
+func (w *Wallet) History() []HistoryEntry {
+	w.Lock()
+	defer w.Unlock()
+
+	history := make([]HistoryEntry, 0, len(w.history))
+
+	for _, entry := range w.history {
+		history = append(history, *entry)
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		if history[i].Height == 0 || history[j].Height == 0 {
+			return history[j].Height == 0 && history[i].Height != 0
+		}
+
+		return history[i].Height < history[j].Height
+	})
+
+	return history
+}
+
+func (w *Wallet) Balance() int64 {
+	w.Lock()
+	defer w.Unlock()
+
+	var balance int64
+
+	for _, u := range w.utxos {
+		balance += u.Value
+	}
+
+	return balance
+}
+
+//Implements transaction.Signer for wallets created from xprv
+func (w *Wallet) PrivateKey(script []byte) (*btcec.PrivateKey, bool, error) {
+	w.Lock()
+	defer w.Unlock()
+
+	if !w.account.IsPrivate() {
+		return nil, false, errors.New("watch-only wallet can not sign")
+	}
+
+	info, ok := w.scripts[string(script)]
+
+	if !ok {
+		return nil, false, errors.New("output script does not belong to wallet")
+	}
+
+	branch, err := w.account.Child(info.chain)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	key, err := branch.Child(info.index)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	privateKey, err := key.ECPrivKey()
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return privateKey, true, nil
+}
@@ -0,0 +1,403 @@
+package gateway
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/bloom"
+	"github.com/co-in/dash-dapi/evo/stream"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"github.com/co-in/dash-dapi/logging"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//Event types of stream bridge
+const (
+	eventTransaction = "transaction"
+	eventMerkleBlock = "merkleBlock"
+	eventInstantLock = "instantLock"
+	eventHeartbeat   = "heartbeat"
+	eventError       = "error"
+)
+
+//Limit of subscription request body
+const maxStreamRequest = 1 << 20
+
+//Subscription of stream bridge: addresses or bloom filter, optionally resumed from block height
+type streamRequest struct {
+	Addresses       []string      `json:"addresses,omitempty"`
+	BloomFilter     *streamFilter `json:"bloomFilter,omitempty"`
+	FromBlockHeight *int          `json:"fromBlockHeight,omitempty"`
+	Count           *int          `json:"count,omitempty"`
+}
+
+type streamFilter struct {
+	//Hex encoded filter data
+	Data      string `json:"data"`
+	HashFuncs uint32 `json:"hashFuncs"`
+	Tweak     uint32 `json:"tweak"`
+	Flags     uint32 `json:"flags"`
+}
+
+//Message of stream bridge, fields depend on Type
+type streamEvent struct {
+	Type        string           `json:"type"`
+	Height      int              `json:"height,omitempty"`
+	TxID        string           `json:"txid,omitempty"`
+	Transaction *restTransaction `json:"transaction,omitempty"`
+	MerkleRoot  string           `json:"merkleRoot,omitempty"`
+	Time        *time.Time       `json:"time,omitempty"`
+	//Txids of matched transactions of merkle block
+	Matches []string `json:"matches,omitempty"`
+	Hex     string   `json:"hex,omitempty"`
+	Message string   `json:"message,omitempty"`
+}
+
+//Stream of decoded transactions, merkle blocks and InstantSend locks matching addresses or bloom filter.
+//WebSocket: subscription is given by query parameters or first JSON message, events are JSON messages.
+//Server-Sent Events: subscription is given by query parameters (GET) or JSON body (POST),
+//merkle block events carry height as id, so reconnecting EventSource resumes after last block.
+//Query parameters: address (repeatable), filter, hashFuncs, tweak, flags, fromBlockHeight, count
+func (g *Gateway) StreamHandler() http.Handler {
+	return http.HandlerFunc(g.serveStream)
+}
+
+func (g *Gateway) serveStream(w http.ResponseWriter, r *http.Request) {
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		//Browsers send Origin of page, bridge is public like REST routes
+		server := websocket.Server{Handler: func(conn *websocket.Conn) {
+			g.serveWebSocket(r.Context(), conn)
+		}}
+		server.ServeHTTP(w, r)
+
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodPost)
+		writeREST(w, http.StatusMethodNotAllowed, map[string]restError{"error": {Code: "method_not_allowed", Message: "method not allowed"}})
+
+		return
+	}
+
+	g.serveSSE(w, r)
+}
+
+func (g *Gateway) serveSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+
+	if !ok {
+		writeRESTError(w, status.Error(codes.Unimplemented, "streaming is not supported by connection"))
+
+		return
+	}
+
+	request, err := queryStreamRequest(r)
+
+	if err == nil && r.Method == http.MethodPost {
+		err = json.NewDecoder(io.LimitReader(r.Body, maxStreamRequest)).Decode(&request)
+
+		if err != nil {
+			err = status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	//EventSource resends id of last event after reconnection
+	if id := r.Header.Get("Last-Event-ID"); err == nil && id != "" {
+		height, parseErr := strconv.Atoi(id)
+
+		if parseErr != nil {
+			err = status.Error(codes.InvalidArgument, "Last-Event-ID: "+parseErr.Error())
+		} else {
+			next := height + 1
+			request.FromBlockHeight = &next
+		}
+	}
+
+	var subscriber *stream.Subscriber
+
+	if err == nil {
+		subscriber, err = g.subscribe(r.Context(), request)
+	}
+
+	if err != nil {
+		writeRESTError(w, err)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	_ = g.bridge(r.Context(), subscriber, nil, func(e streamEvent) error {
+		data, err := json.Marshal(e)
+
+		if err != nil {
+			return err
+		}
+
+		if e.Type == eventMerkleBlock {
+			_, err = fmt.Fprintf(w, "id: %d\n", e.Height)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+
+		if err != nil {
+			return err
+		}
+
+		flusher.Flush()
+
+		return nil
+	})
+}
+
+func (g *Gateway) serveWebSocket(ctx context.Context, conn *websocket.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	send := func(e streamEvent) error {
+		return websocket.JSON.Send(conn, e)
+	}
+
+	//Proxies watching control frames see traffic, heartbeat events are only data for them
+	ping := func() error {
+		conn.PayloadType = websocket.PingFrame
+		_, err := conn.Write(nil)
+		conn.PayloadType = websocket.TextFrame
+
+		return err
+	}
+
+	request, err := queryStreamRequest(conn.Request())
+
+	if err == nil && len(request.Addresses) == 0 && request.BloomFilter == nil {
+		conn.MaxPayloadBytes = maxStreamRequest
+		err = websocket.JSON.Receive(conn, &request)
+
+		if err != nil {
+			err = status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	var subscriber *stream.Subscriber
+
+	if err == nil {
+		subscriber, err = g.subscribe(ctx, request)
+	}
+
+	if err != nil {
+		_ = send(streamEvent{Type: eventError, Message: err.Error()})
+
+		return
+	}
+
+	//Client messages are ignored, read fails when client disconnects
+	go func() {
+		defer cancel()
+
+		var message []byte
+
+		for websocket.Message.Receive(conn, &message) == nil {
+		}
+	}()
+
+	_ = g.bridge(ctx, subscriber, ping, send)
+}
+
+//Subscription given by query parameters, missing parameters are left empty
+func queryStreamRequest(r *http.Request) (streamRequest, error) {
+	query := r.URL.Query()
+	request := streamRequest{
+		Addresses: query["address"],
+	}
+
+	if data := query.Get("filter"); data != "" {
+		request.BloomFilter = &streamFilter{Data: data}
+
+		for name, target := range map[string]*uint32{"hashFuncs": &request.BloomFilter.HashFuncs, "tweak": &request.BloomFilter.Tweak, "flags": &request.BloomFilter.Flags} {
+			if value := query.Get(name); value != "" {
+				n, err := strconv.ParseUint(value, 10, 32)
+
+				if err != nil {
+					return request, status.Error(codes.InvalidArgument, name+": "+err.Error())
+				}
+
+				*target = uint32(n)
+			}
+		}
+	}
+
+	var err error
+
+	request.FromBlockHeight, err = restInt(query, "fromBlockHeight")
+
+	if err != nil {
+		return request, err
+	}
+
+	request.Count, err = restInt(query, "count")
+
+	return request, err
+}
+
+//Start managed subscription, it moves to another node when stream fails
+func (g *Gateway) subscribe(ctx context.Context, request streamRequest) (*stream.Subscriber, error) {
+	if (len(request.Addresses) == 0) == (request.BloomFilter == nil) {
+		return nil, status.Error(codes.InvalidArgument, "one of addresses or bloomFilter is required")
+	}
+
+	var filter structures.BloomFilterRequest
+
+	if request.BloomFilter != nil {
+		data, err := hex.DecodeString(request.BloomFilter.Data)
+
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "bloomFilter: "+err.Error())
+		}
+
+		filter = structures.BloomFilterRequest{
+			Data:     data,
+			HashFunc: request.BloomFilter.HashFuncs,
+			Tweak:    request.BloomFilter.Tweak,
+			Flags:    request.BloomFilter.Flags,
+		}
+	} else {
+		network := g.client.GetNetwork()
+
+		if network == nil {
+			return nil, status.Error(codes.FailedPrecondition, "network of gateway is unknown, addresses can not be decoded, use bloomFilter")
+		}
+
+		addressFilter, err := bloom.NewAddressFilter(request.Addresses, network.Address, 0)
+
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		filter = addressFilter.Request()
+	}
+
+	options := stream.Options{
+		MaxRetries: g.options.Attempts,
+		RetryDelay: g.options.RetryDelay,
+		Metrics:    g.options.Metrics,
+	}
+
+	options.OnReconnect = func(e stream.ReconnectEvent) {
+		logging.Warn(g.options.Logger, "Stream bridge moved", logging.Any("from", e.From), logging.Node(e.To), logging.Any("from_block_height", e.FromBlockHeight), logging.Err(e.Err))
+	}
+
+	return stream.NewSubscriber(ctx, g.client, structures.SubscribeToTransactionsWithProofsRequest{
+		BloomFilter:     filter,
+		FromBlockHeight: request.FromBlockHeight,
+		Count:           request.Count,
+	}, options)
+}
+
+//Deliver events and heartbeats until ctx is done, stream finished or send failed. Heartbeat is preceded by ping of transport, when it has one.
+//Client slower than stream is disconnected with error event once its buffer is full
+func (g *Gateway) bridge(ctx context.Context, subscriber *stream.Subscriber, ping func() error, send func(streamEvent) error) error {
+	events := subscriber.Events(g.options.StreamBuffer, stream.PolicyError)
+	subscriber.Start()
+
+	if g.metrics != nil {
+		g.metrics.streamClients.Add(1)
+		defer g.metrics.streamClients.Add(-1)
+	}
+
+	heartbeat := time.NewTicker(g.options.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-heartbeat.C:
+			if ping != nil {
+				err := ping()
+
+				if err != nil {
+					return err
+				}
+			}
+
+			now = now.UTC()
+			err := send(streamEvent{Type: eventHeartbeat, Time: &now})
+
+			if err != nil {
+				return err
+			}
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			if event, ok := e.(stream.ErrorEvent); ok {
+				_ = send(streamEvent{Type: eventError, Message: event.Err.Error()})
+
+				return event.Err
+			}
+
+			err := send(g.newStreamEvent(e))
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (g *Gateway) newStreamEvent(e stream.Event) streamEvent {
+	switch event := e.(type) {
+	case stream.TransactionEvent:
+		return streamEvent{
+			Type:        eventTransaction,
+			TxID:        event.TxID,
+			Transaction: g.newRESTTransaction(event.Raw, event.Transaction),
+		}
+	case stream.MerkleBlockEvent:
+		blockTime := time.Unix(int64(event.MerkleBlock.Header.Timestamp), 0).UTC()
+		view := streamEvent{
+			Type:       eventMerkleBlock,
+			Height:     event.Height,
+			MerkleRoot: hex.EncodeToString(transaction.ReverseBytes(event.MerkleBlock.Header.MerkleRoot[:])),
+			Time:       &blockTime,
+			Matches:    make([]string, 0, len(event.Matches)),
+			Hex:        hex.EncodeToString(event.Raw),
+		}
+
+		for _, hash := range event.Matches {
+			view.Matches = append(view.Matches, hex.EncodeToString(transaction.ReverseBytes(hash[:])))
+		}
+
+		return view
+	case stream.InstantLockEvent:
+		return streamEvent{
+			Type: eventInstantLock,
+			TxID: event.TxID,
+			Hex:  hex.EncodeToString(event.Raw),
+		}
+	}
+
+	return streamEvent{Type: eventError, Message: "unknown event"}
+}
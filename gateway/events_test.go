@@ -0,0 +1,420 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/dapitest"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/evo/stream"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"golang.org/x/net/websocket"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+//Fake hash of block at height, RPC byte order
+func fakeBlockHash(height int) string {
+	return fmt.Sprintf("%064x", height)
+}
+
+//Merkle block of single matched transaction, header names block at prevHeight
+func fakeMerkleBlock(prevHeight int, tx *transaction.Transaction) []byte {
+	prev, _ := hex.DecodeString(fakeBlockHash(prevHeight))
+	hash := tx.Hash()
+	data := make([]byte, 80)
+	copy(data[4:36], transaction.ReverseBytes(prev))
+	copy(data[36:68], hash[:])
+	data = append(data, 1, 0, 0, 0, 1)
+	data = append(data, hash[:]...)
+
+	return append(data, 1, 1)
+}
+
+func instantLock(tx *transaction.Transaction) []byte {
+	hash := tx.Hash()
+	data := append([]byte{0}, hash[:]...)
+
+	return append(data, make([]byte, 96)...)
+}
+
+//Fake node streaming transaction, its merkle block at height 100 and InstantSend lock to every subscription
+func newStreamServer(t *testing.T) (*dapitest.Server, *transaction.Transaction) {
+	t.Helper()
+	tx := &transaction.Transaction{
+		Version: transaction.DefaultVersion,
+		Inputs:  []transaction.Input{{PreviousOutput: transaction.OutPoint{Hash: [32]byte{1}}, Script: []byte{1, 1}, Sequence: transaction.DefaultSequence}},
+		Outputs: []transaction.Output{{Value: 1000, Script: []byte{0x6a}}},
+	}
+
+	s := newNodeServer(t)
+	s.SetResponse(dapitest.MethodGetStatus, &proto.GetStatusResponse{Blocks: 1000})
+	s.Handle(dapitest.MethodGetBlockHash, func(ctx context.Context, request interface{}) (interface{}, error) {
+		var params struct {
+			Height int `json:"height"`
+		}
+
+		err := json.Unmarshal(request.(json.RawMessage), &params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return fakeBlockHash(params.Height), nil
+	})
+	s.SetStream(dapitest.MethodSubscribeToTransactionsWithProofs,
+		dapitest.Transactions(tx.Bytes()),
+		dapitest.MerkleBlock(fakeMerkleBlock(99, tx)),
+		dapitest.InstantLocks(instantLock(tx)),
+		dapitest.Hold(),
+	)
+
+	return s, tx
+}
+
+func newStreamEndpoint(t *testing.T, s *dapitest.Server, options Options) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(New(newNodeClient(t, s), options).RESTHandler())
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+//Subscription request received by node
+func subscription(t *testing.T, s *dapitest.Server) *proto.TransactionsWithProofsRequest {
+	t.Helper()
+	requests := s.Requests(dapitest.MethodSubscribeToTransactionsWithProofs)
+
+	if len(requests) != 1 {
+		t.Fatalf("%d subscriptions", len(requests))
+	}
+
+	return requests[0].(*proto.TransactionsWithProofsRequest)
+}
+
+//Fields of Server-Sent Event
+type sseEvent struct {
+	id    string
+	event string
+	data  string
+}
+
+func readSSE(t *testing.T, r *bufio.Reader) sseEvent {
+	t.Helper()
+	var e sseEvent
+
+	for {
+		line, err := r.ReadString('\n')
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "":
+			return e
+		case strings.HasPrefix(line, "id: "):
+			e.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			e.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.data = strings.TrimPrefix(line, "data: ")
+		default:
+			t.Fatalf("unexpected line %q", line)
+		}
+	}
+}
+
+func TestSSE(t *testing.T) {
+	s, tx := newStreamServer(t)
+	server := newStreamEndpoint(t, s, DefaultOptions())
+
+	response, err := http.Get(server.URL + "/v0/stream?filter=ff&hashFuncs=3&tweak=7&fromBlockHeight=100")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK || response.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("%d %s", response.StatusCode, response.Header.Get("Content-Type"))
+	}
+
+	r := bufio.NewReader(response.Body)
+	tests := []struct {
+		id    string
+		event string
+	}{
+		{"", eventTransaction},
+		{"100", eventMerkleBlock},
+		{"", eventInstantLock},
+	}
+
+	for _, test := range tests {
+		e := readSSE(t, r)
+
+		if e.id != test.id || e.event != test.event {
+			t.Fatalf("event %+v, expected %s with id %q", e, test.event, test.id)
+		}
+
+		var data streamEvent
+		err = json.Unmarshal([]byte(e.data), &data)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if data.Type != test.event || (data.Type != eventMerkleBlock && data.TxID != tx.TxID()) {
+			t.Fatalf("data %s", e.data)
+		}
+	}
+
+	request := subscription(t, s)
+
+	if request.GetFromBlockHeight() != 100 || hex.EncodeToString(request.GetBloomFilter().GetVData()) != "ff" || request.GetBloomFilter().GetNHashFuncs() != 3 || request.GetBloomFilter().GetNTweak() != 7 {
+		t.Fatalf("subscription %v", request)
+	}
+}
+
+//Reconnecting EventSource resumes after last merkle block
+func TestSSELastEventID(t *testing.T) {
+	s, _ := newStreamServer(t)
+	server := newStreamEndpoint(t, s, DefaultOptions())
+
+	request, err := http.NewRequest(http.MethodPost, server.URL+"/v0/stream", strings.NewReader(`{"bloomFilter":{"data":"ff","hashFuncs":3},"fromBlockHeight":50}`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request.Header.Set("Last-Event-ID", "99")
+	response, err := http.DefaultClient.Do(request)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e := readSSE(t, bufio.NewReader(response.Body)); e.event != eventTransaction {
+		t.Fatalf("event %+v", e)
+	}
+
+	_ = response.Body.Close()
+
+	if height := subscription(t, s).GetFromBlockHeight(); height != 100 {
+		t.Fatalf("resumed from %d", height)
+	}
+
+	request, _ = http.NewRequest(http.MethodGet, server.URL+"/v0/stream?filter=ff", nil)
+	request.Header.Set("Last-Event-ID", "last")
+	response, err = http.DefaultClient.Do(request)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = response.Body.Close()
+
+	if response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("invalid Last-Event-ID answered with %d", response.StatusCode)
+	}
+}
+
+func TestWebSocketFirstMessage(t *testing.T) {
+	s, tx := newStreamServer(t)
+	server := newStreamEndpoint(t, s, DefaultOptions())
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/v0/stream", "", server.URL)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer conn.Close()
+
+	err = websocket.JSON.Send(conn, streamRequest{BloomFilter: &streamFilter{Data: "ff", HashFuncs: 3}, FromBlockHeight: intPointer(100)})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{eventTransaction, eventMerkleBlock, eventInstantLock} {
+		var e streamEvent
+		err = websocket.JSON.Receive(conn, &e)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Type != expected || (e.Type == eventMerkleBlock && e.Height != 100) || (e.Type == eventTransaction && e.Transaction.TxID != tx.TxID()) {
+			t.Fatalf("event %+v, expected %s", e, expected)
+		}
+	}
+
+	if request := subscription(t, s); request.GetFromBlockHeight() != 100 || hex.EncodeToString(request.GetBloomFilter().GetVData()) != "ff" {
+		t.Fatalf("subscription %v", request)
+	}
+
+	//Invalid subscription is answered by error event
+	conn, err = websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/v0/stream", "", server.URL)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer conn.Close()
+
+	err = websocket.JSON.Send(conn, streamRequest{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var e streamEvent
+	err = websocket.JSON.Receive(conn, &e)
+
+	if err != nil || e.Type != eventError {
+		t.Fatalf("event %+v, error %v", e, err)
+	}
+}
+
+func intPointer(value int) *int {
+	return &value
+}
+
+//Heartbeat of WebSocket is ping control frame followed by heartbeat event
+func TestWebSocketPing(t *testing.T) {
+	s, _ := newStreamServer(t)
+	options := DefaultOptions()
+	options.Heartbeat = 20 * time.Millisecond
+	server := newStreamEndpoint(t, s, options)
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer conn.Close()
+
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	key := base64.StdEncoding.EncodeToString(make([]byte, 16))
+	_, err = fmt.Fprintf(conn, "GET /v0/stream?filter=ff HTTP/1.1\r\nHost: %s\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: %s\r\nSec-WebSocket-Version: 13\r\nOrigin: %s\r\n\r\n", conn.RemoteAddr(), key, server.URL)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := bufio.NewReader(conn)
+	response, err := http.ReadResponse(r, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake answered with %d", response.StatusCode)
+	}
+
+	//Frames of server are not masked, payloads of events are shorter than 64 KiB
+	var opcodes []byte
+
+	for len(opcodes) == 0 || opcodes[len(opcodes)-1] != websocket.PingFrame {
+		var header [2]byte
+		_, err = io.ReadFull(r, header[:])
+
+		if err != nil {
+			t.Fatalf("no ping frame after %v: %s", opcodes, err)
+		}
+
+		size := int(header[1] & 0x7f)
+
+		if size == 126 {
+			var extended [2]byte
+			_, err = io.ReadFull(r, extended[:])
+			size = int(extended[0])<<8 | int(extended[1])
+		}
+
+		if err == nil {
+			_, err = io.CopyN(io.Discard, r, int64(size))
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		opcodes = append(opcodes, header[0]&0x0f)
+	}
+}
+
+//Client, which does not read events, gets error event once its buffer is full and is disconnected
+func TestSlowClient(t *testing.T) {
+	s, _ := newStreamServer(t)
+	options := DefaultOptions()
+	options.StreamBuffer = 1
+	g := New(newNodeClient(t, s), options)
+
+	from := 100
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscriber, err := stream.NewSubscriber(ctx, g.client, structures.SubscribeToTransactionsWithProofsRequest{
+		BloomFilter:     structures.BloomFilterRequest{Data: []byte{0xff}, HashFunc: 3},
+		FromBlockHeight: &from,
+	}, stream.DefaultOptions())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release := make(chan struct{})
+	var sent []streamEvent
+	result := make(chan error, 1)
+
+	go func() {
+		result <- g.bridge(ctx, subscriber, nil, func(e streamEvent) error {
+			//First event blocks until stream has overflown buffer of client
+			if len(sent) == 0 {
+				<-release
+			}
+
+			sent = append(sent, e)
+
+			return nil
+		})
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+
+	select {
+	case err = <-result:
+	case <-time.After(5 * time.Second):
+		t.Fatal("slow client is not disconnected")
+	}
+
+	if err != stream.ErrSlowConsumer || len(sent) != 2 || sent[1].Type != eventError {
+		t.Fatalf("error %v, sent %+v", err, sent)
+	}
+}
+
+//Zero options are defaulted, heartbeat ticker does not panic
+func TestZeroOptions(t *testing.T) {
+	g := New(noNetworkClient{}, Options{})
+
+	if g.options.Heartbeat <= 0 || g.options.StreamBuffer < 1 || g.options.Attempts < 1 {
+		t.Fatalf("options %+v", g.options)
+	}
+}
@@ -0,0 +1,279 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"github.com/co-in/dash-dapi/evo"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/logging"
+	"github.com/co-in/dash-dapi/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+//Attempts to find node not tried by current call
+const selectNodeTries = 10
+
+type Options struct {
+	//Nodes tried by single call before error is returned
+	Attempts int
+	//Delay between stream reconnections
+	RetryDelay time.Duration
+	//Failovers are logged at warning level, nil discards them
+	Logger logging.Logger
+	//Events buffered for single stream bridge client, slower clients are disconnected
+	StreamBuffer int
+	//Interval of heartbeat events of stream bridge
+	Heartbeat time.Duration
+	//Forwarded calls and streams are measured when set
+	Metrics *metrics.Registry
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Attempts:     3,
+		RetryDelay:   time.Second,
+		StreamBuffer: 256,
+		Heartbeat:    15 * time.Second,
+	}
+}
+
+//Local DAPI endpoint forwarding calls to healthy evonodes
+type Gateway struct {
+	client  interfaces.IClient
+	options Options
+	metrics *gatewayMetrics
+}
+
+type gatewayMetrics struct {
+	requests      *metrics.CounterVec
+	duration      *metrics.HistogramVec
+	streamClients *metrics.GaugeVec
+}
+
+func New(client interfaces.IClient, options Options) *Gateway {
+	if options.Attempts < 1 {
+		options.Attempts = 1
+	}
+
+	if options.StreamBuffer < 1 {
+		options.StreamBuffer = DefaultOptions().StreamBuffer
+	}
+
+	if options.Heartbeat <= 0 {
+		options.Heartbeat = DefaultOptions().Heartbeat
+	}
+
+	if options.Logger == nil {
+		options.Logger = logging.Nop()
+	}
+
+	g := &Gateway{
+		client:  client,
+		options: options,
+	}
+
+	if options.Metrics != nil {
+		g.metrics = &gatewayMetrics{
+			requests:      options.Metrics.Counter("dapi_gateway_requests_total", "Forwarded calls by method and gRPC status code", "method", "code"),
+			duration:      options.Metrics.Histogram("dapi_gateway_request_duration_seconds", "Latency of forwarded calls including failovers", metrics.DefaultBuckets, "method"),
+			streamClients: options.Metrics.Gauge("dapi_gateway_stream_clients", "Connected clients of stream bridge"),
+		}
+	}
+
+	return g
+}
+
+//Register Core, Platform and TransactionsFilterStream services
+func (g *Gateway) RegisterGRPC(server *grpc.Server) {
+	proto.RegisterCoreServer(server, &coreServer{g})
+	proto.RegisterPlatformServer(server, &platformServer{g})
+	proto.RegisterTransactionsFilterStreamServer(server, &transactionsFilterStreamServer{g})
+}
+
+//Endpoints of gateway, nil listener disables endpoint
+type Listeners struct {
+	GRPC    net.Listener
+	JSONRPC net.Listener
+	REST    net.Listener
+	//Prometheus metrics of Options.Metrics on /metrics
+	Metrics net.Listener
+}
+
+//Serve until ctx is done
+func (g *Gateway) Serve(ctx context.Context, listeners Listeners) error {
+	httpEndpoints := []struct {
+		listener net.Listener
+		handler  http.Handler
+	}{
+		{listeners.JSONRPC, g.JSONRPCHandler()},
+		{listeners.REST, g.RESTHandler()},
+		{listeners.Metrics, g.MetricsHandler()},
+	}
+
+	errs := make(chan error, 1+len(httpEndpoints))
+	wg := new(sync.WaitGroup)
+	var gRPCServer *grpc.Server
+	var httpServers []*http.Server
+
+	if listeners.GRPC != nil {
+		gRPCServer = grpc.NewServer()
+		g.RegisterGRPC(gRPCServer)
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			errs <- gRPCServer.Serve(listeners.GRPC)
+		}()
+	}
+
+	for _, endpoint := range httpEndpoints {
+		if endpoint.listener == nil {
+			continue
+		}
+
+		//Requests and hijacked WebSocket connections end with ctx
+		httpServer := &http.Server{
+			Handler: endpoint.handler,
+			BaseContext: func(net.Listener) context.Context {
+				return ctx
+			},
+		}
+		httpServers = append(httpServers, httpServer)
+		wg.Add(1)
+
+		go func(listener net.Listener) {
+			defer wg.Done()
+
+			if err := httpServer.Serve(listener); err != http.ErrServerClosed {
+				errs <- err
+			}
+		}(endpoint.listener)
+	}
+
+	if gRPCServer == nil && len(httpServers) == 0 {
+		return errors.New("no endpoint to serve")
+	}
+
+	var err error
+
+	select {
+	case <-ctx.Done():
+	case err = <-errs:
+	}
+
+	if gRPCServer != nil {
+		gRPCServer.Stop()
+	}
+
+	for _, httpServer := range httpServers {
+		_ = httpServer.Close()
+	}
+
+	wg.Wait()
+
+	return err
+}
+
+//Metrics endpoint, empty when gateway has no registry
+func (g *Gateway) MetricsHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	if g.options.Metrics != nil {
+		mux.Handle("/metrics", g.options.Metrics.Handler())
+	}
+
+	return mux
+}
+
+//Call fn on healthy nodes until success, non-retryable error or attempts are exhausted
+func (g *Gateway) forward(ctx context.Context, method string, fn func(node interfaces.IConnection) error) error {
+	start := time.Now()
+	err := g.forwardAttempts(ctx, method, fn)
+
+	if g.metrics != nil {
+		g.metrics.requests.Inc(method, status.Code(toStatus(err)).String())
+		g.metrics.duration.Observe(time.Since(start).Seconds(), method)
+	}
+
+	return err
+}
+
+func (g *Gateway) forwardAttempts(ctx context.Context, method string, fn func(node interfaces.IConnection) error) error {
+	tried := make(map[string]bool)
+	var err error
+
+	for attempt := 0; attempt < g.options.Attempts; attempt++ {
+		node, selectErr := g.selectNode(tried)
+
+		if selectErr != nil {
+			if err == nil {
+				err = selectErr
+			}
+
+			return err
+		}
+
+		tried[node.GetNodeName()] = true
+		err = fn(node.WithContext(ctx))
+
+		if err == nil || !retryable(ctx, err) {
+			return err
+		}
+
+		logging.Warn(g.options.Logger, "Forward failed", logging.Method(method), logging.Node(node.GetNodeName()), logging.Any("attempt", attempt+1), logging.Any("attempts", g.options.Attempts), logging.Err(err))
+	}
+
+	return err
+}
+
+//Random healthy node not tried yet, tried node only when pool has no other nodes
+func (g *Gateway) selectNode(tried map[string]bool) (interfaces.IConnection, error) {
+	var node interfaces.IConnection
+	var err error
+
+	for i := 0; i < selectNodeTries; i++ {
+		node, err = g.client.SelectRandomNode()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !tried[node.GetNodeName()] {
+			break
+		}
+	}
+
+	return node, nil
+}
+
+//Node failures are retried on another node, errors of request itself are not
+func retryable(ctx context.Context, err error) bool {
+	return ctx.Err() == nil && evo.NodeFailure(err)
+}
+
+//gRPC status of forwarded call, node errors become Unavailable
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch err {
+	case context.Canceled:
+		return status.Error(codes.Canceled, err.Error())
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	return status.Error(codes.Unavailable, err.Error())
+}
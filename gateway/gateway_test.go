@@ -0,0 +1,38 @@
+package gateway
+
+import (
+	"context"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	"github.com/co-in/dash-dapi/evo/network"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+//Client without network, e.g. started with verification disabled
+type noNetworkClient struct {
+	interfaces.IClient
+}
+
+func (noNetworkClient) GetNetwork() *network.Params {
+	return nil
+}
+
+func TestUnknownNetwork(t *testing.T) {
+	g := New(noNetworkClient{}, DefaultOptions())
+	script := transaction.PayToPubKeyHashScript(make([]byte, 20))
+	tx := &transaction.Transaction{Version: transaction.DefaultVersion, Outputs: []transaction.Output{{Value: 1000, Script: script}}}
+
+	view := g.newRESTTransaction(tx.Bytes(), tx)
+
+	if len(view.Outputs) != 1 || view.Outputs[0].Address != "" {
+		t.Fatalf("outputs %+v, expected output without address", view.Outputs)
+	}
+
+	_, err := g.subscribe(context.Background(), streamRequest{Addresses: []string{"yNdiwCY1pUBijwayLXh5Qz3ZFVnMkQXHgb"}})
+
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("error %v, expected FailedPrecondition", err)
+	}
+}
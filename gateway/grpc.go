@@ -0,0 +1,279 @@
+package gateway
+
+import (
+	"context"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/evo/stream"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+)
+
+type coreServer struct {
+	*Gateway
+}
+
+type platformServer struct {
+	*Gateway
+}
+
+type transactionsFilterStreamServer struct {
+	*Gateway
+}
+
+func (s *coreServer) GetStatus(ctx context.Context, r *proto.GetStatusRequest) (*proto.GetStatusResponse, error) {
+	var response *proto.GetStatusResponse
+
+	err := s.forward(ctx, "GetStatus", func(node interfaces.IConnection) (err error) {
+		response, err = node.GetStatus()
+
+		return err
+	})
+
+	return response, toStatus(err)
+}
+
+func (s *coreServer) GetBlock(ctx context.Context, r *proto.GetBlockRequest) (*proto.GetBlockResponse, error) {
+	request := structures.BlockRequest{}
+
+	switch block := r.Block.(type) {
+	case *proto.GetBlockRequest_Hash:
+		request.Hash = &block.Hash
+	case *proto.GetBlockRequest_Height:
+		height := int(block.Height)
+		request.Height = &height
+	default:
+		return nil, status.Error(codes.InvalidArgument, "required one of fields (Hash, Height)")
+	}
+
+	var response *proto.GetBlockResponse
+
+	err := s.forward(ctx, "GetBlock", func(node interfaces.IConnection) (err error) {
+		response, err = node.GetBlock(request)
+
+		return err
+	})
+
+	return response, toStatus(err)
+}
+
+func (s *coreServer) SendTransaction(ctx context.Context, r *proto.SendTransactionRequest) (*proto.SendTransactionResponse, error) {
+	var response *proto.SendTransactionResponse
+
+	err := s.forward(ctx, "SendTransaction", func(node interfaces.IConnection) (err error) {
+		response, err = node.SendTransaction(r.Transaction, r.AllowHighFees, r.BypassLimits)
+
+		return err
+	})
+
+	return response, toStatus(err)
+}
+
+func (s *coreServer) GetTransaction(ctx context.Context, r *proto.GetTransactionRequest) (*proto.GetTransactionResponse, error) {
+	var response *proto.GetTransactionResponse
+
+	err := s.forward(ctx, "GetTransaction", func(node interfaces.IConnection) (err error) {
+		response, err = node.GetTransaction(r.Id)
+
+		return err
+	})
+
+	return response, toStatus(err)
+}
+
+func (s *coreServer) GetEstimatedTransactionFee(ctx context.Context, r *proto.GetEstimatedTransactionFeeRequest) (*proto.GetEstimatedTransactionFeeResponse, error) {
+	var response *proto.GetEstimatedTransactionFeeResponse
+
+	err := s.forward(ctx, "GetEstimatedTransactionFee", func(node interfaces.IConnection) (err error) {
+		response, err = node.GetEstimatedTransactionFee(int(r.Blocks))
+
+		return err
+	})
+
+	return response, toStatus(err)
+}
+
+//Failover happens only until first response, headers stream has no resume point
+func (s *coreServer) SubscribeToBlockHeadersWithChainLocks(r *proto.BlockHeadersWithChainLocksRequest, server proto.Core_SubscribeToBlockHeadersWithChainLocksServer) error {
+	request := structures.BlockHeadersWithChainLocksRequest{}
+
+	if r.Count > 0 {
+		count := int(r.Count)
+		request.Count = &count
+	}
+
+	switch from := r.FromBlock.(type) {
+	case *proto.BlockHeadersWithChainLocksRequest_FromBlockHash:
+		request.FromBlockHash = &from.FromBlockHash
+	case *proto.BlockHeadersWithChainLocksRequest_FromBlockHeight:
+		height := int(from.FromBlockHeight)
+		request.FromBlockHeight = &height
+	}
+
+	var upstream proto.Core_SubscribeToBlockHeadersWithChainLocksClient
+	var first *proto.BlockHeadersWithChainLocksResponse
+
+	err := s.forward(server.Context(), "SubscribeToBlockHeadersWithChainLocks", func(node interfaces.IConnection) (err error) {
+		upstream, err = node.SubscribeToBlockHeadersWithChainLocks(request)
+
+		if err != nil {
+			return err
+		}
+
+		first, err = upstream.Recv()
+
+		return err
+	})
+
+	for err == nil {
+		err = server.Send(first)
+
+		if err == nil {
+			first, err = upstream.Recv()
+		}
+	}
+
+	if err == io.EOF {
+		return nil
+	}
+
+	return toStatus(err)
+}
+
+func (s *platformServer) ApplyStateTransition(ctx context.Context, r *proto.ApplyStateTransitionRequest) (*proto.ApplyStateTransitionResponse, error) {
+	var response *proto.ApplyStateTransitionResponse
+
+	err := s.forward(ctx, "ApplyStateTransition", func(node interfaces.IConnection) (err error) {
+		response, err = node.ApplyStateTransition(r.StateTransition)
+
+		return err
+	})
+
+	return response, toStatus(err)
+}
+
+func (s *platformServer) GetIdentity(ctx context.Context, r *proto.GetIdentityRequest) (*proto.GetIdentityResponse, error) {
+	var response *proto.GetIdentityResponse
+
+	err := s.forward(ctx, "GetIdentity", func(node interfaces.IConnection) (err error) {
+		response, err = node.GetIdentity(r.Id)
+
+		return err
+	})
+
+	return response, toStatus(err)
+}
+
+func (s *platformServer) GetDataContract(ctx context.Context, r *proto.GetDataContractRequest) (*proto.GetDataContractResponse, error) {
+	var response *proto.GetDataContractResponse
+
+	err := s.forward(ctx, "GetDataContract", func(node interfaces.IConnection) (err error) {
+		response, err = node.GetDataContract(r.Id)
+
+		return err
+	})
+
+	return response, toStatus(err)
+}
+
+func (s *platformServer) GetDocuments(ctx context.Context, r *proto.GetDocumentsRequest) (*proto.GetDocumentsResponse, error) {
+	filter := structures.GetDocumentsRequest{}
+
+	if len(r.Where) > 0 {
+		filter.Where = &r.Where
+	}
+
+	if len(r.OrderBy) > 0 {
+		filter.OrderBy = &r.OrderBy
+	}
+
+	if r.Limit > 0 {
+		limit := int(r.Limit)
+		filter.Limit = &limit
+	}
+
+	switch start := r.Start.(type) {
+	case *proto.GetDocumentsRequest_StartAt:
+		startAt := int(start.StartAt)
+		filter.StartAt = &startAt
+	case *proto.GetDocumentsRequest_StartAfter:
+		startAfter := int(start.StartAfter)
+		filter.StartAfter = &startAfter
+	}
+
+	var response *proto.GetDocumentsResponse
+
+	err := s.forward(ctx, "GetDocuments", func(node interfaces.IConnection) (err error) {
+		response, err = node.GetDocuments(r.DataContractId, r.DocumentType, filter)
+
+		return err
+	})
+
+	return response, toStatus(err)
+}
+
+//Managed subscription resumes on another node from last processed block
+func (s *transactionsFilterStreamServer) SubscribeToTransactionsWithProofs(r *proto.TransactionsWithProofsRequest, server proto.TransactionsFilterStream_SubscribeToTransactionsWithProofsServer) error {
+	request := structures.SubscribeToTransactionsWithProofsRequest{
+		SendTransactionHashes: &r.SendTransactionHashes,
+	}
+
+	if f := r.BloomFilter; f != nil {
+		request.BloomFilter = structures.BloomFilterRequest{
+			Data:     f.VData,
+			HashFunc: f.NHashFuncs,
+			Tweak:    f.NTweak,
+			Flags:    f.NFlags,
+		}
+	}
+
+	if r.Count > 0 {
+		count := int(r.Count)
+		request.Count = &count
+	}
+
+	switch from := r.FromBlock.(type) {
+	case *proto.TransactionsWithProofsRequest_FromBlockHash:
+		request.FromBlockHash = &from.FromBlockHash
+	case *proto.TransactionsWithProofsRequest_FromBlockHeight:
+		height := int(from.FromBlockHeight)
+		request.FromBlockHeight = &height
+	}
+
+	options := stream.Options{
+		MaxRetries: s.options.Attempts,
+		RetryDelay: s.options.RetryDelay,
+		Metrics:    s.options.Metrics,
+	}
+
+	options.OnReconnect = func(e stream.ReconnectEvent) {
+		logging.Warn(s.options.Logger, "Forwarded stream moved", logging.Method("SubscribeToTransactionsWithProofs"), logging.Any("from", e.From), logging.Node(e.To), logging.Any("from_block_height", e.FromBlockHeight), logging.Err(e.Err))
+	}
+
+	subscription, err := stream.Subscribe(server.Context(), s.client, request, options)
+
+	if err != nil {
+		return toStatus(err)
+	}
+
+	for {
+		response, err := subscription.Recv()
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return toStatus(err)
+		}
+
+		err = server.Send(response)
+
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo"
+	"github.com/co-in/dash-dapi/evo/dapitest"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net"
+	"testing"
+	"time"
+)
+
+func newNodeServer(t *testing.T) *dapitest.Server {
+	t.Helper()
+	s, err := dapitest.NewServer()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+//Second node with ports of first one on other loopback address, client uses same ports for all nodes
+func newSecondNodeServer(t *testing.T, first *dapitest.Server) *dapitest.Server {
+	t.Helper()
+	s, err := dapitest.NewServerAt("127.0.0.2", first.JSONRPCPort, first.GRPCPort)
+
+	if err != nil {
+		t.Skipf("second loopback address: %s", err)
+	}
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+//Client of nodes without network verification, first node is seed
+func newNodeClient(t *testing.T, nodes ...*dapitest.Server) interfaces.IClient {
+	t.Helper()
+	c, err := evo.NewClient(nil, nodes[0].Host, nodes[0].JSONRPCPort, nodes[0].GRPCPort, evo.WithTimeout(5*time.Second))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, node := range nodes[1:] {
+		err = c.AddNode(node.Host, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return c
+}
+
+//Core service of gateway served on loopback
+func newCoreClient(t *testing.T, g *Gateway) proto.CoreClient {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := grpc.NewServer()
+	g.RegisterGRPC(server)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return proto.NewCoreClient(conn)
+}
+
+func TestForward(t *testing.T) {
+	s := newNodeServer(t)
+	s.SetResponse(dapitest.MethodGetStatus, &proto.GetStatusResponse{CoreVersion: 170000, Blocks: 1000, Network: "testnet"})
+	s.SetResponse(dapitest.MethodGetTransaction, &proto.GetTransactionResponse{Transaction: []byte{1, 2, 3}})
+
+	core := newCoreClient(t, New(newNodeClient(t, s), DefaultOptions()))
+
+	response, err := core.GetStatus(context.Background(), &proto.GetStatusRequest{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.CoreVersion != 170000 || response.Blocks != 1000 || response.Network != "testnet" {
+		t.Fatalf("status %+v", response)
+	}
+
+	tx, err := core.GetTransaction(context.Background(), &proto.GetTransactionRequest{Id: "abcd"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(tx.Transaction) != "\x01\x02\x03" {
+		t.Fatalf("transaction %x", tx.Transaction)
+	}
+
+	requests := s.Requests(dapitest.MethodGetTransaction)
+
+	if len(requests) != 1 || requests[0].(*proto.GetTransactionRequest).GetId() != "abcd" {
+		t.Fatalf("forwarded requests %v", requests)
+	}
+
+	//Invalid request is answered by gateway itself
+	_, err = core.GetBlock(context.Background(), &proto.GetBlockRequest{})
+
+	if status.Code(err) != codes.InvalidArgument || len(s.Requests(dapitest.MethodGetBlock)) != 0 {
+		t.Fatalf("error %v, expected InvalidArgument without forwarding", err)
+	}
+}
+
+func TestFailover(t *testing.T) {
+	failing := newNodeServer(t)
+	healthy := newSecondNodeServer(t, failing)
+	failing.SetError(dapitest.MethodGetStatus, status.Error(codes.Unavailable, "node is down"))
+	healthy.SetResponse(dapitest.MethodGetStatus, &proto.GetStatusResponse{Blocks: 1000})
+
+	options := DefaultOptions()
+	options.Attempts = 2
+	core := newCoreClient(t, New(newNodeClient(t, failing, healthy), options))
+
+	//Every call succeeds, including calls which selected failing node first
+	for i := 0; i < 50 && len(failing.Requests(dapitest.MethodGetStatus)) == 0; i++ {
+		response, err := core.GetStatus(context.Background(), &proto.GetStatusRequest{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Blocks != 1000 {
+			t.Fatalf("status %+v", response)
+		}
+	}
+
+	if len(failing.Requests(dapitest.MethodGetStatus)) == 0 {
+		t.Fatal("failing node was never selected")
+	}
+}
+
+func TestNoFailoverOfRequestError(t *testing.T) {
+	first := newNodeServer(t)
+	second := newSecondNodeServer(t, first)
+
+	for _, s := range []*dapitest.Server{first, second} {
+		s.SetError(dapitest.MethodGetTransaction, status.Error(codes.NotFound, "transaction not found"))
+	}
+
+	core := newCoreClient(t, New(newNodeClient(t, first, second), DefaultOptions()))
+
+	_, err := core.GetTransaction(context.Background(), &proto.GetTransactionRequest{Id: "abcd"})
+
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("error %v, expected NotFound", err)
+	}
+
+	if calls := len(first.Requests(dapitest.MethodGetTransaction)) + len(second.Requests(dapitest.MethodGetTransaction)); calls != 1 {
+		t.Fatalf("request error was forwarded %d times", calls)
+	}
+}
+
+func TestAttemptsExhausted(t *testing.T) {
+	first := newNodeServer(t)
+	second := newSecondNodeServer(t, first)
+
+	for _, s := range []*dapitest.Server{first, second} {
+		s.SetError(dapitest.MethodGetStatus, status.Error(codes.Unavailable, "node is down"))
+	}
+
+	options := DefaultOptions()
+	options.Attempts = 2
+	core := newCoreClient(t, New(newNodeClient(t, first, second), options))
+
+	_, err := core.GetStatus(context.Background(), &proto.GetStatusRequest{})
+
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("error %v, expected Unavailable", err)
+	}
+
+	//Each attempt goes to node not tried by call
+	if len(first.Requests(dapitest.MethodGetStatus)) == 0 || len(second.Requests(dapitest.MethodGetStatus)) == 0 {
+		t.Fatal("call was not forwarded to both nodes")
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		err      error
+		expected bool
+	}{
+		{"unavailable", context.Background(), status.Error(codes.Unavailable, "down"), true},
+		{"deadline", context.Background(), status.Error(codes.DeadlineExceeded, "slow"), true},
+		{"overloaded", context.Background(), status.Error(codes.ResourceExhausted, "busy"), true},
+		{"transport", context.Background(), errors.New("connection refused"), true},
+		{"wrapped", context.Background(), fmt.Errorf("dial: %w", errors.New("connection refused")), true},
+		{"invalid argument", context.Background(), status.Error(codes.InvalidArgument, "bad"), false},
+		{"not found", context.Background(), status.Error(codes.NotFound, "missing"), false},
+		{"aborted", context.Background(), status.Error(codes.Aborted, "conflict"), false},
+		{"JSON-RPC error", context.Background(), &evo.RPCError{Code: -5, Message: "not found"}, false},
+		{"canceled caller", canceled, status.Error(codes.Unavailable, "down"), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if retryable(test.ctx, test.err) != test.expected {
+				t.Fatalf("retryable %v", !test.expected)
+			}
+
+			//Gateway classifies errors as client does
+			if test.ctx.Err() == nil && evo.NodeFailure(test.err) != test.expected {
+				t.Fatalf("client classifies as %v", !test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/co-in/dash-dapi/evo"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	"net/http"
+)
+
+//JSON-RPC 2.0 error codes
+const (
+	jsonRPCParseError     = -32700
+	jsonRPCInvalidRequest = -32600
+	jsonRPCMethodNotFound = -32601
+	jsonRPCServerError    = -32000
+)
+
+//Methods served by DAPI JSON-RPC endpoint
+var jsonRPCMethods = map[string]bool{
+	"getAddressSummary": true,
+	"getBestBlockHash":  true,
+	"getBlockHash":      true,
+	"getMnListDiff":     true,
+	"getUTXO":           true,
+}
+
+type jsonRPCRequest struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Id     json.RawMessage `json:"id"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type jsonRPCResponse struct {
+	JsonRPC string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *jsonRPCError   `json:"error,omitempty"`
+}
+
+//JSON-RPC endpoint, requests are forwarded as is and results are returned unchanged.
+//Block hashes are requested through typed calls, so they are answered by cache of client
+func (g *Gateway) JSONRPCHandler() http.Handler {
+	return http.HandlerFunc(g.serveJSONRPC)
+}
+
+func (g *Gateway) serveJSONRPC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	request := new(jsonRPCRequest)
+	err := json.NewDecoder(r.Body).Decode(request)
+
+	if err != nil {
+		writeJSONRPC(w, nil, nil, &jsonRPCError{Code: jsonRPCParseError, Message: err.Error()})
+
+		return
+	}
+
+	if request.Method == "" {
+		writeJSONRPC(w, request.Id, nil, &jsonRPCError{Code: jsonRPCInvalidRequest, Message: "missing method"})
+
+		return
+	}
+
+	if !jsonRPCMethods[request.Method] {
+		writeJSONRPC(w, request.Id, nil, &jsonRPCError{Code: jsonRPCMethodNotFound, Message: "method not found: " + request.Method})
+
+		return
+	}
+
+	params := request.Params
+
+	if len(params) == 0 || string(params) == "null" {
+		params = json.RawMessage("{}")
+	}
+
+	var result json.RawMessage
+
+	err = g.forward(r.Context(), request.Method, func(node interfaces.IConnection) (err error) {
+		result, err = callJSON(node, request.Method, params)
+
+		return err
+	})
+
+	if err != nil {
+		var rpcErr *evo.RPCError
+
+		if errors.As(err, &rpcErr) {
+			writeJSONRPC(w, request.Id, nil, &jsonRPCError{Code: rpcErr.Code, Message: rpcErr.Message})
+		} else {
+			writeJSONRPC(w, request.Id, nil, &jsonRPCError{Code: jsonRPCServerError, Message: err.Error()})
+		}
+
+		return
+	}
+
+	if len(result) == 0 {
+		result = json.RawMessage("null")
+	}
+
+	writeJSONRPC(w, request.Id, result, nil)
+}
+
+//Methods answered by cached connection go through its typed calls, others are passed as is
+func callJSON(node interfaces.IConnection, method string, params json.RawMessage) (json.RawMessage, error) {
+	var response interface{}
+	var err error
+
+	switch method {
+	case "getBestBlockHash":
+		response, err = node.GetBestBlockHash()
+	case "getBlockHash":
+		var request struct {
+			Height *int `json:"height"`
+		}
+
+		err = json.Unmarshal(params, &request)
+
+		if err != nil || request.Height == nil {
+			return node.CallJSON(method, params)
+		}
+
+		response, err = node.GetBlockHash(*request.Height)
+	default:
+		return node.CallJSON(method, params)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(response)
+}
+
+func writeJSONRPC(w http.ResponseWriter, id json.RawMessage, result json.RawMessage, rpcErr *jsonRPCError) {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(jsonRPCResponse{
+		JsonRPC: "2.0",
+		Id:      id,
+		Result:  result,
+		Error:   rpcErr,
+	})
+}
@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/cache"
+	"github.com/co-in/dash-dapi/evo/dapitest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const lockedHash = "000000000000000000000000000000000000000000000000000000000000abcd"
+
+func postJSONRPC(t *testing.T, url string, body string) jsonRPCResponse {
+	t.Helper()
+	response, err := http.Post(url, "application/json", bytes.NewBufferString(body))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer response.Body.Close()
+
+	var decoded jsonRPCResponse
+	err = json.NewDecoder(response.Body).Decode(&decoded)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return decoded
+}
+
+//Block hashes of final heights are answered by cache of client, like in dapi serve
+func TestJSONRPCCache(t *testing.T) {
+	s := newNodeServer(t)
+	s.Handle(dapitest.MethodGetBlockHash, func(ctx context.Context, request interface{}) (interface{}, error) {
+		var params struct {
+			Height int `json:"height"`
+		}
+
+		err := json.Unmarshal(request.(json.RawMessage), &params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return fmt.Sprintf("%064x", params.Height), nil
+	})
+
+	responses := cache.New(cache.DefaultOptions())
+	responses.ChainLocked("a", 500, lockedHash)
+	responses.ChainLocked("b", 500, lockedHash)
+
+	server := httptest.NewServer(New(responses.WrapClient(newNodeClient(t, s)), DefaultOptions()).JSONRPCHandler())
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name     string
+		height   int
+		result   string
+		requests int
+	}{
+		{name: "chain-locked height", height: 500, result: lockedHash, requests: 0},
+		{name: "final height", height: 400, result: fmt.Sprintf("%064x", 400), requests: 1},
+		{name: "cached final height", height: 400, result: fmt.Sprintf("%064x", 400), requests: 1},
+		{name: "height above chain lock", height: 600, result: fmt.Sprintf("%064x", 600), requests: 2},
+		{name: "repeated height above chain lock", height: 600, result: fmt.Sprintf("%064x", 600), requests: 3},
+	}
+
+	for _, test := range tests {
+		response := postJSONRPC(t, server.URL, fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"getBlockHash","params":{"height":%d}}`, test.height))
+
+		if response.Error != nil || string(response.Result) != `"`+test.result+`"` || string(response.Id) != "1" {
+			t.Fatalf("%s: response %+v", test.name, response)
+		}
+
+		if requests := len(s.Requests(dapitest.MethodGetBlockHash)); requests != test.requests {
+			t.Fatalf("%s: %d requests to node, expected %d", test.name, requests, test.requests)
+		}
+	}
+}
@@ -0,0 +1,551 @@
+package gateway
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/block"
+	"github.com/co-in/dash-dapi/evo/interfaces"
+	"github.com/co-in/dash-dapi/evo/platform"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/evo/structures"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+//Prefix of REST routes
+const restVersion = "/v0/"
+
+//Limit of POST /v0/tx body, twice the size of largest standard transaction in hex
+const maxTransactionBody = 1 << 21
+
+type restError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type restStatus struct {
+	Network         string  `json:"network"`
+	CoreVersion     uint32  `json:"coreVersion"`
+	ProtocolVersion uint32  `json:"protocolVersion"`
+	Blocks          uint32  `json:"blocks"`
+	TimeOffset      uint32  `json:"timeOffset"`
+	Connections     uint32  `json:"connections"`
+	Proxy           string  `json:"proxy"`
+	Difficulty      float64 `json:"difficulty"`
+	Testnet         bool    `json:"testnet"`
+	RelayFee        float64 `json:"relayFee"`
+	Errors          string  `json:"errors"`
+}
+
+type restBlock struct {
+	Hash       string    `json:"hash"`
+	Version    int32     `json:"version"`
+	PrevBlock  string    `json:"previousBlockHash"`
+	MerkleRoot string    `json:"merkleRoot"`
+	Time       time.Time `json:"time"`
+	Bits       string    `json:"bits"`
+	Nonce      uint32    `json:"nonce"`
+	Size       int       `json:"size"`
+	Hex        string    `json:"hex"`
+}
+
+type restInput struct {
+	TxID     string `json:"txid"`
+	Index    uint32 `json:"index"`
+	Sequence uint32 `json:"sequence"`
+}
+
+type restOutput struct {
+	Value   int64  `json:"value"`
+	Address string `json:"address,omitempty"`
+	Script  string `json:"script"`
+}
+
+type restTransaction struct {
+	TxID         string       `json:"txid"`
+	Version      uint16       `json:"version"`
+	Type         uint16       `json:"type"`
+	LockTime     uint32       `json:"lockTime"`
+	Inputs       []restInput  `json:"inputs"`
+	Outputs      []restOutput `json:"outputs"`
+	ExtraPayload string       `json:"extraPayload,omitempty"`
+	Hex          string       `json:"hex"`
+}
+
+//Body of POST /v0/tx, raw transaction may be sent as application/octet-stream instead
+type restSendTransaction struct {
+	Hex           string `json:"hex"`
+	AllowHighFees bool   `json:"allowHighFees"`
+	BypassLimits  bool   `json:"bypassLimits"`
+}
+
+//REST endpoint for clients without gRPC, platform objects are decoded from CBOR to JSON:
+//  GET  /v0/status
+//  GET  /v0/blocks/{hashOrHeight}
+//  GET  /v0/tx/{id}
+//  POST /v0/tx
+//  GET  /v0/identities/{id}
+//  GET  /v0/contracts/{id}
+//  GET  /v0/contracts/{id}/documents/{type}?where=&orderBy=&limit=&startAt=&startAfter=
+//  GET  /v0/stream, POST /v0/stream (WebSocket or Server-Sent Events, see StreamHandler)
+func (g *Gateway) RESTHandler() http.Handler {
+	return http.HandlerFunc(g.serveREST)
+}
+
+func (g *Gateway) serveREST(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, restVersion) {
+		writeRESTError(w, status.Error(codes.NotFound, "unknown route"))
+
+		return
+	}
+
+	path := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, restVersion), "/"), "/")
+
+	if len(path) == 1 && path[0] == "stream" {
+		g.serveStream(w, r)
+
+		return
+	}
+
+	method := http.MethodGet
+
+	if len(path) == 1 && path[0] == "tx" {
+		method = http.MethodPost
+	}
+
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		writeREST(w, http.StatusMethodNotAllowed, map[string]restError{"error": {Code: "method_not_allowed", Message: "method not allowed"}})
+
+		return
+	}
+
+	var value interface{}
+	var err error
+
+	switch {
+	case len(path) == 1 && path[0] == "status":
+		value, err = g.restStatus(r)
+	case len(path) == 2 && path[0] == "blocks":
+		value, err = g.restBlock(r, path[1])
+	case len(path) == 2 && path[0] == "tx":
+		value, err = g.restTransaction(r, path[1])
+	case len(path) == 1 && path[0] == "tx":
+		value, err = g.restSendTransaction(r)
+	case len(path) == 2 && path[0] == "identities":
+		value, err = g.restIdentity(r, path[1])
+	case len(path) == 2 && path[0] == "contracts":
+		value, err = g.restContract(r, path[1])
+	case len(path) == 4 && path[0] == "contracts" && path[2] == "documents":
+		value, err = g.restDocuments(r, path[1], path[3])
+	default:
+		err = status.Error(codes.NotFound, "unknown route")
+	}
+
+	if err != nil {
+		writeRESTError(w, err)
+
+		return
+	}
+
+	writeREST(w, http.StatusOK, value)
+}
+
+func (g *Gateway) restStatus(r *http.Request) (interface{}, error) {
+	var response *proto.GetStatusResponse
+
+	err := g.forward(r.Context(), "GetStatus", func(node interfaces.IConnection) (err error) {
+		response, err = node.GetStatus()
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &restStatus{
+		Network:         response.Network,
+		CoreVersion:     response.CoreVersion,
+		ProtocolVersion: response.ProtocolVersion,
+		Blocks:          response.Blocks,
+		TimeOffset:      response.TimeOffset,
+		Connections:     response.Connections,
+		Proxy:           response.Proxy,
+		Difficulty:      response.Difficulty,
+		Testnet:         response.Testnet,
+		RelayFee:        response.RelayFee,
+		Errors:          response.Errors,
+	}, nil
+}
+
+//All-digit value shorter than 64 characters (length of block hash) is height, any other value is block hash
+func (g *Gateway) restBlock(r *http.Request, hashOrHeight string) (interface{}, error) {
+	request := structures.BlockRequest{}
+
+	if height, err := strconv.Atoi(hashOrHeight); err == nil && len(hashOrHeight) < 64 {
+		if height < 0 {
+			return nil, status.Error(codes.InvalidArgument, "negative block height")
+		}
+
+		request.Height = &height
+	} else {
+		request.Hash = &hashOrHeight
+	}
+
+	var response *proto.GetBlockResponse
+	hash := strings.ToLower(hashOrHeight)
+
+	err := g.forward(r.Context(), "GetBlock", func(node interfaces.IConnection) (err error) {
+		response, err = node.GetBlock(request)
+
+		if err != nil || request.Height == nil {
+			return err
+		}
+
+		//Block hash is X11 of header, it is taken from node instead of computed
+		blockHash, err := node.GetBlockHash(*request.Height)
+
+		if err != nil {
+			return err
+		}
+
+		hash = string(*blockHash)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	raw := response.GetBlock()
+	header, err := block.ParseHeader(raw)
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &restBlock{
+		Hash:       hash,
+		Version:    header.Version,
+		PrevBlock:  hex.EncodeToString(transaction.ReverseBytes(header.PrevBlock[:])),
+		MerkleRoot: hex.EncodeToString(transaction.ReverseBytes(header.MerkleRoot[:])),
+		Time:       time.Unix(int64(header.Timestamp), 0).UTC(),
+		Bits:       fmt.Sprintf("%08x", header.Bits),
+		Nonce:      header.Nonce,
+		Size:       len(raw),
+		Hex:        hex.EncodeToString(raw),
+	}, nil
+}
+
+func (g *Gateway) restTransaction(r *http.Request, id string) (interface{}, error) {
+	var response *proto.GetTransactionResponse
+
+	err := g.forward(r.Context(), "GetTransaction", func(node interfaces.IConnection) (err error) {
+		response, err = node.GetTransaction(id)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	raw := response.GetTransaction()
+	tx, err := transaction.Parse(raw)
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return g.newRESTTransaction(raw, tx), nil
+}
+
+func (g *Gateway) newRESTTransaction(raw []byte, tx *transaction.Transaction) *restTransaction {
+	view := &restTransaction{
+		TxID:         tx.TxID(),
+		Version:      tx.Version,
+		Type:         tx.Type,
+		LockTime:     tx.LockTime,
+		Inputs:       make([]restInput, 0, len(tx.Inputs)),
+		Outputs:      make([]restOutput, 0, len(tx.Outputs)),
+		ExtraPayload: hex.EncodeToString(tx.ExtraPayload),
+		Hex:          hex.EncodeToString(raw),
+	}
+
+	for _, in := range tx.Inputs {
+		view.Inputs = append(view.Inputs, restInput{
+			TxID:     in.PreviousOutput.TxID(),
+			Index:    in.PreviousOutput.Index,
+			Sequence: in.Sequence,
+		})
+	}
+
+	//Addresses are derived only when network of client is known, they are omitted otherwise
+	network := g.client.GetNetwork()
+
+	for _, out := range tx.Outputs {
+		var address string
+
+		if network != nil {
+			address, _ = transaction.ScriptToAddress(out.Script, network.Address)
+		}
+
+		view.Outputs = append(view.Outputs, restOutput{
+			Value:   out.Value,
+			Address: address,
+			Script:  hex.EncodeToString(out.Script),
+		})
+	}
+
+	return view
+}
+
+func (g *Gateway) restSendTransaction(r *http.Request) (interface{}, error) {
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxTransactionBody+1))
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if len(body) > maxTransactionBody {
+		return nil, status.Error(codes.InvalidArgument, "request body is too large")
+	}
+
+	request := restSendTransaction{}
+	var raw []byte
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/octet-stream") {
+		raw = body
+	} else {
+		err = json.Unmarshal(body, &request)
+
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		raw, err = hex.DecodeString(request.Hex)
+
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "hex: "+err.Error())
+		}
+	}
+
+	query := r.URL.Query()
+	allowHighFees := request.AllowHighFees || query.Get("allowHighFees") == "true"
+	bypassLimits := request.BypassLimits || query.Get("bypassLimits") == "true"
+
+	_, err = transaction.Parse(raw)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var response *proto.SendTransactionResponse
+
+	err = g.forward(r.Context(), "SendTransaction", func(node interfaces.IConnection) (err error) {
+		response, err = node.SendTransaction(raw, allowHighFees, bypassLimits)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{"transactionId": response.TransactionId}, nil
+}
+
+func (g *Gateway) restIdentity(r *http.Request, id string) (interface{}, error) {
+	var response *proto.GetIdentityResponse
+
+	err := g.forward(r.Context(), "GetIdentity", func(node interfaces.IConnection) (err error) {
+		response, err = node.GetIdentity(id)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return decodePlatform(response.GetIdentity())
+}
+
+func (g *Gateway) restContract(r *http.Request, id string) (interface{}, error) {
+	var response *proto.GetDataContractResponse
+
+	err := g.forward(r.Context(), "GetDataContract", func(node interfaces.IConnection) (err error) {
+		response, err = node.GetDataContract(id)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return decodePlatform(response.GetDataContract())
+}
+
+func (g *Gateway) restDocuments(r *http.Request, contractID string, documentType string) (interface{}, error) {
+	query := r.URL.Query()
+	filter := structures.GetDocumentsRequest{}
+	var err error
+
+	filter.Where, err = restQuery(query, "where")
+
+	if err != nil {
+		return nil, err
+	}
+
+	filter.OrderBy, err = restQuery(query, "orderBy")
+
+	if err != nil {
+		return nil, err
+	}
+
+	for name, target := range map[string]**int{"limit": &filter.Limit, "startAt": &filter.StartAt, "startAfter": &filter.StartAfter} {
+		*target, err = restInt(query, name)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var response *proto.GetDocumentsResponse
+
+	err = g.forward(r.Context(), "GetDocuments", func(node interfaces.IConnection) (err error) {
+		response, err = node.GetDocuments(contractID, documentType, filter)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := platform.DecodeDocuments(response.GetDocuments())
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return documents, nil
+}
+
+func decodePlatform(raw []byte) (interface{}, error) {
+	value, err := platform.Decode(raw)
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return value, nil
+}
+
+//JSON array of query parameter encoded as CBOR, as expected by DAPI
+func restQuery(query url.Values, name string) (*[]byte, error) {
+	value := query.Get(name)
+
+	if value == "" {
+		return nil, nil
+	}
+
+	data, err := platform.QueryFromJSON(value)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, name+": "+err.Error())
+	}
+
+	return &data, nil
+}
+
+func restInt(query url.Values, name string) (*int, error) {
+	value := query.Get(name)
+
+	if value == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(value)
+
+	if err != nil || n < 0 {
+		return nil, status.Error(codes.InvalidArgument, name+": non-negative integer expected")
+	}
+
+	return &n, nil
+}
+
+//HTTP status of gRPC code, node failures become 502
+var restStatusCodes = map[codes.Code]int{
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusBadGateway,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.Canceled:           499,
+}
+
+func writeRESTError(w http.ResponseWriter, err error) {
+	s, _ := status.FromError(toStatus(err))
+	code, ok := restStatusCodes[s.Code()]
+
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+
+	writeREST(w, code, map[string]restError{"error": {
+		Code:    snakeCase(s.Code().String()),
+		Message: s.Message(),
+	}})
+}
+
+//NotFound -> not_found
+func snakeCase(name string) string {
+	var b strings.Builder
+
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+
+			r = unicode.ToLower(r)
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+func writeREST(w http.ResponseWriter, code int, value interface{}) {
+	data, err := json.Marshal(value)
+
+	if err != nil {
+		code = http.StatusInternalServerError
+		data, _ = json.Marshal(map[string]restError{"error": {Code: "internal", Message: err.Error()}})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(append(data, '\n'))
+}
@@ -0,0 +1,193 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/co-in/dash-dapi/evo/dapitest"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/evo/transaction"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+//REST endpoint of gateway forwarding to single fake node
+func newRESTServer(t *testing.T, s *dapitest.Server) string {
+	t.Helper()
+	server := httptest.NewServer(New(newNodeClient(t, s), DefaultOptions()).RESTHandler())
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+//Status code and decoded JSON body of request
+func restRequest(t *testing.T, method string, url string, body string, value interface{}) int {
+	t.Helper()
+	request, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer response.Body.Close()
+
+	if response.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("content type %s", response.Header.Get("Content-Type"))
+	}
+
+	data, err := ioutil.ReadAll(response.Body)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = json.Unmarshal(data, value)
+
+	if err != nil {
+		t.Fatalf("%s: %s", data, err)
+	}
+
+	return response.StatusCode
+}
+
+func testBlock() []byte {
+	header := make([]byte, 80)
+	header[0] = 2
+	header[4] = 0xaa
+	header[36] = 0xbb
+
+	return append(header, 0)
+}
+
+func TestRESTStatus(t *testing.T) {
+	s := newNodeServer(t)
+	s.SetResponse(dapitest.MethodGetStatus, &proto.GetStatusResponse{CoreVersion: 170000, Blocks: 1000, Network: "testnet", Testnet: true})
+
+	var view restStatus
+	code := restRequest(t, http.MethodGet, newRESTServer(t, s)+"/v0/status", "", &view)
+
+	if code != http.StatusOK || view.CoreVersion != 170000 || view.Blocks != 1000 || view.Network != "testnet" || !view.Testnet {
+		t.Fatalf("%d %+v", code, view)
+	}
+}
+
+func TestRESTBlock(t *testing.T) {
+	hash := fmt.Sprintf("%064x", 500)
+	s := newNodeServer(t)
+	s.SetResponse(dapitest.MethodGetBlock, &proto.GetBlockResponse{Block: testBlock()})
+	s.SetResponse(dapitest.MethodGetBlockHash, hash)
+	url := newRESTServer(t, s)
+
+	//Hash is not computed, it is requested one or hash of height reported by node
+	for _, path := range []string{hash, "500"} {
+		var view restBlock
+		code := restRequest(t, http.MethodGet, url+"/v0/blocks/"+path, "", &view)
+
+		if code != http.StatusOK || view.Hash != hash || view.Version != 2 || view.Size != 81 {
+			t.Fatalf("%s: %d %+v", path, code, view)
+		}
+
+		if view.PrevBlock != fmt.Sprintf("%062x%02x", 0, 0xaa) || view.MerkleRoot != fmt.Sprintf("%062x%02x", 0, 0xbb) {
+			t.Fatalf("%s: previous block %s, merkle root %s", path, view.PrevBlock, view.MerkleRoot)
+		}
+	}
+
+	requests := s.Requests(dapitest.MethodGetBlock)
+
+	if len(requests) != 2 || requests[0].(*proto.GetBlockRequest).GetHash() != hash || requests[1].(*proto.GetBlockRequest).GetHeight() != 500 {
+		t.Fatalf("forwarded requests %v", requests)
+	}
+
+	if len(s.Requests(dapitest.MethodGetBlockHash)) != 1 {
+		t.Fatal("hash of block by height is not requested")
+	}
+}
+
+func TestRESTTransaction(t *testing.T) {
+	tx := &transaction.Transaction{
+		Version: transaction.DefaultVersion,
+		Inputs:  []transaction.Input{{PreviousOutput: transaction.OutPoint{Hash: [32]byte{1}, Index: 2}, Script: []byte{1, 1}, Sequence: transaction.DefaultSequence}},
+		Outputs: []transaction.Output{{Value: 1000, Script: []byte{0x6a}}},
+	}
+
+	s := newNodeServer(t)
+	s.SetResponse(dapitest.MethodGetTransaction, &proto.GetTransactionResponse{Transaction: tx.Bytes()})
+	s.SetResponse(dapitest.MethodSendTransaction, &proto.SendTransactionResponse{TransactionId: tx.TxID()})
+	url := newRESTServer(t, s)
+
+	var view restTransaction
+	code := restRequest(t, http.MethodGet, url+"/v0/tx/"+tx.TxID(), "", &view)
+
+	if code != http.StatusOK || view.TxID != tx.TxID() || view.Hex != hex.EncodeToString(tx.Bytes()) {
+		t.Fatalf("%d %+v", code, view)
+	}
+
+	if len(view.Inputs) != 1 || view.Inputs[0].Index != 2 || len(view.Outputs) != 1 || view.Outputs[0].Value != 1000 || view.Outputs[0].Script != "6a" {
+		t.Fatalf("inputs %+v, outputs %+v", view.Inputs, view.Outputs)
+	}
+
+	var sent map[string]string
+	code = restRequest(t, http.MethodPost, url+"/v0/tx?allowHighFees=true", `{"hex":"`+hex.EncodeToString(tx.Bytes())+`"}`, &sent)
+
+	if code != http.StatusOK || sent["transactionId"] != tx.TxID() {
+		t.Fatalf("%d %v", code, sent)
+	}
+
+	requests := s.Requests(dapitest.MethodSendTransaction)
+
+	if len(requests) != 1 || !bytes.Equal(requests[0].(*proto.SendTransactionRequest).GetTransaction(), tx.Bytes()) || !requests[0].(*proto.SendTransactionRequest).GetAllowHighFees() {
+		t.Fatalf("forwarded requests %v", requests)
+	}
+}
+
+func TestRESTErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		nodeErr error
+		status  int
+		code    string
+	}{
+		{name: "unknown route", method: http.MethodGet, path: "/v0/unknown", status: http.StatusNotFound, code: "not_found"},
+		{name: "unversioned route", method: http.MethodGet, path: "/status", status: http.StatusNotFound, code: "not_found"},
+		{name: "method", method: http.MethodGet, path: "/v0/tx", status: http.StatusMethodNotAllowed, code: "method_not_allowed"},
+		{name: "negative height", method: http.MethodGet, path: "/v0/blocks/-1", status: http.StatusBadRequest, code: "invalid_argument"},
+		{name: "invalid transaction", method: http.MethodPost, path: "/v0/tx", body: `{"hex":"00"}`, status: http.StatusBadRequest, code: "invalid_argument"},
+		{name: "invalid hex", method: http.MethodPost, path: "/v0/tx", body: `{"hex":"zz"}`, status: http.StatusBadRequest, code: "invalid_argument"},
+		{name: "not found", method: http.MethodGet, path: "/v0/tx/abcd", nodeErr: status.Error(codes.NotFound, "no such transaction"), status: http.StatusNotFound, code: "not_found"},
+		{name: "invalid argument", method: http.MethodGet, path: "/v0/tx/abcd", nodeErr: status.Error(codes.InvalidArgument, "invalid id"), status: http.StatusBadRequest, code: "invalid_argument"},
+		{name: "node down", method: http.MethodGet, path: "/v0/tx/abcd", nodeErr: status.Error(codes.Unavailable, "node is down"), status: http.StatusBadGateway, code: "unavailable"},
+		{name: "node timeout", method: http.MethodGet, path: "/v0/tx/abcd", nodeErr: status.Error(codes.DeadlineExceeded, "node is slow"), status: http.StatusGatewayTimeout, code: "deadline_exceeded"},
+		{name: "node failure", method: http.MethodGet, path: "/v0/tx/abcd", nodeErr: status.Error(codes.Internal, "node failed"), status: http.StatusInternalServerError, code: "internal"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newNodeServer(t)
+
+			if test.nodeErr != nil {
+				s.SetError(dapitest.MethodGetTransaction, test.nodeErr)
+			}
+
+			var body map[string]restError
+			code := restRequest(t, test.method, newRESTServer(t, s)+test.path, test.body, &body)
+
+			if code != test.status || body["error"].Code != test.code || body["error"].Message == "" {
+				t.Fatalf("%d %+v, expected %d %s", code, body, test.status, test.code)
+			}
+		})
+	}
+}
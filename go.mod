@@ -3,6 +3,12 @@ module github.com/co-in/dash-dapi
 go 1.14
 
 require (
+	github.com/btcsuite/btcd v0.20.1-beta
+	github.com/btcsuite/btcutil v1.0.2
+	github.com/chzyer/readline v0.0.0-20180603132655-2972be24d48e
+	github.com/fxamacker/cbor/v2 v2.2.0
 	github.com/golang/protobuf v1.3.5
+	go.etcd.io/bbolt v1.3.5
+	golang.org/x/net v0.0.0-20190404232315-eb5bcb51f2a3
 	google.golang.org/grpc v1.28.1
 )
@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+type jsonLogger struct {
+	sync.Mutex
+	w     io.Writer
+	level Level
+}
+
+//One JSON object per line: time, level, msg and fields, e.g. for log collectors
+func NewJSONLogger(w io.Writer, level Level) Logger {
+	return &jsonLogger{w: w, level: level}
+}
+
+func (j *jsonLogger) Enabled(level Level) bool {
+	return level >= j.level
+}
+
+func (j *jsonLogger) Log(level Level, message string, fields ...Field) {
+	if !j.Enabled(level) {
+		return
+	}
+
+	var b bytes.Buffer
+	b.WriteString(`{"time":`)
+	writeJSON(&b, time.Now().UTC().Format(time.RFC3339Nano))
+	b.WriteString(`,"level":`)
+	writeJSON(&b, level.String())
+	b.WriteString(`,"msg":`)
+	writeJSON(&b, message)
+
+	//Fields are written in given order, unlike keys of map
+	for _, field := range fields {
+		b.WriteByte(',')
+		writeJSON(&b, field.Key)
+		b.WriteByte(':')
+		writeJSON(&b, jsonValue(field.Value))
+	}
+
+	b.WriteString("}\n")
+
+	j.Lock()
+	defer j.Unlock()
+
+	_, _ = j.w.Write(b.Bytes())
+}
+
+func writeJSON(b *bytes.Buffer, value interface{}) {
+	data, err := json.Marshal(value)
+
+	if err != nil {
+		data, _ = json.Marshal(err.Error())
+	}
+
+	b.Write(data)
+}
+
+//Value of JSON record: durations in seconds, errors and stringers as text
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Duration:
+		return v.Seconds()
+	case error:
+		return v.Error()
+	case json.RawMessage, json.Marshaler:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	return value
+}
@@ -0,0 +1,145 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+func (l Level) String() string {
+	if l < LevelDebug || l > LevelError {
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+
+	return levelNames[l]
+}
+
+func ParseLevel(name string) (Level, error) {
+	for i, levelName := range levelNames {
+		if strings.EqualFold(name, levelName) {
+			return Level(i), nil
+		}
+	}
+
+	return LevelInfo, fmt.Errorf("unknown log level %s, expected one of %s", name, strings.Join(levelNames, ", "))
+}
+
+//Level of legacy verbose setting: 0 errors, 1 warnings, 2 node failures and reputation, 3 and more payloads of calls
+func LevelFromVerbose(verbose int) Level {
+	switch {
+	case verbose <= 0:
+		return LevelError
+	case verbose == 1:
+		return LevelWarn
+	case verbose == 2:
+		return LevelInfo
+	}
+
+	return LevelDebug
+}
+
+//Key-value pair of structured record
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+//Keys of common fields
+const (
+	KeyNode      = "node"
+	KeyMethod    = "method"
+	KeyRequestID = "request_id"
+	KeyDuration  = "duration"
+	KeyError     = "error"
+)
+
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+func Node(node string) Field {
+	return Field{Key: KeyNode, Value: node}
+}
+
+func Method(method string) Field {
+	return Field{Key: KeyMethod, Value: method}
+}
+
+func RequestID(id int) Field {
+	return Field{Key: KeyRequestID, Value: id}
+}
+
+func Duration(d time.Duration) Field {
+	return Field{Key: KeyDuration, Value: d}
+}
+
+func Err(err error) Field {
+	return Field{Key: KeyError, Value: err}
+}
+
+//Structured leveled logger, safe for concurrent use
+type Logger interface {
+	//Record is dropped when level is not enabled
+	Log(level Level, message string, fields ...Field)
+	//Check before building expensive fields, e.g. payloads
+	Enabled(level Level) bool
+}
+
+func Debug(logger Logger, message string, fields ...Field) {
+	logger.Log(LevelDebug, message, fields...)
+}
+
+func Info(logger Logger, message string, fields ...Field) {
+	logger.Log(LevelInfo, message, fields...)
+}
+
+func Warn(logger Logger, message string, fields ...Field) {
+	logger.Log(LevelWarn, message, fields...)
+}
+
+func Error(logger Logger, message string, fields ...Field) {
+	logger.Log(LevelError, message, fields...)
+}
+
+type nop struct{}
+
+//Logger discarding all records
+func Nop() Logger {
+	return nop{}
+}
+
+func (nop) Log(Level, string, ...Field) {
+}
+
+func (nop) Enabled(Level) bool {
+	return false
+}
+
+//Logger adding fields to every record, e.g. component name
+func With(logger Logger, fields ...Field) Logger {
+	return &withFields{logger, fields}
+}
+
+type withFields struct {
+	Logger
+	fields []Field
+}
+
+func (w *withFields) Log(level Level, message string, fields ...Field) {
+	if !w.Logger.Enabled(level) {
+		return
+	}
+
+	w.Logger.Log(level, message, append(append([]Field(nil), w.fields...), fields...)...)
+}
@@ -0,0 +1,90 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/co-in/dash-dapi/evo"
+	proto "github.com/co-in/dash-dapi/evo/protobuf"
+	"github.com/co-in/dash-dapi/logging"
+	"log"
+	"strings"
+	"testing"
+)
+
+//Payload of transaction, it must not be found in logs in any encoding
+var secret = []byte("signed transaction of wallet")
+
+func loggers(buf *bytes.Buffer) map[string]logging.Logger {
+	return map[string]logging.Logger{
+		"std":  logging.NewStdLogger(log.New(buf, "", 0), logging.LevelDebug),
+		"json": logging.NewJSONLogger(buf, logging.LevelDebug),
+	}
+}
+
+func leaked(output string) bool {
+	for _, encoded := range []string{string(secret), hex.EncodeToString(secret), base64.StdEncoding.EncodeToString(secret)} {
+		if strings.Contains(output, encoded) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//Log call of method passing through LoggingMiddleware at debug level
+func logCall(logger logging.Logger, method string, request interface{}, response interface{}) {
+	next := func(ctx context.Context, call *evo.Call) error {
+		call.Response = response
+
+		return nil
+	}
+
+	call := &evo.Call{Transport: evo.TransportGRPC, Method: method, Node: "node", Request: request}
+	_ = evo.LoggingMiddleware(logger)(next)(context.Background(), call)
+}
+
+func TestRedactedPayloads(t *testing.T) {
+	tests := []struct {
+		method   string
+		request  interface{}
+		response interface{}
+	}{
+		{"getTransaction", &proto.GetTransactionRequest{Id: "abcd"}, &proto.GetTransactionResponse{Transaction: secret}},
+		{"sendTransaction", &proto.SendTransactionRequest{Transaction: secret}, &proto.SendTransactionResponse{TransactionId: "abcd"}},
+		{"applyStateTransition", &proto.ApplyStateTransitionRequest{StateTransition: secret}, &proto.ApplyStateTransitionResponse{}},
+	}
+
+	for _, test := range tests {
+		if !evo.RedactedMethods[test.method] {
+			t.Fatalf("%s is not redacted", test.method)
+		}
+
+		var buf bytes.Buffer
+
+		for name, logger := range loggers(&buf) {
+			buf.Reset()
+			logCall(logger, test.method, test.request, test.response)
+			output := buf.String()
+
+			if leaked(output) || strings.Count(output, "redacted") != 2 {
+				t.Fatalf("%s logger, %s: %q", name, test.method, output)
+			}
+		}
+	}
+}
+
+//Same payload of other method is logged, test detects leaks
+func TestPayloadLogged(t *testing.T) {
+	var buf bytes.Buffer
+
+	for name, logger := range loggers(&buf) {
+		buf.Reset()
+		logCall(logger, "getBlock", &proto.GetBlockRequest{}, &proto.GetBlockResponse{Block: secret})
+
+		if !leaked(buf.String()) {
+			t.Fatalf("%s logger: %q", name, buf.String())
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+type stdLogger struct {
+	logger *log.Logger
+	level  Level
+}
+
+//Text records through standard library logger: "LEVEL message key=value ...", values with spaces are quoted
+func NewStdLogger(logger *log.Logger, level Level) Logger {
+	return &stdLogger{logger: logger, level: level}
+}
+
+func (s *stdLogger) Enabled(level Level) bool {
+	return level >= s.level
+}
+
+func (s *stdLogger) Log(level Level, message string, fields ...Field) {
+	if !s.Enabled(level) {
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.ToUpper(level.String()))
+	b.WriteByte(' ')
+	b.WriteString(message)
+
+	for _, field := range fields {
+		b.WriteByte(' ')
+		b.WriteString(field.Key)
+		b.WriteByte('=')
+		b.WriteString(textValue(field.Value))
+	}
+
+	s.logger.Println(b.String())
+}
+
+func textValue(value interface{}) string {
+	var text string
+
+	switch v := value.(type) {
+	case string:
+		text = v
+	case error:
+		text = v.Error()
+	case fmt.Stringer:
+		text = v.String()
+	case json.RawMessage:
+		text = string(v)
+	case []byte:
+		text = string(v)
+	default:
+		text = fmt.Sprint(v)
+	}
+
+	if text == "" || strings.ContainsAny(text, " \t\n\"=") {
+		return strconv.Quote(text)
+	}
+
+	return text
+}
@@ -0,0 +1,317 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//Latency buckets in seconds, from 5ms to 30s
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+const (
+	kindCounter   = "counter"
+	kindGauge     = "gauge"
+	kindHistogram = "histogram"
+)
+
+//Metrics exposed in Prometheus text format, safe for concurrent use.
+//Metric with same name is shared by all callers registering it
+type Registry struct {
+	sync.Mutex
+	families   map[string]*family
+	collectors []func()
+}
+
+type family struct {
+	name    string
+	help    string
+	kind    string
+	labels  []string
+	buckets []float64
+	series  map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	//Counter or gauge value, sum of histogram observations
+	value float64
+	//Cumulative counts of histogram buckets and count of observations
+	buckets []uint64
+	count   uint64
+}
+
+type CounterVec struct {
+	*family
+	registry *Registry
+}
+
+type GaugeVec struct {
+	*family
+	registry *Registry
+}
+
+type HistogramVec struct {
+	*family
+	registry *Registry
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		families: make(map[string]*family),
+	}
+}
+
+//Monotonic counter, labels are names of label values given to Add
+func (r *Registry) Counter(name string, help string, labels ...string) *CounterVec {
+	return &CounterVec{r.register(name, help, kindCounter, labels, nil), r}
+}
+
+func (r *Registry) Gauge(name string, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{r.register(name, help, kindGauge, labels, nil), r}
+}
+
+//Histogram with upper bounds of buckets in ascending order, +Inf bucket is implicit
+func (r *Registry) Histogram(name string, help string, buckets []float64, labels ...string) *HistogramVec {
+	return &HistogramVec{r.register(name, help, kindHistogram, labels, buckets), r}
+}
+
+//Run fn before each scrape, used for gauges computed from current state
+func (r *Registry) OnCollect(fn func()) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.collectors = append(r.collectors, fn)
+}
+
+//Registration of existing metric returns it, different type or labels is programming error
+func (r *Registry) register(name string, help string, kind string, labels []string, buckets []float64) *family {
+	r.Lock()
+	defer r.Unlock()
+
+	if f, ok := r.families[name]; ok {
+		if f.kind != kind || strings.Join(f.labels, ",") != strings.Join(labels, ",") {
+			panic(fmt.Sprintf("metrics: %s registered as %s with labels %v", name, f.kind, f.labels))
+		}
+
+		return f
+	}
+
+	f := &family{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+
+	r.families[name] = f
+
+	return f
+}
+
+//Series of label values, r.Mutex is held
+func (f *family) get(labelValues []string) *series {
+	if len(labelValues) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s expects labels %v, got %d values", f.name, f.labels, len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, "\xff")
+	s, ok := f.series[key]
+
+	if !ok {
+		s = &series{labelValues: append([]string(nil), labelValues...)}
+
+		if f.kind == kindHistogram {
+			s.buckets = make([]uint64, len(f.buckets))
+		}
+
+		f.series[key] = s
+	}
+
+	return s
+}
+
+func (c *CounterVec) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		return
+	}
+
+	c.registry.Lock()
+	defer c.registry.Unlock()
+
+	c.get(labelValues).value += value
+}
+
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	g.registry.Lock()
+	defer g.registry.Unlock()
+
+	g.get(labelValues).value = value
+}
+
+func (g *GaugeVec) Add(delta float64, labelValues ...string) {
+	g.registry.Lock()
+	defer g.registry.Unlock()
+
+	g.get(labelValues).value += delta
+}
+
+//Remove all series, e.g. before gauges of current nodes are set again
+func (g *GaugeVec) Reset() {
+	g.registry.Lock()
+	defer g.registry.Unlock()
+
+	g.series = make(map[string]*series)
+}
+
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	h.registry.Lock()
+	defer h.registry.Unlock()
+
+	s := h.get(labelValues)
+	s.value += value
+	s.count++
+
+	for i, bound := range h.buckets {
+		if value <= bound {
+			s.buckets[i]++
+		}
+	}
+}
+
+//Write metrics in Prometheus text exposition format
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.Lock()
+	collectors := append([]func(){}, r.collectors...)
+	r.Unlock()
+
+	for _, collect := range collectors {
+		collect()
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	names := make([]string, 0, len(r.families))
+
+	for name := range r.families {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	counter := &countingWriter{w: w}
+	buf := bufio.NewWriter(counter)
+
+	for _, name := range names {
+		r.families[name].write(buf)
+	}
+
+	err := buf.Flush()
+
+	return counter.n, err
+}
+
+func (f *family) write(w *bufio.Writer) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.name, escapeHelp(f.help), f.name, f.kind)
+
+	keys := make([]string, 0, len(f.series))
+
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := f.series[key]
+
+		if f.kind != kindHistogram {
+			_, _ = fmt.Fprintf(w, "%s%s %s\n", f.name, formatLabels(f.labels, s.labelValues, "", ""), formatFloat(s.value))
+
+			continue
+		}
+
+		for i, bound := range f.buckets {
+			_, _ = fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(f.labels, s.labelValues, "le", formatFloat(bound)), s.buckets[i])
+		}
+
+		_, _ = fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(f.labels, s.labelValues, "le", "+Inf"), s.count)
+		_, _ = fmt.Fprintf(w, "%s_sum%s %s\n", f.name, formatLabels(f.labels, s.labelValues, "", ""), formatFloat(s.value))
+		_, _ = fmt.Fprintf(w, "%s_count%s %d\n", f.name, formatLabels(f.labels, s.labelValues, "", ""), s.count)
+	}
+}
+
+//Endpoint for Prometheus scraper
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = r.WriteTo(w)
+	})
+}
+
+func formatLabels(names []string, values []string, extraName string, extraValue string) string {
+	if len(names) == 0 && extraName == "" {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(names)+1)
+
+	for i, name := range names {
+		pairs = append(pairs, name+`="`+escapeLabel(values[i])+`"`)
+	}
+
+	if extraName != "" {
+		pairs = append(pairs, extraName+`="`+extraValue+`"`)
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+
+	return n, err
+}
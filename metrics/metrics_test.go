@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"math"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+//Registry covering escaping, special values and histogram series
+func testRegistry() *Registry {
+	r := NewRegistry()
+
+	requests := r.Counter("dapi_requests_total", "Requests to nodes.\nBy method and code", "method", "code")
+	requests.Inc("getStatus", "OK")
+	requests.Add(2, "getBlock", "Unavailable")
+	requests.Add(-1, "getBlock", "Unavailable")
+
+	nodes := r.Gauge("dapi_nodes", `Known nodes, path C:\dapi`, "address")
+	nodes.Set(1, `quoted "node"`)
+	nodes.Set(math.Inf(1), "back\\slash\nnewline")
+	nodes.Set(math.NaN(), "nan")
+
+	r.Gauge("dapi_up", "Gateway is up").Set(1)
+
+	latency := r.Histogram("dapi_request_duration_seconds", "Latency of requests", []float64{0.1, 0.5, 1}, "method")
+	latency.Observe(0.05, "getStatus")
+	latency.Observe(0.1, "getStatus")
+	latency.Observe(0.7, "getStatus")
+	latency.Observe(3, "getStatus")
+
+	//Family without series has HELP and TYPE lines only
+	r.Counter("dapi_errors_total", "Errors", "code")
+
+	return r
+}
+
+func TestGolden(t *testing.T) {
+	r := testRegistry()
+	var buf bytes.Buffer
+	n, err := r.WriteTo(&buf)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != int64(buf.Len()) {
+		t.Fatalf("%d bytes written, %d counted", buf.Len(), n)
+	}
+
+	golden := filepath.Join("testdata", "registry.golden")
+
+	if *update {
+		err = ioutil.WriteFile(golden, buf.Bytes(), 0644)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := ioutil.ReadFile(golden)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Fatalf("output differs from %s:\n%s", golden, buf.String())
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := testRegistry()
+	collected := 0
+	r.OnCollect(func() {
+		collected++
+	})
+
+	recorder := httptest.NewRecorder()
+	r.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	if recorder.Header().Get("Content-Type") != "text/plain; version=0.0.4; charset=utf-8" || collected != 1 {
+		t.Fatalf("content type %s, collected %d times", recorder.Header().Get("Content-Type"), collected)
+	}
+
+	expected, err := ioutil.ReadFile(filepath.Join("testdata", "registry.golden"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if recorder.Body.String() != string(expected) {
+		t.Fatalf("body:\n%s", recorder.Body.String())
+	}
+}
+
+func TestRegisterMismatch(t *testing.T) {
+	r := NewRegistry()
+
+	if r.Counter("dapi_total", "Total", "code").family != r.Counter("dapi_total", "Total", "code").family {
+		t.Fatal("metric is registered twice")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("gauge registered under name of counter")
+		}
+	}()
+
+	r.Gauge("dapi_total", "Total", "code")
+}
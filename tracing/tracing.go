@@ -0,0 +1,275 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+//Header of HTTP requests and key of gRPC metadata carrying span context, W3C Trace Context format
+const TraceparentKey = "traceparent"
+
+//Trace flag set when caller records trace
+const FlagSampled byte = 0x01
+
+type TraceID [16]byte
+
+type SpanID [8]byte
+
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+//Identity of span propagated to callee
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	//Trace flags, e.g. FlagSampled, are passed to children unchanged
+	Flags byte
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{} && sc.SpanID != SpanID{}
+}
+
+//Value of traceparent header, e.g. 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func (sc SpanContext) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", sc.TraceID, sc.SpanID, sc.Flags)
+}
+
+func ParseTraceparent(value string) (SpanContext, error) {
+	var sc SpanContext
+	parts := strings.Split(strings.TrimSpace(value), "-")
+
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return sc, fmt.Errorf("invalid traceparent %s", value)
+	}
+
+	traceID, err := hex.DecodeString(parts[1])
+
+	if err != nil || len(traceID) != len(sc.TraceID) {
+		return sc, fmt.Errorf("invalid trace id %s", parts[1])
+	}
+
+	spanID, err := hex.DecodeString(parts[2])
+
+	if err != nil || len(spanID) != len(sc.SpanID) {
+		return sc, fmt.Errorf("invalid span id %s", parts[2])
+	}
+
+	flags, err := hex.DecodeString(parts[3])
+
+	if err != nil || len(flags) != 1 {
+		return sc, fmt.Errorf("invalid trace flags %s", parts[3])
+	}
+
+	copy(sc.TraceID[:], traceID)
+	copy(sc.SpanID[:], spanID)
+	sc.Flags = flags[0]
+
+	if !sc.IsValid() {
+		return sc, errors.New("zero trace or span id")
+	}
+
+	return sc, nil
+}
+
+//Finished span passed to exporter
+type SpanData struct {
+	Name       string
+	Context    SpanContext
+	Parent     SpanID
+	Start      time.Time
+	End        time.Time
+	Attributes map[string]interface{}
+	//Empty for successful operation
+	Error string
+}
+
+func (d SpanData) Duration() time.Duration {
+	return d.End.Sub(d.Start)
+}
+
+//Receives finished spans, e.g. sends them to collector. Must be safe for concurrent use and should not block
+type Exporter interface {
+	ExportSpan(span SpanData)
+}
+
+//Starts spans and passes them to exporter when they are finished.
+//Nil tracer and tracer without exporter are no-op, their spans are nil
+type Tracer struct {
+	exporter Exporter
+}
+
+func NewTracer(exporter Exporter) *Tracer {
+	return &Tracer{exporter: exporter}
+}
+
+//Tracer recording nothing
+func Nop() *Tracer {
+	return &Tracer{}
+}
+
+//Span of operation, methods of nil span do nothing
+type Span struct {
+	sync.Mutex
+	tracer *Tracer
+	data   SpanData
+	ended  bool
+}
+
+type spanKey struct{}
+
+type remoteKey struct{}
+
+//Start child of span in ctx (or of remote parent, see WithRemoteParent), returned ctx carries new span
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, *Span) {
+	if t == nil || t.exporter == nil {
+		return ctx, nil
+	}
+
+	span := &Span{
+		tracer: t,
+		data: SpanData{
+			Name:       name,
+			Start:      time.Now(),
+			Attributes: make(map[string]interface{}),
+		},
+	}
+
+	if parent, ok := SpanContextFromContext(ctx); ok {
+		span.data.Context.TraceID = parent.TraceID
+		span.data.Context.Flags = parent.Flags
+		span.data.Parent = parent.SpanID
+	} else {
+		_, _ = rand.Read(span.data.Context.TraceID[:])
+		//Trace started here is recorded
+		span.data.Context.Flags = FlagSampled
+	}
+
+	_, _ = rand.Read(span.data.Context.SpanID[:])
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+//Context continuing trace of caller, e.g. parsed from incoming traceparent
+func WithRemoteParent(ctx context.Context, parent SpanContext) context.Context {
+	return context.WithValue(ctx, remoteKey{}, parent)
+}
+
+//Current span of ctx, nil if there is none
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+
+	return span
+}
+
+//Context of current span or remote parent
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.data.Context, true
+	}
+
+	parent, ok := ctx.Value(remoteKey{}).(SpanContext)
+
+	return parent, ok && parent.IsValid()
+}
+
+func (s *Span) Context() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+
+	return s.data.Context
+}
+
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.data.Attributes[key] = value
+}
+
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.data.Error = err.Error()
+}
+
+//Export span, following calls do nothing
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	s.Lock()
+
+	if s.ended {
+		s.Unlock()
+
+		return
+	}
+
+	s.ended = true
+	s.data.End = time.Now()
+	data := s.data
+	data.Attributes = make(map[string]interface{}, len(s.data.Attributes))
+
+	for key, value := range s.data.Attributes {
+		data.Attributes[key] = value
+	}
+
+	s.Unlock()
+
+	s.tracer.exporter.ExportSpan(data)
+}
+
+//Exporter keeping finished spans in memory, e.g. for tests
+type Recorder struct {
+	sync.Mutex
+	spans []SpanData
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) ExportSpan(span SpanData) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.spans = append(r.spans, span)
+}
+
+//Finished spans in order of their end
+func (r *Recorder) Spans() []SpanData {
+	r.Lock()
+	defer r.Unlock()
+
+	return append([]SpanData(nil), r.spans...)
+}
+
+func (r *Recorder) Reset() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.spans = nil
+}
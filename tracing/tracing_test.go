@@ -0,0 +1,126 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		value   string
+		traceID string
+		spanID  string
+		flags   byte
+		invalid bool
+	}{
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", traceID: "4bf92f3577b34da6a3ce929d0e0e4736", spanID: "00f067aa0ba902b7", flags: FlagSampled},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", traceID: "4bf92f3577b34da6a3ce929d0e0e4736", spanID: "00f067aa0ba902b7"},
+		{value: " 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-03 ", traceID: "4bf92f3577b34da6a3ce929d0e0e4736", spanID: "00f067aa0ba902b7", flags: 0x03},
+		{value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", invalid: true},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01", invalid: true},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902-01", invalid: true},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-x1", invalid: true},
+		{value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", invalid: true},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", invalid: true},
+		{value: "00-4bf92f3577b34da6a3ce929d0e0e4736", invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			sc, err := ParseTraceparent(test.value)
+
+			if test.invalid {
+				if err == nil {
+					t.Fatalf("parsed %+v", sc)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sc.TraceID.String() != test.traceID || sc.SpanID.String() != test.spanID || sc.Flags != test.flags {
+				t.Fatalf("parsed %s %s %02x", sc.TraceID, sc.SpanID, sc.Flags)
+			}
+
+			//Sampled flag of caller is kept
+			if again, _ := ParseTraceparent(sc.Traceparent()); again != sc {
+				t.Fatalf("traceparent %s", sc.Traceparent())
+			}
+		})
+	}
+}
+
+func TestSpans(t *testing.T) {
+	recorder := NewRecorder()
+	tracer := NewTracer(recorder)
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	child.SetAttribute("attempts", 2)
+	child.SetError(errors.New("unavailable"))
+	child.End()
+	child.End()
+	parent.End()
+
+	spans := recorder.Spans()
+
+	if len(spans) != 2 || spans[0].Name != "child" || spans[1].Name != "parent" {
+		t.Fatalf("spans %+v", spans)
+	}
+
+	if spans[1].Context.Flags != FlagSampled || spans[1].Parent != (SpanID{}) {
+		t.Fatalf("root span %+v", spans[1])
+	}
+
+	if spans[0].Context.TraceID != spans[1].Context.TraceID || spans[0].Parent != spans[1].Context.SpanID || spans[0].Context.SpanID == spans[1].Context.SpanID {
+		t.Fatalf("child %+v is not linked to parent %+v", spans[0].Context, spans[1].Context)
+	}
+
+	if spans[0].Attributes["attempts"] != 2 || spans[0].Error != "unavailable" || spans[0].Duration() < 0 {
+		t.Fatalf("child %+v", spans[0])
+	}
+
+	recorder.Reset()
+
+	if len(recorder.Spans()) != 0 {
+		t.Fatal("spans after reset")
+	}
+}
+
+func TestRemoteParent(t *testing.T) {
+	recorder := NewRecorder()
+	remote, err := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, span := NewTracer(recorder).Start(WithRemoteParent(context.Background(), remote), "call")
+	span.End()
+
+	sc := span.Context()
+
+	if sc.TraceID != remote.TraceID || recorder.Spans()[0].Parent != remote.SpanID {
+		t.Fatalf("span %+v is not child of remote %+v", sc, remote)
+	}
+
+	//Caller did not sample trace, callee is told the same
+	if sc.Traceparent() != "00-4bf92f3577b34da6a3ce929d0e0e4736-"+sc.SpanID.String()+"-00" {
+		t.Fatalf("traceparent %s", sc.Traceparent())
+	}
+}
+
+func TestNopTracer(t *testing.T) {
+	ctx, span := Nop().Start(context.Background(), "call")
+
+	if span != nil || SpanFromContext(ctx) != nil {
+		t.Fatal("no-op tracer started span")
+	}
+
+	span.SetAttribute("key", 1)
+	span.End()
+}